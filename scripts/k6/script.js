@@ -134,24 +134,23 @@ function getRandomUser() {
     return TEST_USERS[Math.floor(Math.random() * TEST_USERS.length)];
 }
 
-function getUser() {
-    const user = getRandomUser();
-    const payload = JSON.stringify({user_id: user.userId});
+function login(user) {
+    const payload = JSON.stringify({user_id: user.userId, pin: user.pin});
     const params = {
         headers: {'Content-Type': 'application/json'}
     };
 
     const start = Date.now();
-    const res = http.post(`${BASE_URL}/api/v1/get-user-by-id`, payload, params);
+    const res = http.post(`${BASE_URL}/api/v1/login`, payload, params);
     const duration = Date.now() - start;
-    getUserResponseTime.add(duration);
+    loginResponseTime.add(duration);
 
     const ok = check(res, {
-        'get_user status is 200': (r) => r.status === 200,
-        'get_user returns user_info': (r) => {
+        'login status is 200': (r) => r.status === 200,
+        'login returns token': (r) => {
             try {
                 const data = JSON.parse(r.body);
-                return data.data && data.data.user_info.name;
+                return data.data && data.data.token;
             } catch (_) {
                 return false;
             }
@@ -163,27 +162,31 @@ function getUser() {
         return null;
     }
 
-    successfulGetUser.add(1);
-    return user;
+    successfulLogin.add(1);
+    const data = JSON.parse(res.body).data;
+    return {token: data.token};
 }
 
-function login(user) {
-    const payload = JSON.stringify({user_id: user.userId, pin: user.pin});
+function getMe(token) {
+    if (!token?.token) return false;
+
     const params = {
-        headers: {'Content-Type': 'application/json'}
+        headers: {
+            Authorization: `Bearer ${token.token}`,
+        },
     };
 
     const start = Date.now();
-    const res = http.post(`${BASE_URL}/api/v1/login`, payload, params);
+    const res = http.get(`${BASE_URL}/api/v1/me`, params);
     const duration = Date.now() - start;
-    loginResponseTime.add(duration);
+    getUserResponseTime.add(duration);
 
     const ok = check(res, {
-        'login status is 200': (r) => r.status === 200,
-        'login returns token': (r) => {
+        'get_me status is 200': (r) => r.status === 200,
+        'get_me returns name': (r) => {
             try {
                 const data = JSON.parse(r.body);
-                return data.data && data.data.token;
+                return data.data && data.data.name;
             } catch (_) {
                 return false;
             }
@@ -192,12 +195,11 @@ function login(user) {
 
     errorRate.add(!ok);
     if (!ok) {
-        return null;
+        return false;
+    } else {
+        successfulGetUser.add(1);
+        return true;
     }
-
-    successfulLogin.add(1);
-    const data = JSON.parse(res.body).data;
-    return {token: data.token};
 }
 
 function getUserAccounts(token) {
@@ -341,16 +343,17 @@ function getUserBanners(token) {
 }
 
 export default function () {
-    const user = getUser();
+    const user = getRandomUser();
     const token = login(user);
     if (token) {
+        const getMeSuccess = getMe(token);
         const getUserAccountsSuccess = getUserAccounts(token);
         const getUseDebitCardsSuccess = getUseDebitCards(token);
         const getUserSavedAccountsSuccess = getUserSavedAccounts(token);
         const getUserBannersSuccess = getUserBanners(token);
 
         // Count as complete transaction only if all api calls succeed
-        if (getUserAccountsSuccess && getUseDebitCardsSuccess && getUserSavedAccountsSuccess && getUserBannersSuccess) {
+        if (getMeSuccess && getUserAccountsSuccess && getUseDebitCardsSuccess && getUserSavedAccountsSuccess && getUserBannersSuccess) {
             transactionCounter.add(1);
         }
     }
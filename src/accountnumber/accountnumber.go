@@ -0,0 +1,126 @@
+package accountnumber
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	CheckDigitNone  = "none"
+	CheckDigitLuhn  = "luhn"
+	CheckDigitMod11 = "mod11"
+)
+
+var (
+	ErrMalformed         = errors.New("account number must only contain digits, spaces and dashes")
+	ErrUnknownFormat     = errors.New("account number does not match any supported bank")
+	ErrInvalidCheckDigit = errors.New("account number check digit is invalid")
+)
+
+// Format is the account number format of one issuer, numbers are matched on Prefix and Length after
+// separators are removed and the last digit is verified with the CheckDigit rule
+type Format struct {
+	Issuer     string `mapstructure:"Issuer"`
+	Prefix     string `mapstructure:"Prefix"`
+	Length     int    `mapstructure:"Length"`
+	CheckDigit string `mapstructure:"CheckDigit"`
+}
+
+// DefaultFormats are the issuers accepted for external payees until they are set from config
+var DefaultFormats = []Format{
+	{Issuer: "Lumen Bank", Prefix: "1", Length: 10, CheckDigit: CheckDigitLuhn},
+	{Issuer: "Harbor Bank", Prefix: "2", Length: 12, CheckDigit: CheckDigitMod11},
+}
+
+// Formats is set from config at start up
+var Formats = DefaultFormats
+
+// Normalize removes the spaces and dashes people use to group the digits, e.g. 568-2-90992 becomes 568290992
+func Normalize(number string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(number)
+}
+
+// Digits normalizes the number and makes sure nothing but digits is left
+func Digits(number string) (string, error) {
+	digits := Normalize(number)
+	if digits == "" || strings.TrimLeft(digits, "0123456789") != "" {
+		return "", ErrMalformed
+	}
+	return digits, nil
+}
+
+// Identify returns the format of the first issuer that accepts the number
+func Identify(formats []Format, number string) (Format, error) {
+	digits, err := Digits(number)
+	if err != nil {
+		return Format{}, err
+	}
+
+	err = ErrUnknownFormat
+	for _, format := range formats {
+		if len(digits) != format.Length || !strings.HasPrefix(digits, format.Prefix) {
+			continue
+		}
+		if !validCheckDigit(format.CheckDigit, digits) {
+			err = ErrInvalidCheckDigit
+			continue
+		}
+		return format, nil
+	}
+	return Format{}, err
+}
+
+// ValidateFormats rejects formats that could never match a number, it is meant for config at start up
+func ValidateFormats(formats []Format) error {
+	for _, format := range formats {
+		if format.Issuer == "" || format.Length <= len(format.Prefix) {
+			return fmt.Errorf("account number format %+v needs an issuer and a length longer than its prefix", format)
+		}
+		switch format.CheckDigit {
+		case CheckDigitNone, CheckDigitLuhn, CheckDigitMod11:
+		default:
+			return fmt.Errorf("account number format of %s has unknown check digit %q", format.Issuer, format.CheckDigit)
+		}
+	}
+	return nil
+}
+
+func validCheckDigit(rule, digits string) bool {
+	switch rule {
+	case CheckDigitNone:
+		return true
+	case CheckDigitLuhn:
+		return luhnValid(digits)
+	case CheckDigitMod11:
+		return mod11Valid(digits)
+	}
+	return false
+}
+
+func luhnValid(digits string) bool {
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		digit := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return sum%10 == 0
+}
+
+// mod11Valid weights the digits before the check digit 2, 3, 4, ... from the right. A remainder that would need
+// a check digit of 10 is never issued.
+func mod11Valid(digits string) bool {
+	body, check := digits[:len(digits)-1], int(digits[len(digits)-1]-'0')
+	sum := 0
+	for i := 0; i < len(body); i++ {
+		sum += int(body[len(body)-1-i]-'0') * (i + 2)
+	}
+	expected := (11 - sum%11) % 11
+	return expected < 10 && expected == check
+}
@@ -0,0 +1,63 @@
+package accountnumber
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIdentify_KnownIssuers(t *testing.T) {
+	for number, issuer := range map[string]string{
+		"1234567897":      "Lumen Bank",
+		"123-4-56789-7":   "Lumen Bank",
+		"2012 3456 7898":  "Harbor Bank",
+		"20-1234-56789-8": "Harbor Bank",
+	} {
+		format, err := Identify(DefaultFormats, number)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", number, err)
+		}
+		if format.Issuer != issuer {
+			t.Fatalf("%s: unexpected issuer: got %s, want %s", number, format.Issuer, issuer)
+		}
+	}
+}
+
+func TestIdentify_Rejects(t *testing.T) {
+	for number, want := range map[string]error{
+		"":              ErrMalformed,
+		"12345x7897":    ErrMalformed,
+		"1234567890":    ErrInvalidCheckDigit,
+		"201234567890":  ErrInvalidCheckDigit,
+		"123456789":     ErrUnknownFormat,
+		"9234567897":    ErrUnknownFormat,
+		"1234567897123": ErrUnknownFormat,
+	} {
+		if _, err := Identify(DefaultFormats, number); !errors.Is(err, want) {
+			t.Fatalf("%q: expected %v, got %v", number, want, err)
+		}
+	}
+}
+
+func TestIdentify_NoCheckDigit(t *testing.T) {
+	formats := []Format{{Issuer: "Plain Bank", Prefix: "9", Length: 6, CheckDigit: CheckDigitNone}}
+
+	if _, err := Identify(formats, "912345"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateFormats(t *testing.T) {
+	if err := ValidateFormats(DefaultFormats); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, format := range []Format{
+		{Prefix: "1", Length: 10, CheckDigit: CheckDigitLuhn},
+		{Issuer: "Short Bank", Prefix: "123", Length: 3, CheckDigit: CheckDigitLuhn},
+		{Issuer: "Odd Bank", Prefix: "1", Length: 10, CheckDigit: "mod97"},
+	} {
+		if err := ValidateFormats([]Format{format}); err == nil {
+			t.Fatalf("expected error for %+v", format)
+		}
+	}
+}
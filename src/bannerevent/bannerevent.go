@@ -0,0 +1,141 @@
+package bannerevent
+
+import (
+	"assignment/entity"
+	"assignment/logger"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	TypeImpression = "impression"
+	TypeClick      = "click"
+	TypeDismiss    = "dismiss"
+
+	flushTimeout = 10 * time.Second
+)
+
+var (
+	ErrBufferFull = errors.New("banner event buffer is full")
+	ErrClosed     = errors.New("banner event writer is closed")
+)
+
+// Recorder is set at start up when the database is enabled
+var Recorder RecorderIface
+
+// RecorderIface takes banner events off the request path, Record must not block
+type RecorderIface interface {
+	Record(event entity.BannerEvents) error
+}
+
+// FlushFunc persists one batch of events
+type FlushFunc func(ctx context.Context, events []entity.BannerEvents) error
+
+// BufferedWriter queues events in memory and writes them in batches, whenever batchSize events are waiting or
+// interval has passed. A batch that fails to write is logged and dropped, events are only used for reporting.
+type BufferedWriter struct {
+	flush     FlushFunc
+	batchSize int
+	interval  time.Duration
+
+	events chan entity.BannerEvents
+	closed atomic.Bool
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+func NewBufferedWriter(flush FlushFunc, bufferSize, batchSize int, interval time.Duration) *BufferedWriter {
+	return &BufferedWriter{
+		flush:     flush,
+		batchSize: batchSize,
+		interval:  interval,
+		events:    make(chan entity.BannerEvents, bufferSize),
+		done:      make(chan struct{}),
+	}
+}
+
+// Record queues the event, it fails with ErrBufferFull instead of waiting when the writer falls behind
+func (writer *BufferedWriter) Record(event entity.BannerEvents) error {
+	if writer.closed.Load() {
+		return ErrClosed
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	select {
+	case writer.events <- event:
+		return nil
+	default:
+		return ErrBufferFull
+	}
+}
+
+// Start runs the writer until Close is called
+func (writer *BufferedWriter) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	writer.cancel = cancel
+	go writer.run(ctx)
+}
+
+// Close stops taking events and waits until the queued ones are written
+func (writer *BufferedWriter) Close() {
+	writer.once.Do(func() {
+		writer.closed.Store(true)
+		writer.cancel()
+		<-writer.done
+	})
+}
+
+func (writer *BufferedWriter) run(ctx context.Context) {
+	defer close(writer.done)
+
+	ticker := time.NewTicker(writer.interval)
+	defer ticker.Stop()
+
+	batch := make([]entity.BannerEvents, 0, writer.batchSize)
+	add := func(event entity.BannerEvents) {
+		batch = append(batch, event)
+		if len(batch) >= writer.batchSize {
+			writer.write(batch)
+			batch = batch[:0]
+		}
+	}
+
+	for {
+		select {
+		case event := <-writer.events:
+			add(event)
+		case <-ticker.C:
+			if len(batch) > 0 {
+				writer.write(batch)
+				batch = batch[:0]
+			}
+		case <-ctx.Done():
+			for {
+				select {
+				case event := <-writer.events:
+					add(event)
+				default:
+					if len(batch) > 0 {
+						writer.write(batch)
+					}
+					return
+				}
+			}
+		}
+	}
+}
+
+func (writer *BufferedWriter) write(batch []entity.BannerEvents) {
+	// The run context is already cancelled on Close, the last batches get their own deadline
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+
+	if err := writer.flush(ctx, batch); err != nil {
+		logger.Logger.Errorf("write %d banner events failed because: %s", len(batch), err)
+	}
+}
@@ -0,0 +1,85 @@
+package bannerevent
+
+import (
+	"assignment/entity"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]entity.BannerEvents
+}
+
+func (recorder *batchRecorder) flush(_ context.Context, events []entity.BannerEvents) error {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.batches = append(recorder.batches, append([]entity.BannerEvents(nil), events...))
+	return nil
+}
+
+func (recorder *batchRecorder) sizes() []int {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	sizes := make([]int, 0, len(recorder.batches))
+	for _, batch := range recorder.batches {
+		sizes = append(sizes, len(batch))
+	}
+	return sizes
+}
+
+func TestBufferedWriter_FlushesFullBatches(t *testing.T) {
+	recorder := &batchRecorder{}
+	writer := NewBufferedWriter(recorder.flush, 10, 2, time.Hour)
+	writer.Start()
+
+	for i := 0; i < 4; i++ {
+		if err := writer.Record(entity.BannerEvents{BannerId: "banner-1", EventType: TypeImpression}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(recorder.sizes()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if sizes := recorder.sizes(); len(sizes) != 2 || sizes[0] != 2 || sizes[1] != 2 {
+		t.Fatalf("expected two batches of 2, got %v", sizes)
+	}
+	writer.Close()
+}
+
+func TestBufferedWriter_CloseWritesQueuedEvents(t *testing.T) {
+	recorder := &batchRecorder{}
+	writer := NewBufferedWriter(recorder.flush, 10, 5, time.Hour)
+	writer.Start()
+
+	for i := 0; i < 3; i++ {
+		if err := writer.Record(entity.BannerEvents{BannerId: "banner-1", EventType: TypeClick}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	writer.Close()
+
+	if sizes := recorder.sizes(); len(sizes) != 1 || sizes[0] != 3 {
+		t.Fatalf("expected one batch of 3, got %v", sizes)
+	}
+	if err := writer.Record(entity.BannerEvents{BannerId: "banner-1"}); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected ErrClosed, got %v", err)
+	}
+}
+
+func TestBufferedWriter_DoesNotBlockWhenFull(t *testing.T) {
+	// Not started, nothing takes events off the buffer
+	writer := NewBufferedWriter((&batchRecorder{}).flush, 1, 1, time.Hour)
+
+	if err := writer.Record(entity.BannerEvents{BannerId: "banner-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := writer.Record(entity.BannerEvents{BannerId: "banner-1"}); !errors.Is(err, ErrBufferFull) {
+		t.Fatalf("expected ErrBufferFull, got %v", err)
+	}
+}
@@ -10,14 +10,20 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"assignment/bannerevent"
 	"assignment/datastore/mysql"
 	"assignment/global"
 	"assignment/interface/http"
+	"assignment/jwt"
 	"assignment/logger"
 	zaplogger "assignment/logger/zap"
+	model_mysql "assignment/model/mysql"
+	"assignment/notifier"
+	lognotifier "assignment/notifier/log"
 )
 
 var wg sync.WaitGroup
+var bannerEventWriter *bannerevent.BufferedWriter
 var configFile string
 var enableDatabase bool
 var enableInterface bool
@@ -43,11 +49,34 @@ func initComponent() {
 	// Init Logger
 	logger.Logger = zaplogger.NewLogger()
 
+	// Init Notifier
+	notifier.Notifier = lognotifier.NewNotifier()
+
 	// Init Global Variable
 	global.InitVariable()
 
 	// Init Timezone
 	initTimezone()
+
+	// Init Access Token Signer
+	if global.AuthMode == global.AUTH_MODE_JWT {
+		initSigner()
+	}
+}
+
+func initSigner() {
+	var keys []jwt.Key
+	if err := viper.UnmarshalKey("Auth.Jwt.Keys", &keys); err != nil {
+		logger.Logger.Errorf("unable to read jwt signing keys because: %s", err)
+		os.Exit(1)
+	}
+
+	signer, err := jwt.NewSigner(viper.GetString("Auth.Jwt.ActiveKid"), keys)
+	if err != nil {
+		logger.Logger.Errorf("unable to init jwt signer because: %s", err)
+		os.Exit(1)
+	}
+	jwt.DefaultSigner = signer
 }
 
 func initConfig() {
@@ -79,6 +108,20 @@ func initMysql() {
 	mysql.InitDatabase()
 }
 
+// initBannerEventWriter needs the database, it has to be closed before the database so queued events are written
+func initBannerEventWriter() {
+	logger.Logger.Info("initializing banner event writer")
+	bannerEventWriter = bannerevent.NewBufferedWriter(model_mysql.NewModelRepository().CreateBannerEvents,
+		global.BannerEventBufferSize, global.BannerEventBatchSize, global.BannerEventFlushInterval)
+	bannerEventWriter.Start()
+	bannerevent.Recorder = bannerEventWriter
+}
+
+func shutdownBannerEventWriter() {
+	logger.Logger.Info("shutting down banner event writer")
+	bannerEventWriter.Close()
+}
+
 func shutdownMysql() {
 	logger.Logger.Info("shutting down mysql")
 	mysql.ShutdownDatabase()
@@ -29,22 +29,21 @@ func initListenOsSignal() {
 						os.Exit(1)
 					}
 
-					go func() {
-						if enableDatabase {
-							shutdownMysql()
-						}
-					}()
+					logger.Logger.Info("signal SIGKILL caught. shutting down")
+					logger.Logger.Info("catching SIGKILL one more time will forcefully exit")
 
+					// Stop taking requests first, then write the queued banner events while the database is still
+					// open, and only close the database at the end
 					go func() {
 						if enableInterface {
 							http.ShutdownHttpServer()
 						}
+						if enableDatabase {
+							shutdownBannerEventWriter()
+							shutdownMysql()
+						}
+						wg.Done()
 					}()
-
-					logger.Logger.Info("signal SIGKILL caught. shutting down")
-					logger.Logger.Info("catching SIGKILL one more time will forcefully exit")
-
-					wg.Done()
 				}
 			}
 			close(chanOsSignal)
@@ -65,6 +64,7 @@ var ServeCmd = &cobra.Command{
 		// Init Database
 		if enableDatabase {
 			initMysql()
+			initBannerEventWriter()
 		}
 
 		// Init Interface
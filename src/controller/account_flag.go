@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"assignment/entity"
+	"assignment/global"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"slices"
+	"strings"
+	"time"
+)
+
+type GetAccountFlagsInput struct {
+	AccountId string `query:"account_id" validate:"required,max=50"`
+}
+
+type AddAccountFlagInput struct {
+	AccountId string     `json:"account_id" validate:"required,max=50"`
+	FlagType  string     `json:"flag_type" validate:"required,max=50"`
+	FlagValue string     `json:"flag_value" validate:"required,max=30"`
+	ExpiredAt *time.Time `json:"expired_at"`
+}
+
+type UpdateAccountFlagInput struct {
+	FlagValue string     `json:"flag_value" validate:"required,max=30"`
+	ExpiredAt *time.Time `json:"expired_at"`
+}
+
+func invalidAccountFlag(reason global.Reason) error {
+	return global.NewSystemError(global.InvalidAccountFlag, reason)
+}
+
+// checkAccountFlag looks the type up in global.AccountFlagTypes and validates the value against its schema
+func checkAccountFlag(flagType string, value string, expiredAt *time.Time, now time.Time) error {
+	schema, ok := global.AccountFlagTypes[flagType]
+	if !ok {
+		types := make([]string, 0, len(global.AccountFlagTypes))
+		for registered := range global.AccountFlagTypes {
+			types = append(types, registered)
+		}
+		slices.Sort(types)
+		return invalidAccountFlag(global.NewReason(global.ReasonUnknownFlagType, strings.Join(types, ", ")))
+	}
+	if err := validator.New().Var(value, schema); err != nil {
+		return invalidAccountFlag(global.NewReason(global.ReasonFlagValueMismatch, flagType, schema))
+	}
+	if expiredAt != nil && !expiredAt.After(now) {
+		return invalidAccountFlag(global.NewReason(global.ReasonNotInFuture, "expired_at"))
+	}
+	return nil
+}
+
+func accountFlagError(err error) error {
+	var code int64
+	switch {
+	case errors.Is(err, model_mysql.ErrAccountNotFound):
+		code = global.AccountNotFound
+	case errors.Is(err, model_mysql.ErrAccountFlagNotFound):
+		code = global.AccountFlagNotFound
+	case errors.Is(err, model_mysql.ErrAccountFlagExists):
+		code = global.AccountFlagExists
+	case errors.Is(err, model_mysql.ErrAccountFlagExpired):
+		return invalidAccountFlag(global.NewReason(global.ReasonExpiredFlagUnchanged))
+	default:
+		return global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+	return global.NewSystemError(code)
+}
+
+// GetAccountFlags lists the flags of any account, including the expired ones, for support staff
+func (controller Controller) GetAccountFlags(ctx context.Context, input GetAccountFlagsInput) ([]entity.AccountFlags, error) {
+	controller.Logger.Infof("start get account flags of account %s", input.AccountId)
+
+	flags, err := controller.ModelRepository.GetAccountFlags(ctx, input.AccountId)
+	if err != nil {
+		controller.Logger.Errorf("get account flags failed because: %s", err.Error())
+		return nil, accountFlagError(err)
+	}
+
+	controller.Logger.Info("get account flags completed")
+	return flags, nil
+}
+
+func (controller Controller) AddAccountFlag(ctx context.Context, input AddAccountFlagInput) (entity.AccountFlags, error) {
+	controller.Logger.Infof("start add %s flag to account %s", input.FlagType, input.AccountId)
+
+	if err := checkAccountFlag(input.FlagType, input.FlagValue, input.ExpiredAt, time.Now()); err != nil {
+		return entity.AccountFlags{}, err
+	}
+
+	flag, err := controller.ModelRepository.CreateAccountFlag(ctx, entity.AccountFlags{
+		AccountId: input.AccountId,
+		FlagType:  input.FlagType,
+		FlagValue: input.FlagValue,
+		ExpiredAt: input.ExpiredAt,
+	})
+	if err != nil {
+		controller.Logger.Errorf("add account flag failed because: %s", err.Error())
+		return flag, accountFlagError(err)
+	}
+
+	controller.Logger.Info("add account flag completed")
+	return flag, nil
+}
+
+// UpdateAccountFlag replaces the value and expiry of a flag, the type of a flag cannot change and an expired flag
+// cannot be reactivated
+func (controller Controller) UpdateAccountFlag(ctx context.Context, flagId int, input UpdateAccountFlagInput) (entity.AccountFlags, error) {
+	controller.Logger.Infof("start update account flag %d", flagId)
+
+	flag, err := controller.ModelRepository.GetAccountFlag(ctx, flagId)
+	if err != nil {
+		controller.Logger.Errorf("get account flag failed because: %s", err.Error())
+		return flag, accountFlagError(err)
+	}
+	if err := checkAccountFlag(flag.FlagType, input.FlagValue, input.ExpiredAt, time.Now()); err != nil {
+		return entity.AccountFlags{}, err
+	}
+
+	flag, err = controller.ModelRepository.UpdateAccountFlag(ctx, flagId, input.FlagValue, input.ExpiredAt)
+	if err != nil {
+		controller.Logger.Errorf("update account flag failed because: %s", err.Error())
+		return flag, accountFlagError(err)
+	}
+
+	controller.Logger.Info("update account flag completed")
+	return flag, nil
+}
+
+// ExpireAccountFlag expires the flag now, so it is no longer returned with the user's accounts
+func (controller Controller) ExpireAccountFlag(ctx context.Context, flagId int) (entity.AccountFlags, error) {
+	controller.Logger.Infof("start expire account flag %d", flagId)
+
+	flag, err := controller.ModelRepository.ExpireAccountFlag(ctx, flagId, time.Now())
+	if err != nil {
+		controller.Logger.Errorf("expire account flag failed because: %s", err.Error())
+		return flag, accountFlagError(err)
+	}
+
+	controller.Logger.Info("expire account flag completed")
+	return flag, nil
+}
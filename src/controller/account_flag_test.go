@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"assignment/entity"
+	"assignment/global"
+	mock_model "assignment/mocks/model"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestController_AddAccountFlag_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	expiredAt := time.Now().Add(24 * time.Hour)
+	want := entity.AccountFlags{FlagId: 7, AccountId: "acc-1", UserId: "user-1", FlagType: "badge", FlagValue: "new", ExpiredAt: &expiredAt}
+	mockRepo.EXPECT().CreateAccountFlag(gomock.Any(), entity.AccountFlags{
+		AccountId: "acc-1", FlagType: "badge", FlagValue: "new", ExpiredAt: &expiredAt,
+	}).Return(want, nil).Times(1)
+
+	out, err := c.AddAccountFlag(context.Background(), AddAccountFlagInput{
+		AccountId: "acc-1", FlagType: "badge", FlagValue: "new", ExpiredAt: &expiredAt,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.FlagId != want.FlagId {
+		t.Fatalf("unexpected output: %+v", out)
+	}
+}
+
+func TestController_AddAccountFlag_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	past := time.Now().Add(-time.Hour)
+	for _, input := range []AddAccountFlagInput{
+		{AccountId: "acc-1", FlagType: "unknown", FlagValue: "new"},
+		{AccountId: "acc-1", FlagType: "badge", FlagValue: "hot"},
+		{AccountId: "acc-1", FlagType: "system", FlagValue: "not alphanumeric"},
+		{AccountId: "acc-1", FlagType: "badge", FlagValue: "new", ExpiredAt: &past},
+	} {
+		_, err := c.AddAccountFlag(context.Background(), input)
+		sysErr, ok := err.(global.SystemError)
+		if !ok || sysErr.Code != global.InvalidAccountFlag {
+			t.Fatalf("expected InvalidAccountFlag for %+v, got %v", input, err)
+		}
+	}
+}
+
+func TestController_AddAccountFlag_Exists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().CreateAccountFlag(gomock.Any(), gomock.Any()).
+		Return(entity.AccountFlags{}, model_mysql.ErrAccountFlagExists).Times(1)
+
+	_, err := c.AddAccountFlag(context.Background(), AddAccountFlagInput{AccountId: "acc-1", FlagType: "badge", FlagValue: "new"})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.AccountFlagExists {
+		t.Fatalf("expected AccountFlagExists, got %v", err)
+	}
+}
+
+func TestController_UpdateAccountFlag_ChecksSchemaOfStoredType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().GetAccountFlag(gomock.Any(), 7).
+		Return(entity.AccountFlags{FlagId: 7, FlagType: "badge", FlagValue: "new"}, nil).Times(2)
+	mockRepo.EXPECT().UpdateAccountFlag(gomock.Any(), 7, "popular", nil).
+		Return(entity.AccountFlags{FlagId: 7, FlagType: "badge", FlagValue: "popular"}, nil).Times(1)
+
+	_, err := c.UpdateAccountFlag(context.Background(), 7, UpdateAccountFlagInput{FlagValue: "SYS01"})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.InvalidAccountFlag {
+		t.Fatalf("expected InvalidAccountFlag, got %v", err)
+	}
+
+	out, err := c.UpdateAccountFlag(context.Background(), 7, UpdateAccountFlagInput{FlagValue: "popular"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.FlagValue != "popular" {
+		t.Fatalf("unexpected output: %+v", out)
+	}
+}
+
+func TestController_UpdateAccountFlag_Expired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().GetAccountFlag(gomock.Any(), 7).
+		Return(entity.AccountFlags{FlagId: 7, FlagType: "badge", FlagValue: "new"}, nil).Times(1)
+	mockRepo.EXPECT().UpdateAccountFlag(gomock.Any(), 7, "new", nil).
+		Return(entity.AccountFlags{}, model_mysql.ErrAccountFlagExpired).Times(1)
+
+	_, err := c.UpdateAccountFlag(context.Background(), 7, UpdateAccountFlagInput{FlagValue: "new"})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.InvalidAccountFlag {
+		t.Fatalf("expected InvalidAccountFlag, got %v", err)
+	}
+}
+
+func TestController_ExpireAccountFlag_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().ExpireAccountFlag(gomock.Any(), 7, gomock.Any()).
+		Return(entity.AccountFlags{}, model_mysql.ErrAccountFlagNotFound).Times(1)
+
+	_, err := c.ExpireAccountFlag(context.Background(), 7)
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.AccountFlagNotFound {
+		t.Fatalf("expected AccountFlagNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"assignment/global"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"errors"
+)
+
+type ReorderAccountsInput struct {
+	AccountIds []string `json:"account_ids" validate:"required,min=1,dive,required,max=50"`
+}
+
+func accountOrderError(err error) error {
+	switch {
+	case errors.Is(err, model_mysql.ErrAccountNotFound):
+		return global.NewSystemError(global.AccountNotFound)
+	case errors.Is(err, model_mysql.ErrAccountOrderMismatch):
+		return global.NewSystemError(global.InvalidAccountOrder)
+	}
+	return global.NewRawSystemError(global.DatabaseError, err.Error())
+}
+
+// SetMainAccount makes the account the user's only main account and returns the accounts afterwards
+func (controller Controller) SetMainAccount(ctx context.Context, accountId string) (GetAccountsOutput, error) {
+	controller.Logger.Infof("start set main account to %s", accountId)
+
+	if err := controller.ModelRepository.SetMainAccount(ctx, controller.UserId, accountId); err != nil {
+		controller.Logger.Errorf("set main account failed because: %s", err.Error())
+		return GetAccountsOutput{}, accountOrderError(err)
+	}
+
+	controller.Logger.Info("set main account completed")
+	return controller.GetUserAccounts(ctx)
+}
+
+// ReorderAccounts stores the order the user wants to see the accounts in and returns the accounts afterwards
+func (controller Controller) ReorderAccounts(ctx context.Context, input ReorderAccountsInput) (GetAccountsOutput, error) {
+	controller.Logger.Info("start reorder accounts")
+
+	if err := controller.ModelRepository.ReorderAccounts(ctx, controller.UserId, input.AccountIds); err != nil {
+		controller.Logger.Errorf("reorder accounts failed because: %s", err.Error())
+		return GetAccountsOutput{}, accountOrderError(err)
+	}
+
+	controller.Logger.Info("reorder accounts completed")
+	return controller.GetUserAccounts(ctx)
+}
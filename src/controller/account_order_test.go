@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"assignment/global"
+	mock_model "assignment/mocks/model"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestController_SetMainAccount_ReturnsAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	accounts := []model_mysql.AccountWithDetails{{AccountID: "acc-1"}, {AccountID: "acc-2", IsMainAccount: true}}
+	gomock.InOrder(
+		mockRepo.EXPECT().SetMainAccount(gomock.Any(), "test-user-id", "acc-2").Return(nil),
+		mockRepo.EXPECT().GetUserAccounts(gomock.Any(), "test-user-id").Return(accounts, nil),
+	)
+
+	out, err := c.SetMainAccount(context.Background(), "acc-2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.Accounts) != 2 || !out.Accounts[1].IsMainAccount {
+		t.Fatalf("unexpected accounts: %+v", out.Accounts)
+	}
+}
+
+func TestController_SetMainAccount_NotOwned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().SetMainAccount(gomock.Any(), "test-user-id", "acc-9").Return(model_mysql.ErrAccountNotFound).Times(1)
+
+	_, err := c.SetMainAccount(context.Background(), "acc-9")
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.AccountNotFound {
+		t.Fatalf("expected AccountNotFound, got %v", err)
+	}
+}
+
+func TestController_ReorderAccounts_Mismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().ReorderAccounts(gomock.Any(), "test-user-id", []string{"acc-1"}).Return(model_mysql.ErrAccountOrderMismatch).Times(1)
+
+	_, err := c.ReorderAccounts(context.Background(), ReorderAccountsInput{AccountIds: []string{"acc-1"}})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.InvalidAccountOrder {
+		t.Fatalf("expected InvalidAccountOrder, got %v", err)
+	}
+}
@@ -20,10 +20,7 @@ func (controller Controller) GetUserAccounts(ctx context.Context) (GetAccountsOu
 	output.Accounts, err = controller.ModelRepository.GetUserAccounts(ctx, controller.UserId)
 	if err != nil {
 		controller.Logger.Errorf("get user accounts failed because: %s", err.Error())
-		return output, global.SystemError{
-			Code:    global.DatabaseError,
-			Message: err.Error(),
-		}
+		return output, global.NewRawSystemError(global.DatabaseError, err.Error())
 	}
 
 	controller.Logger.Info("get user accounts completed")
@@ -43,10 +40,7 @@ func (controller Controller) GetUserDebitCards(ctx context.Context) (GetDebitCar
 	output.DebitCards, err = controller.ModelRepository.GetUserCards(ctx, controller.UserId)
 	if err != nil {
 		controller.Logger.Errorf("get user debit cards failed because: %s", err.Error())
-		return output, global.SystemError{
-			Code:    global.DatabaseError,
-			Message: err.Error(),
-		}
+		return output, global.NewRawSystemError(global.DatabaseError, err.Error())
 	}
 
 	// Masked middle half of card number
@@ -65,22 +59,23 @@ func (controller Controller) GetUserDebitCards(ctx context.Context) (GetDebitCar
 	return output, nil
 }
 
+type GetSavedAccountsInput struct {
+	Search string `query:"search" validate:"max=100"`
+}
+
 type GetSavedAccountsOutput struct {
 	SavedAccounts []model_mysql.SavedAccounts `json:"saved_accounts"`
 }
 
-func (controller Controller) GetUserSavedAccounts(ctx context.Context) (GetSavedAccountsOutput, error) {
+func (controller Controller) GetUserSavedAccounts(ctx context.Context, input GetSavedAccountsInput) (GetSavedAccountsOutput, error) {
 	controller.Logger.Info("getting user saved accounts")
 	output := GetSavedAccountsOutput{}
 
 	var err error
-	output.SavedAccounts, err = controller.ModelRepository.GetUserSavedAccounts(ctx, controller.UserId)
+	output.SavedAccounts, err = controller.ModelRepository.GetUserSavedAccounts(ctx, controller.UserId, strings.TrimSpace(input.Search))
 	if err != nil {
 		controller.Logger.Errorf("get user saved accounts failed because: %s", err.Error())
-		return output, global.SystemError{
-			Code:    global.DatabaseError,
-			Message: err.Error(),
-		}
+		return output, global.NewRawSystemError(global.DatabaseError, err.Error())
 	}
 
 	controller.Logger.Info("get user saved accounts completed")
@@ -188,11 +188,11 @@ func TestGetUserSavedAccounts_Success(t *testing.T) {
 
 	repo.EXPECT().ConfigureRequestId(gomock.AssignableToTypeOf((*string)(nil))).AnyTimes()
 	repo.EXPECT().ConfigureUserId(gomock.AssignableToTypeOf((*string)(nil))).AnyTimes()
-	repo.EXPECT().GetUserSavedAccounts(gomock.Any(), gomock.Any()).Return(wantSaved, nil).Times(1)
+	repo.EXPECT().GetUserSavedAccounts(gomock.Any(), gomock.Any(), "ali").Return(wantSaved, nil).Times(1)
 
 	c := newTestController(repo)
 
-	out, err := c.GetUserSavedAccounts(ctx)
+	out, err := c.GetUserSavedAccounts(ctx, GetSavedAccountsInput{Search: "  ali "})
 	if err != nil {
 		t.Fatalf("expected nil error, got %v", err)
 	}
@@ -214,11 +214,11 @@ func TestGetUserSavedAccounts_Error(t *testing.T) {
 
 	repo.EXPECT().ConfigureRequestId(gomock.AssignableToTypeOf((*string)(nil))).AnyTimes()
 	repo.EXPECT().ConfigureUserId(gomock.AssignableToTypeOf((*string)(nil))).AnyTimes()
-	repo.EXPECT().GetUserSavedAccounts(gomock.Any(), gomock.Any()).Return(nil, wantErr).Times(1)
+	repo.EXPECT().GetUserSavedAccounts(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, wantErr).Times(1)
 
 	c := newTestController(repo)
 
-	out, err := c.GetUserSavedAccounts(ctx)
+	out, err := c.GetUserSavedAccounts(ctx, GetSavedAccountsInput{})
 	if err == nil {
 		t.Fatalf("expected error, got nil (output: %#v)", out)
 	}
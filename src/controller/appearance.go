@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"assignment/global"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+)
+
+type UpdateAccountAppearanceInput struct {
+	Color string `json:"color" validate:"required,hexcolor"`
+}
+
+type AccountAppearanceOutput struct {
+	AccountId string `json:"account_id"`
+	Color     string `json:"color"`
+}
+
+type UpdateCardDesignInput struct {
+	Color       string `json:"color" validate:"required,hexcolor"`
+	BorderColor string `json:"border_color" validate:"required,hexcolor"`
+}
+
+type CardDesignOutput struct {
+	CardId      string `json:"card_id"`
+	Color       string `json:"color"`
+	BorderColor string `json:"border_color"`
+}
+
+func (controller Controller) UpdateAccountAppearance(ctx context.Context, accountId string, input UpdateAccountAppearanceInput) (AccountAppearanceOutput, error) {
+	controller.Logger.Infof("start update appearance of account %s", accountId)
+	output := AccountAppearanceOutput{}
+
+	color, err := paletteColor("color", input.Color)
+	if err != nil {
+		return output, err
+	}
+
+	if err := controller.ModelRepository.UpdateAccountColor(ctx, controller.UserId, accountId, color); err != nil {
+		controller.Logger.Errorf("update account color failed because: %s", err.Error())
+		if errors.Is(err, model_mysql.ErrAccountNotFound) {
+			return output, global.NewSystemError(global.AccountNotFound)
+		}
+		return output, global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	output.AccountId = accountId
+	output.Color = color
+
+	controller.Logger.Info("update account appearance completed")
+	return output, nil
+}
+
+func (controller Controller) UpdateCardDesign(ctx context.Context, cardId string, input UpdateCardDesignInput) (CardDesignOutput, error) {
+	controller.Logger.Infof("start update design of card %s", cardId)
+	output := CardDesignOutput{}
+
+	color, err := paletteColor("color", input.Color)
+	if err != nil {
+		return output, err
+	}
+	borderColor, err := paletteColor("border_color", input.BorderColor)
+	if err != nil {
+		return output, err
+	}
+
+	if err := controller.ModelRepository.UpdateCardDesign(ctx, controller.UserId, cardId, color, borderColor); err != nil {
+		controller.Logger.Errorf("update card design failed because: %s", err.Error())
+		return output, cardError(err)
+	}
+
+	output.CardId = cardId
+	output.Color = color
+	output.BorderColor = borderColor
+
+	controller.Logger.Info("update card design completed")
+	return output, nil
+}
+
+// paletteColor returns the color in the lower case form it is stored in, or an error when it is not in the palette
+func paletteColor(field, color string) (string, error) {
+	color = strings.ToLower(color)
+	if !slices.Contains(global.AppearancePalette, color) {
+		return "", global.NewSystemError(global.ColorNotAllowed, field, strings.Join(global.AppearancePalette, ", "))
+	}
+	return color, nil
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"assignment/global"
+	mock_model "assignment/mocks/model"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestController_UpdateAccountAppearance_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().UpdateAccountColor(gomock.Any(), "test-user-id", "acc-1", "#00a1e2").Return(nil).Times(1)
+
+	out, err := c.UpdateAccountAppearance(context.Background(), "acc-1", UpdateAccountAppearanceInput{Color: "#00A1E2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != (AccountAppearanceOutput{AccountId: "acc-1", Color: "#00a1e2"}) {
+		t.Fatalf("unexpected output: %+v", out)
+	}
+}
+
+func TestController_UpdateAccountAppearance_ColorNotInPalette(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	_, err := c.UpdateAccountAppearance(context.Background(), "acc-1", UpdateAccountAppearanceInput{Color: "#123456"})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.ColorNotAllowed {
+		t.Fatalf("expected ColorNotAllowed error, got %v", err)
+	}
+}
+
+func TestController_UpdateAccountAppearance_NotOwned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().UpdateAccountColor(gomock.Any(), "test-user-id", "acc-2", "#00a1e2").Return(model_mysql.ErrAccountNotFound).Times(1)
+
+	_, err := c.UpdateAccountAppearance(context.Background(), "acc-2", UpdateAccountAppearanceInput{Color: "#00a1e2"})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.AccountNotFound {
+		t.Fatalf("expected AccountNotFound error, got %v", err)
+	}
+}
+
+func TestController_UpdateCardDesign_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().UpdateCardDesign(gomock.Any(), "test-user-id", "card-1", "#845ef7", "#ffffff").Return(nil).Times(1)
+
+	out, err := c.UpdateCardDesign(context.Background(), "card-1", UpdateCardDesignInput{Color: "#845ef7", BorderColor: "#FFFFFF"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != (CardDesignOutput{CardId: "card-1", Color: "#845ef7", BorderColor: "#ffffff"}) {
+		t.Fatalf("unexpected output: %+v", out)
+	}
+}
+
+func TestController_UpdateCardDesign_BorderColorNotInPalette(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	_, err := c.UpdateCardDesign(context.Background(), "card-1", UpdateCardDesignInput{Color: "#845ef7", BorderColor: "#fff"})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.ColorNotAllowed {
+		t.Fatalf("expected ColorNotAllowed error, got %v", err)
+	}
+}
@@ -1,58 +1,150 @@
 package controller
 
 import (
+	"assignment/entity"
 	"assignment/global"
+	model_mysql "assignment/model/mysql"
 	"assignment/util"
 	"context"
+	"errors"
+	"time"
 )
 
 type LoginInput struct {
-	UserId string `json:"user_id" validate:"required"`
-	Pin    string `json:"pin" validate:"required"`
+	UserId     string `json:"user_id" validate:"required"`
+	Pin        string `json:"pin" validate:"required"`
+	DeviceId   string `json:"device_id" validate:"max=100"`
+	DeviceName string `json:"device_name" validate:"max=100"`
+	UserAgent  string `json:"-"`
+	IpAddress  string `json:"-"`
 }
 
 type LoginOutput struct {
 	Greeting string `json:"greeting"`
-	Token    string `json:"token"`
+	TokenOutput
+}
+
+type TokenOutput struct {
+	Token        string    `json:"token"`
+	ExpiredAt    time.Time `json:"expired_at"`
+	RefreshToken string    `json:"refresh_token"`
 }
 
 func (controller Controller) Login(ctx context.Context, input LoginInput) (LoginOutput, error) {
 	controller.Logger.Info("start logging in")
 	output := LoginOutput{}
 
-	userPin, err := controller.ModelRepository.GetUserHashedPin(ctx, input.UserId)
+	userPin, err := controller.verifyPin(ctx, input.UserId, input.Pin)
 	if err != nil {
-		controller.Logger.Errorf("get user hashed pin failed because: %s", err.Error())
-		return output, global.SystemError{
-			Code:    global.DatabaseError,
-			Message: err.Error(),
+		return output, err
+	}
+
+	if util.PinNeedsRehash(userPin.Pin) {
+		controller.rehashPin(ctx, input.UserId, input.Pin, userPin.Pin)
+	}
+
+	device := model_mysql.SessionDevice{
+		DeviceId:   input.DeviceId,
+		DeviceName: input.DeviceName,
+		UserAgent:  input.UserAgent,
+		IpAddress:  input.IpAddress,
+	}
+	tokens, greeting, err := controller.ModelRepository.CreateSessionToken(ctx, input.UserId, device, sessionPolicy())
+	if err != nil {
+		controller.Logger.Errorf("create token failed because: %s", err.Error())
+		return output, global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+	output.Greeting = controller.localizeGreeting(ctx, input.UserId, greeting)
+	output.TokenOutput = newTokenOutput(tokens)
+
+	controller.Logger.Info("login completed")
+	return output, nil
+}
+
+type UnlockPinInput struct {
+	UserId string `json:"user_id" validate:"required"`
+}
+
+// UnlockPin lets support staff clear a pin lockout before it expires
+func (controller Controller) UnlockPin(ctx context.Context, input UnlockPinInput) error {
+	controller.Logger.Infof("start unlocking pin of user %s", input.UserId)
+
+	if _, err := controller.ModelRepository.GetUserHashedPin(ctx, input.UserId); err != nil {
+		if errors.Is(err, model_mysql.ErrUserNotFound) {
+			controller.Logger.Errorf("unlock pin requested for unknown user %s", input.UserId)
+			return global.NewSystemError(global.UserNotFound)
 		}
+		controller.Logger.Errorf("get user hashed pin failed because: %s", err.Error())
+		return global.NewRawSystemError(global.DatabaseError, err.Error())
 	}
 
-	if same, err := util.ValidatePin(input.Pin, userPin.Pin); !same {
+	if err := controller.ModelRepository.ResetPinLockout(ctx, input.UserId); err != nil {
+		controller.Logger.Errorf("reset pin lockout failed because: %s", err.Error())
+		return global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	controller.Logger.Info("unlock pin completed")
+	return nil
+}
+
+// verifyPin checks the pin of the user while enforcing the lockout policy. The attempt is reserved before the pin is
+// checked, so concurrent wrong pins cannot get more checks than the policy allows, and released again on success.
+func (controller Controller) verifyPin(ctx context.Context, userId, pin string) (entity.UserPin, error) {
+	userPin, err := controller.ModelRepository.ReservePinAttempt(ctx, userId, pinLockoutPolicy())
+	if errors.Is(err, model_mysql.ErrPinLocked) {
+		controller.Logger.Errorf("user %s entered a pin while it is locked", userId)
+		return userPin, pinLockedError(*userPin.LockedUntil)
+	}
+	if err != nil {
+		controller.Logger.Errorf("reserve pin attempt failed because: %s", err.Error())
+		return entity.UserPin{}, global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	if same, err := util.ValidatePin(pin, userPin.Pin); !same {
 		if err != nil {
-			controller.Logger.Errorf("cannot validate pin for user %s", input.UserId)
-			return output, global.SystemError{
-				Code:    global.DatabaseError,
-				Message: err.Error(),
-			}
+			controller.Logger.Errorf("cannot validate pin for user %s", userId)
+			return userPin, global.NewRawSystemError(global.DatabaseError, err.Error())
 		}
-		controller.Logger.Errorf("user %s just input an incorrect password", input.UserId)
-		return output, global.SystemError{
-			Code:    global.IncorrectPin,
-			Message: global.GetErrorMessage(global.IncorrectPin),
+		controller.Logger.Errorf("user %s just input an incorrect password", userId)
+
+		if userPin.LockedUntil != nil && time.Now().Before(*userPin.LockedUntil) {
+			controller.Logger.Errorf("pin of user %s is locked until %s", userId, userPin.LockedUntil.Format(time.RFC3339))
+			return userPin, pinLockedError(*userPin.LockedUntil)
 		}
+		return userPin, global.NewSystemError(global.IncorrectPin)
+	}
+
+	if err := controller.ModelRepository.ResetPinLockout(ctx, userId); err != nil {
+		controller.Logger.Errorf("reset pin lockout failed because: %s", err.Error())
+		return userPin, global.NewRawSystemError(global.DatabaseError, err.Error())
 	}
 
-	output.Token, output.Greeting, err = controller.ModelRepository.RevokeExistingTokenAndCreateNewToken(ctx, input.UserId)
+	return userPin, nil
+}
+
+// rehashPin upgrades the stored hash to the current format now that the plain pin is known. Failing here
+// must not fail the login, the pin will be rehashed on a later login instead.
+func (controller Controller) rehashPin(ctx context.Context, userId, pin, oldHashedPin string) {
+	newHashedPin, err := util.HashPassword(pin)
 	if err != nil {
-		controller.Logger.Errorf("create token failed because: %s", err.Error())
-		return output, global.SystemError{
-			Code:    global.DatabaseError,
-			Message: err.Error(),
-		}
+		controller.Logger.Errorf("rehash pin failed because: %s", err.Error())
+		return
+	}
+	if err := controller.ModelRepository.UpdateUserPinHash(ctx, userId, oldHashedPin, newHashedPin); err != nil {
+		controller.Logger.Errorf("update user pin hash failed because: %s", err.Error())
+		return
 	}
+	controller.Logger.Infof("pin of user %s is rehashed", userId)
+}
 
-	controller.Logger.Info("login completed")
-	return output, nil
+func pinLockoutPolicy() model_mysql.PinLockoutPolicy {
+	return model_mysql.PinLockoutPolicy{
+		MaxAttempts:  global.PinMaxAttempts,
+		BaseLockTime: global.PinBaseLockTime,
+		MaxLockTime:  global.PinMaxLockTime,
+	}
+}
+
+func pinLockedError(lockedUntil time.Time) global.SystemError {
+	return global.NewSystemError(global.PinLocked, lockedUntil.Format(time.RFC3339))
 }
@@ -5,10 +5,13 @@ import (
 	"assignment/util"
 	"context"
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"assignment/global"
 	mock_model "assignment/mocks/model"
+	model_mysql "assignment/model/mysql"
 	"github.com/golang/mock/gomock"
 )
 
@@ -35,8 +38,9 @@ func TestLogin_Success(t *testing.T) {
 
 	repo.EXPECT().ConfigureRequestId(gomock.AssignableToTypeOf((*string)(nil))).AnyTimes()
 	repo.EXPECT().ConfigureUserId(gomock.AssignableToTypeOf((*string)(nil))).AnyTimes()
-	repo.EXPECT().GetUserHashedPin(gomock.Any(), input.UserId).Return(userPin, nil).Times(1)
-	repo.EXPECT().RevokeExistingTokenAndCreateNewToken(gomock.Any(), input.UserId).Return(wantToken, wantGreeting, nil).Times(1)
+	repo.EXPECT().ReservePinAttempt(gomock.Any(), input.UserId, gomock.Any()).Return(userPin, nil).Times(1)
+	repo.EXPECT().ResetPinLockout(gomock.Any(), input.UserId).Return(nil).Times(1)
+	repo.EXPECT().CreateSessionToken(gomock.Any(), input.UserId, gomock.Any(), gomock.Any()).Return(model_mysql.SessionTokens{AccessToken: wantToken, RefreshToken: "refresh-xyz"}, wantGreeting, nil).Times(1)
 
 	out, err := c.Login(ctx, input)
 	if err != nil {
@@ -48,9 +52,12 @@ func TestLogin_Success(t *testing.T) {
 	if out.Greeting != wantGreeting {
 		t.Fatalf("unexpected greeting: want %q, got %q", wantGreeting, out.Greeting)
 	}
+	if out.RefreshToken != "refresh-xyz" {
+		t.Fatalf("unexpected refresh token: got %q", out.RefreshToken)
+	}
 }
 
-func TestLogin_GetUserHashedPinError(t *testing.T) {
+func TestLogin_ReservePinAttemptError(t *testing.T) {
 	t.Parallel()
 
 	ctrl := gomock.NewController(t)
@@ -65,11 +72,11 @@ func TestLogin_GetUserHashedPinError(t *testing.T) {
 		Pin:    "123456",
 	}
 
-	wantErr := errors.New("db fail on get user pin")
+	wantErr := errors.New("db fail on reserve pin attempt")
 
 	repo.EXPECT().ConfigureRequestId(gomock.AssignableToTypeOf((*string)(nil))).AnyTimes()
 	repo.EXPECT().ConfigureUserId(gomock.AssignableToTypeOf((*string)(nil))).AnyTimes()
-	repo.EXPECT().GetUserHashedPin(gomock.Any(), input.UserId).Return(entity.UserPin{}, wantErr).Times(1)
+	repo.EXPECT().ReservePinAttempt(gomock.Any(), input.UserId, gomock.Any()).Return(entity.UserPin{}, wantErr).Times(1)
 
 	out, err := c.Login(ctx, input)
 	if err == nil {
@@ -108,7 +115,7 @@ func TestLogin_IncorrectPin(t *testing.T) {
 
 	repo.EXPECT().ConfigureRequestId(gomock.AssignableToTypeOf((*string)(nil))).AnyTimes()
 	repo.EXPECT().ConfigureUserId(gomock.AssignableToTypeOf((*string)(nil))).AnyTimes()
-	repo.EXPECT().GetUserHashedPin(gomock.Any(), input.UserId).Return(userPin, nil).Times(1)
+	repo.EXPECT().ReservePinAttempt(gomock.Any(), input.UserId, gomock.Any()).Return(userPin, nil).Times(1)
 
 	out, err := c.Login(ctx, input)
 	if err == nil {
@@ -145,7 +152,7 @@ func TestLogin_ValidatePinError(t *testing.T) {
 
 	repo.EXPECT().ConfigureRequestId(gomock.AssignableToTypeOf((*string)(nil))).AnyTimes()
 	repo.EXPECT().ConfigureUserId(gomock.AssignableToTypeOf((*string)(nil))).AnyTimes()
-	repo.EXPECT().GetUserHashedPin(gomock.Any(), input.UserId).Return(userPin, nil).Times(1)
+	repo.EXPECT().ReservePinAttempt(gomock.Any(), input.UserId, gomock.Any()).Return(userPin, nil).Times(1)
 
 	out, err := c.Login(ctx, input)
 	if err == nil {
@@ -181,8 +188,9 @@ func TestLogin_CreateTokenError(t *testing.T) {
 
 	repo.EXPECT().ConfigureRequestId(gomock.AssignableToTypeOf((*string)(nil))).AnyTimes()
 	repo.EXPECT().ConfigureUserId(gomock.AssignableToTypeOf((*string)(nil))).AnyTimes()
-	repo.EXPECT().GetUserHashedPin(gomock.Any(), input.UserId).Return(userPin, nil).Times(1)
-	repo.EXPECT().RevokeExistingTokenAndCreateNewToken(gomock.Any(), input.UserId).Return("", "", wantErr).Times(1)
+	repo.EXPECT().ReservePinAttempt(gomock.Any(), input.UserId, gomock.Any()).Return(userPin, nil).Times(1)
+	repo.EXPECT().ResetPinLockout(gomock.Any(), input.UserId).Return(nil).Times(1)
+	repo.EXPECT().CreateSessionToken(gomock.Any(), input.UserId, gomock.Any(), gomock.Any()).Return(model_mysql.SessionTokens{}, "", wantErr).Times(1)
 
 	out, err := c.Login(ctx, input)
 	if err == nil {
@@ -199,3 +207,323 @@ func TestLogin_CreateTokenError(t *testing.T) {
 		t.Fatalf("expected message %q, got %q", wantErr.Error(), se.Message)
 	}
 }
+
+func TestLogin_PinLocked(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(repo)
+	ctx := context.Background()
+
+	input := LoginInput{
+		UserId: "user-123",
+		Pin:    "123456",
+	}
+
+	// Correct pin must still be rejected while locked
+	pin, _ := util.HashPassword(input.Pin)
+	lockedUntil := time.Now().Add(time.Minute)
+	userPin := entity.UserPin{Pin: pin, LockoutCount: 1, LockedUntil: &lockedUntil}
+
+	repo.EXPECT().ReservePinAttempt(gomock.Any(), input.UserId, gomock.Any()).Return(userPin, model_mysql.ErrPinLocked).Times(1)
+
+	_, err := c.Login(ctx, input)
+	var se global.SystemError
+	if !errors.As(err, &se) {
+		t.Fatalf("expected global.SystemError, got %T", err)
+	}
+	if se.Code != global.PinLocked {
+		t.Fatalf("expected code %v, got %v", global.PinLocked, se.Code)
+	}
+}
+
+func TestLogin_IncorrectPinLocksPin(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(repo)
+	ctx := context.Background()
+
+	input := LoginInput{
+		UserId: "user-123",
+		Pin:    "123456",
+	}
+
+	hashedDifferent, _ := util.HashPassword("000000")
+	lockedUntil := time.Now().Add(5 * time.Minute)
+
+	// the fifth attempt locks the pin when it is reserved, the wrong pin reports the lock
+	repo.EXPECT().ReservePinAttempt(gomock.Any(), input.UserId, gomock.Any()).Return(entity.UserPin{Pin: hashedDifferent, LockoutCount: 1, LockedUntil: &lockedUntil}, nil).Times(1)
+
+	_, err := c.Login(ctx, input)
+	var se global.SystemError
+	if !errors.As(err, &se) {
+		t.Fatalf("expected global.SystemError, got %T", err)
+	}
+	if se.Code != global.PinLocked {
+		t.Fatalf("expected code %v, got %v", global.PinLocked, se.Code)
+	}
+}
+
+func TestLogin_ConcurrentIncorrectPinsAreLimited(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(repo)
+
+	input := LoginInput{UserId: "user-123", Pin: "123456"}
+	hashedDifferent, _ := util.HashPassword("000000")
+
+	// The reservation behaves like the locked user_pin row, one attempt at a time
+	var mu sync.Mutex
+	userPin := entity.UserPin{Pin: hashedDifferent}
+	reserved := 0
+	repo.EXPECT().ReservePinAttempt(gomock.Any(), input.UserId, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, policy model_mysql.PinLockoutPolicy) (entity.UserPin, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if userPin.LockedUntil != nil && time.Now().Before(*userPin.LockedUntil) {
+				return userPin, model_mysql.ErrPinLocked
+			}
+			reserved++
+			userPin.FailedAttempts++
+			if userPin.FailedAttempts >= policy.MaxAttempts {
+				lockedUntil := time.Now().Add(policy.BaseLockTime)
+				userPin.LockedUntil = &lockedUntil
+				userPin.FailedAttempts = 0
+			}
+			return userPin, nil
+		}).
+		AnyTimes()
+
+	const requests = 20
+	codes := make(chan int64, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Login(context.Background(), input)
+			var se global.SystemError
+			if errors.As(err, &se) {
+				codes <- se.Code
+			}
+		}()
+	}
+	wg.Wait()
+	close(codes)
+
+	incorrect, locked := 0, 0
+	for code := range codes {
+		switch code {
+		case global.IncorrectPin:
+			incorrect++
+		case global.PinLocked:
+			locked++
+		default:
+			t.Fatalf("unexpected code %d", code)
+		}
+	}
+	// the pin is only checked after a granted reservation
+	if reserved != global.PinMaxAttempts {
+		t.Fatalf("expected %d pins to be checked, got %d", global.PinMaxAttempts, reserved)
+	}
+	if incorrect != global.PinMaxAttempts-1 || locked != requests-incorrect {
+		t.Fatalf("expected %d incorrect and the rest locked, got %d incorrect and %d locked", global.PinMaxAttempts-1, incorrect, locked)
+	}
+}
+
+func TestLogin_SuccessResetsFailedAttempts(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(repo)
+	ctx := context.Background()
+
+	input := LoginInput{
+		UserId: "user-123",
+		Pin:    "123456",
+	}
+
+	// Lock already expired
+	pin, _ := util.HashPassword(input.Pin)
+	lockedUntil := time.Now().Add(-time.Minute)
+	userPin := entity.UserPin{Pin: pin, FailedAttempts: 2, LockoutCount: 1, LockedUntil: &lockedUntil}
+
+	repo.EXPECT().ReservePinAttempt(gomock.Any(), input.UserId, gomock.Any()).Return(userPin, nil).Times(1)
+	repo.EXPECT().ResetPinLockout(gomock.Any(), input.UserId).Return(nil).Times(1)
+	repo.EXPECT().CreateSessionToken(gomock.Any(), input.UserId, gomock.Any(), gomock.Any()).Return(model_mysql.SessionTokens{AccessToken: "token-xyz"}, "Welcome!", nil).Times(1)
+
+	if _, err := c.Login(ctx, input); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+}
+
+func TestUnlockPin_Success(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(repo)
+
+	repo.EXPECT().GetUserHashedPin(gomock.Any(), "user-123").Return(entity.UserPin{UserId: "user-123"}, nil).Times(1)
+	repo.EXPECT().ResetPinLockout(gomock.Any(), "user-123").Return(nil).Times(1)
+
+	if err := c.UnlockPin(context.Background(), UnlockPinInput{UserId: "user-123"}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+}
+
+func TestUnlockPin_UserNotFound(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(repo)
+
+	repo.EXPECT().GetUserHashedPin(gomock.Any(), "missing").Return(entity.UserPin{}, model_mysql.ErrUserNotFound).Times(1)
+
+	err := c.UnlockPin(context.Background(), UnlockPinInput{UserId: "missing"})
+	var se global.SystemError
+	if !errors.As(err, &se) {
+		t.Fatalf("expected global.SystemError, got %T", err)
+	}
+	if se.Code != global.UserNotFound {
+		t.Fatalf("expected code %v, got %v", global.UserNotFound, se.Code)
+	}
+}
+
+func TestLogin_RehashesLegacyPin(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(repo)
+
+	input := LoginInput{UserId: "user-123", Pin: "123456"}
+	legacyPin, _ := util.HashPasswordFixSalt(util.GenerateSalt(), input.Pin)
+
+	repo.EXPECT().ReservePinAttempt(gomock.Any(), input.UserId, gomock.Any()).Return(entity.UserPin{Pin: legacyPin}, nil).Times(1)
+	repo.EXPECT().ResetPinLockout(gomock.Any(), input.UserId).Return(nil).Times(1)
+	repo.EXPECT().UpdateUserPinHash(gomock.Any(), input.UserId, legacyPin, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, newHashedPin string) error {
+			if util.PinNeedsRehash(newHashedPin) {
+				t.Fatalf("new hash is not in the current format: %s", newHashedPin)
+			}
+			if same, err := util.ValidatePin(input.Pin, newHashedPin); !same || err != nil {
+				t.Fatalf("new hash does not match the pin: %v", err)
+			}
+			return nil
+		}).
+		Times(1)
+	repo.EXPECT().CreateSessionToken(gomock.Any(), input.UserId, gomock.Any(), gomock.Any()).Return(model_mysql.SessionTokens{AccessToken: "token"}, "hello", nil).Times(1)
+
+	if _, err := c.Login(context.Background(), input); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+}
+
+func TestLogin_RehashErrorDoesNotFailLogin(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(repo)
+
+	input := LoginInput{UserId: "user-123", Pin: "123456"}
+	legacyPin, _ := util.HashPasswordFixSalt(util.GenerateSalt(), input.Pin)
+
+	repo.EXPECT().ReservePinAttempt(gomock.Any(), input.UserId, gomock.Any()).Return(entity.UserPin{Pin: legacyPin}, nil).Times(1)
+	repo.EXPECT().ResetPinLockout(gomock.Any(), input.UserId).Return(nil).Times(1)
+	repo.EXPECT().UpdateUserPinHash(gomock.Any(), input.UserId, legacyPin, gomock.Any()).Return(errors.New("db down")).Times(1)
+	repo.EXPECT().CreateSessionToken(gomock.Any(), input.UserId, gomock.Any(), gomock.Any()).Return(model_mysql.SessionTokens{AccessToken: "token"}, "hello", nil).Times(1)
+
+	out, err := c.Login(context.Background(), input)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if out.Token != "token" {
+		t.Fatalf("unexpected token: %q", out.Token)
+	}
+}
+
+func TestLogin_IncorrectPinWithLegacyHash(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(repo)
+
+	input := LoginInput{UserId: "user-123", Pin: "123456"}
+	legacyPin, _ := util.HashPasswordFixSalt(util.GenerateSalt(), "000000")
+
+	repo.EXPECT().ReservePinAttempt(gomock.Any(), input.UserId, gomock.Any()).Return(entity.UserPin{Pin: legacyPin}, nil).Times(1)
+
+	_, err := c.Login(context.Background(), input)
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.IncorrectPin {
+		t.Fatalf("expected IncorrectPin error, got %v", err)
+	}
+}
+
+func TestLogin_PassesDeviceAndSessionPolicy(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(repo)
+
+	input := LoginInput{
+		UserId:     "user-123",
+		Pin:        "123456",
+		DeviceId:   "device-1",
+		DeviceName: "Pixel 8",
+		UserAgent:  "okhttp/4.12",
+		IpAddress:  "10.0.0.1",
+	}
+	pin, _ := util.HashPassword(input.Pin)
+
+	wantDevice := model_mysql.SessionDevice{
+		DeviceId:   "device-1",
+		DeviceName: "Pixel 8",
+		UserAgent:  "okhttp/4.12",
+		IpAddress:  "10.0.0.1",
+	}
+	wantPolicy := model_mysql.SessionPolicy{
+		MaxSessions:          global.MaxConcurrentSessions,
+		AccessTokenLifetime:  global.AccessTokenLifetime,
+		RefreshTokenLifetime: global.RefreshTokenLifetime,
+	}
+
+	repo.EXPECT().ReservePinAttempt(gomock.Any(), input.UserId, gomock.Any()).Return(entity.UserPin{Pin: pin}, nil).Times(1)
+	repo.EXPECT().ResetPinLockout(gomock.Any(), input.UserId).Return(nil).Times(1)
+	repo.EXPECT().CreateSessionToken(gomock.Any(), input.UserId, wantDevice, wantPolicy).Return(model_mysql.SessionTokens{AccessToken: "token"}, "hello", nil).Times(1)
+
+	if _, err := c.Login(context.Background(), input); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+}
@@ -18,11 +18,9 @@ func (controller Controller) GetUserBanners(ctx context.Context) (GetBannersOutp
 	output.Banners, err = controller.ModelRepository.GetUserBanners(ctx, controller.UserId)
 	if err != nil {
 		controller.Logger.Errorf("get user banners failed because: %s", err.Error())
-		return output, global.SystemError{
-			Code:    global.DatabaseError,
-			Message: err.Error(),
-		}
+		return output, global.NewRawSystemError(global.DatabaseError, err.Error())
 	}
+	controller.localizeBanners(ctx, output.Banners)
 
 	controller.Logger.Info("get user banners completed")
 
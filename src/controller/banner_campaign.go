@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"assignment/entity"
+	"assignment/global"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+// BannerCampaignInput describes a whole campaign, it is used to create one and to replace one on update. StartsAt
+// defaults to now on create and to the current start on update, a nil EndsAt runs the campaign until it is changed or deleted. Title and Description are in the
+// default language, Translations holds them per supported language.
+type BannerCampaignInput struct {
+	Title       string     `json:"title" validate:"required,max=255"`
+	Description string     `json:"description" validate:"max=2000"`
+	Image       string     `json:"image" validate:"max=255"`
+	Priority    int        `json:"priority"`
+	StartsAt    *time.Time `json:"starts_at"`
+	EndsAt      *time.Time `json:"ends_at"`
+	Audience    string     `json:"audience" validate:"required,oneof=all users segment"`
+	UserIds     []string   `json:"user_ids" validate:"max=10000,dive,required,max=50"`
+	AccountType *string    `json:"account_type" validate:"omitnil,min=1,max=50"`
+	Currency    *string    `json:"currency" validate:"omitnil,min=1,max=10"`
+
+	Translations map[string]BannerTranslationInput `json:"translations" validate:"max=20,dive"`
+}
+
+type BannerTranslationInput struct {
+	Title       string `json:"title" validate:"required,max=255"`
+	Description string `json:"description" validate:"max=2000"`
+}
+
+func invalidBannerCampaign(reason global.Reason) error {
+	return global.NewSystemError(global.InvalidBannerCampaign, reason)
+}
+
+// newBannerCampaign checks that the audience rules fit the audience and returns the campaign with its unique user ids
+// and translations, startsAt is used when the input has no start
+func newBannerCampaign(input BannerCampaignInput, startsAt time.Time) (model_mysql.BannerCampaign, error) {
+	campaign := entity.BannerCampaigns{
+		Title:       input.Title,
+		Description: input.Description,
+		Image:       input.Image,
+		Priority:    input.Priority,
+		StartsAt:    startsAt,
+		EndsAt:      input.EndsAt,
+		Audience:    input.Audience,
+		AccountType: input.AccountType,
+		Currency:    input.Currency,
+	}
+	if input.StartsAt != nil {
+		campaign.StartsAt = *input.StartsAt
+	}
+	if campaign.EndsAt != nil && !campaign.EndsAt.After(campaign.StartsAt) {
+		return model_mysql.BannerCampaign{}, invalidBannerCampaign(global.NewReason(global.ReasonEndsBeforeStart))
+	}
+
+	if (input.Audience == model_mysql.BannerAudienceUsers) != (len(input.UserIds) > 0) {
+		return model_mysql.BannerCampaign{}, invalidBannerCampaign(global.NewReason(global.ReasonUserIdsNotAllowed))
+	}
+	hasSegment := input.AccountType != nil || input.Currency != nil
+	if (input.Audience == model_mysql.BannerAudienceSegment) != hasSegment {
+		return model_mysql.BannerCampaign{}, invalidBannerCampaign(global.NewReason(global.ReasonSegmentNotAllowed))
+	}
+
+	var translations map[string]model_mysql.BannerTranslation
+	for language, translation := range input.Translations {
+		if language == global.DefaultLanguage || !slices.Contains(global.SupportedLanguages, language) {
+			return model_mysql.BannerCampaign{}, invalidBannerCampaign(global.NewReason(global.ReasonUnsupportedTranslation, global.DefaultLanguage))
+		}
+		if translations == nil {
+			translations = make(map[string]model_mysql.BannerTranslation, len(input.Translations))
+		}
+		translations[language] = model_mysql.BannerTranslation{Title: translation.Title, Description: translation.Description}
+	}
+
+	userIds := slices.Clone(input.UserIds)
+	slices.Sort(userIds)
+	return model_mysql.BannerCampaign{
+		BannerCampaigns: campaign,
+		UserIds:         slices.Compact(userIds),
+		Translations:    translations,
+	}, nil
+}
+
+func bannerCampaignError(err error) error {
+	if errors.Is(err, model_mysql.ErrBannerCampaignNotFound) {
+		return global.NewSystemError(global.BannerCampaignNotFound)
+	}
+	return global.NewRawSystemError(global.DatabaseError, err.Error())
+}
+
+func (controller Controller) GetBannerCampaigns(ctx context.Context) ([]model_mysql.BannerCampaign, error) {
+	controller.Logger.Info("start get banner campaigns")
+
+	campaigns, err := controller.ModelRepository.GetBannerCampaigns(ctx)
+	if err != nil {
+		controller.Logger.Errorf("get banner campaigns failed because: %s", err.Error())
+		return nil, bannerCampaignError(err)
+	}
+
+	controller.Logger.Info("get banner campaigns completed")
+	return campaigns, nil
+}
+
+func (controller Controller) CreateBannerCampaign(ctx context.Context, input BannerCampaignInput) (model_mysql.BannerCampaign, error) {
+	controller.Logger.Infof("start create banner campaign for audience %s", input.Audience)
+
+	campaign, err := newBannerCampaign(input, time.Now())
+	if err != nil {
+		return model_mysql.BannerCampaign{}, err
+	}
+
+	result, err := controller.ModelRepository.CreateBannerCampaign(ctx, campaign)
+	if err != nil {
+		controller.Logger.Errorf("create banner campaign failed because: %s", err.Error())
+		return result, bannerCampaignError(err)
+	}
+
+	controller.Logger.Info("create banner campaign completed")
+	return result, nil
+}
+
+func (controller Controller) UpdateBannerCampaign(ctx context.Context, campaignId string, input BannerCampaignInput) (model_mysql.BannerCampaign, error) {
+	controller.Logger.Infof("start update banner campaign %s", campaignId)
+
+	// Leaving starts_at out must not restart a running campaign nor start a scheduled one early
+	current, err := controller.ModelRepository.GetBannerCampaign(ctx, campaignId)
+	if err != nil {
+		controller.Logger.Errorf("get banner campaign failed because: %s", err.Error())
+		return model_mysql.BannerCampaign{}, bannerCampaignError(err)
+	}
+
+	campaign, err := newBannerCampaign(input, current.StartsAt)
+	if err != nil {
+		return model_mysql.BannerCampaign{}, err
+	}
+	campaign.CampaignId = campaignId
+
+	result, err := controller.ModelRepository.UpdateBannerCampaign(ctx, campaign)
+	if err != nil {
+		controller.Logger.Errorf("update banner campaign failed because: %s", err.Error())
+		return result, bannerCampaignError(err)
+	}
+
+	controller.Logger.Info("update banner campaign completed")
+	return result, nil
+}
+
+func (controller Controller) DeleteBannerCampaign(ctx context.Context, campaignId string) error {
+	controller.Logger.Infof("start delete banner campaign %s", campaignId)
+
+	if err := controller.ModelRepository.DeleteBannerCampaign(ctx, campaignId); err != nil {
+		controller.Logger.Errorf("delete banner campaign failed because: %s", err.Error())
+		return bannerCampaignError(err)
+	}
+
+	controller.Logger.Info("delete banner campaign completed")
+	return nil
+}
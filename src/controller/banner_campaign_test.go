@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"assignment/entity"
+	"assignment/global"
+	mock_model "assignment/mocks/model"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestController_CreateBannerCampaign_UsersAudience(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().CreateBannerCampaign(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, campaign model_mysql.BannerCampaign) (model_mysql.BannerCampaign, error) {
+			if campaign.Audience != model_mysql.BannerAudienceUsers || campaign.StartsAt.IsZero() ||
+				!reflect.DeepEqual(campaign.UserIds, []string{"user-1", "user-2"}) {
+				t.Fatalf("unexpected campaign: %+v", campaign)
+			}
+			campaign.CampaignId = "campaign-1"
+			return campaign, nil
+		}).
+		Times(1)
+
+	out, err := c.CreateBannerCampaign(context.Background(), BannerCampaignInput{
+		Title: "Cashback", Audience: model_mysql.BannerAudienceUsers, UserIds: []string{"user-2", "user-1", "user-2"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.CampaignId != "campaign-1" || !reflect.DeepEqual(out.UserIds, []string{"user-1", "user-2"}) {
+		t.Fatalf("unexpected output: %+v", out)
+	}
+}
+
+func TestController_CreateBannerCampaign_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	currency := "THB"
+	startsAt := time.Now().Add(24 * time.Hour)
+	endsAt := time.Now()
+	for _, input := range []BannerCampaignInput{
+		{Title: "Cashback", Audience: model_mysql.BannerAudienceUsers},
+		{Title: "Cashback", Audience: model_mysql.BannerAudienceAll, UserIds: []string{"user-1"}},
+		{Title: "Cashback", Audience: model_mysql.BannerAudienceSegment},
+		{Title: "Cashback", Audience: model_mysql.BannerAudienceAll, Currency: &currency},
+		{Title: "Cashback", Audience: model_mysql.BannerAudienceAll, StartsAt: &startsAt, EndsAt: &endsAt},
+		{Title: "Cashback", Audience: model_mysql.BannerAudienceAll, Translations: map[string]BannerTranslationInput{
+			global.DefaultLanguage: {Title: "Cashback"},
+		}},
+		{Title: "Cashback", Audience: model_mysql.BannerAudienceAll, Translations: map[string]BannerTranslationInput{
+			"xx": {Title: "Cashback"},
+		}},
+	} {
+		_, err := c.CreateBannerCampaign(context.Background(), input)
+		sysErr, ok := err.(global.SystemError)
+		if !ok || sysErr.Code != global.InvalidBannerCampaign {
+			t.Fatalf("expected InvalidBannerCampaign for %+v, got %v", input, err)
+		}
+	}
+}
+
+func TestController_UpdateBannerCampaign_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	currency := "THB"
+	mockRepo.EXPECT().GetBannerCampaign(gomock.Any(), "campaign-1").
+		Return(entity.BannerCampaigns{}, model_mysql.ErrBannerCampaignNotFound).Times(1)
+	mockRepo.EXPECT().UpdateBannerCampaign(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := c.UpdateBannerCampaign(context.Background(), "campaign-1", BannerCampaignInput{
+		Title: "Travel", Audience: model_mysql.BannerAudienceSegment, Currency: &currency,
+	})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.BannerCampaignNotFound {
+		t.Fatalf("expected BannerCampaignNotFound, got %v", err)
+	}
+}
+
+func TestController_UpdateBannerCampaign_KeepsStartsAt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	scheduled := time.Now().Add(7 * 24 * time.Hour).Truncate(time.Second)
+	mockRepo.EXPECT().GetBannerCampaign(gomock.Any(), "campaign-1").
+		Return(entity.BannerCampaigns{CampaignId: "campaign-1", StartsAt: scheduled}, nil).Times(1)
+	mockRepo.EXPECT().UpdateBannerCampaign(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, campaign model_mysql.BannerCampaign) (model_mysql.BannerCampaign, error) {
+			if campaign.CampaignId != "campaign-1" || !campaign.StartsAt.Equal(scheduled) {
+				t.Fatalf("unexpected campaign: %+v", campaign)
+			}
+			return campaign, nil
+		}).
+		Times(1)
+
+	if _, err := c.UpdateBannerCampaign(context.Background(), "campaign-1", BannerCampaignInput{
+		Title: "Travel", Audience: model_mysql.BannerAudienceAll,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
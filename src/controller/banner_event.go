@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"assignment/bannerevent"
+	"assignment/entity"
+	"assignment/global"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"time"
+)
+
+const (
+	bannerReportDateLayout  = "2006-01-02"
+	bannerReportMaxDays     = 366
+	bannerReportDefaultDays = 30
+)
+
+// BannerReportInput takes an inclusive range of local dates, the last 30 days when empty
+type BannerReportInput struct {
+	From string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To   string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type BannerReportOutput struct {
+	From    string                         `json:"from"`
+	To      string                         `json:"to"`
+	Banners []model_mysql.BannerEventStats `json:"banners"`
+}
+
+func invalidBannerReport(reason global.Reason) error {
+	return global.NewSystemError(global.InvalidBannerReport, reason)
+}
+
+// TrackBannerEvent records an impression, click or dismissal of a banner shown to the user, other banners are not
+// found. A dismissal is stored right away so the banner is gone on the next get-user-banners, the events themselves
+// are written in the background and a dropped event does not fail the request.
+func (controller Controller) TrackBannerEvent(ctx context.Context, bannerId, eventType string) error {
+	controller.Logger.Infof("start track banner %s of banner %s", eventType, bannerId)
+
+	// Only banners shown to the user count, anything else would skew the report
+	shown, err := controller.ModelRepository.IsUserBanner(ctx, controller.UserId, bannerId)
+	if err != nil {
+		controller.Logger.Errorf("check banner of user failed because: %s", err.Error())
+		return global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+	if !shown {
+		controller.Logger.Errorf("banner %s is not shown to the user", bannerId)
+		return global.NewSystemError(global.BannerNotFound)
+	}
+
+	if eventType == bannerevent.TypeDismiss {
+		if err := controller.ModelRepository.DismissBanner(ctx, controller.UserId, bannerId); err != nil {
+			controller.Logger.Errorf("dismiss banner failed because: %s", err.Error())
+			return global.NewRawSystemError(global.DatabaseError, err.Error())
+		}
+	}
+
+	if controller.BannerEvents == nil {
+		controller.Logger.Warnf("banner %s event is dropped because banner event recording is not started", eventType)
+	} else if err := controller.BannerEvents.Record(entity.BannerEvents{
+		BannerId:  bannerId,
+		UserId:    controller.UserId,
+		EventType: eventType,
+		CreatedAt: time.Now(),
+	}); err != nil {
+		controller.Logger.Warnf("banner %s event is dropped because: %s", eventType, err.Error())
+	}
+
+	controller.Logger.Info("track banner event completed")
+	return nil
+}
+
+func (controller Controller) GetBannerReport(ctx context.Context, input BannerReportInput) (BannerReportOutput, error) {
+	controller.Logger.Infof("start get banner report from %q to %q", input.From, input.To)
+
+	now := time.Now()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if input.To != "" {
+		var err error
+		if to, err = time.ParseInLocation(bannerReportDateLayout, input.To, time.Local); err != nil {
+			return BannerReportOutput{}, invalidBannerReport(global.NewReason(global.ReasonInvalidDate, "to"))
+		}
+	}
+	from := to.AddDate(0, 0, 1-bannerReportDefaultDays)
+	if input.From != "" {
+		var err error
+		if from, err = time.ParseInLocation(bannerReportDateLayout, input.From, time.Local); err != nil {
+			return BannerReportOutput{}, invalidBannerReport(global.NewReason(global.ReasonInvalidDate, "from"))
+		}
+	}
+	if from.After(to) {
+		return BannerReportOutput{}, invalidBannerReport(global.NewReason(global.ReasonFromAfterTo))
+	}
+	if to.Sub(from) >= bannerReportMaxDays*24*time.Hour {
+		return BannerReportOutput{}, invalidBannerReport(global.NewReason(global.ReasonReportTooLong, bannerReportMaxDays))
+	}
+
+	output := BannerReportOutput{
+		From: from.Format(bannerReportDateLayout),
+		To:   to.Format(bannerReportDateLayout),
+	}
+
+	var err error
+	output.Banners, err = controller.ModelRepository.GetBannerEventReport(ctx, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		controller.Logger.Errorf("get banner report failed because: %s", err.Error())
+		return output, global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	controller.Logger.Info("get banner report completed")
+	return output, nil
+}
@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"assignment/bannerevent"
+	"assignment/global"
+	fake_bannerevent "assignment/mocks/bannerevent"
+	mock_model "assignment/mocks/model"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestController_TrackBannerEvent_Click(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	recorder := fake_bannerevent.NewRecorder()
+	c := newTestController(mockRepo)
+	c.BannerEvents = recorder
+
+	mockRepo.EXPECT().IsUserBanner(gomock.Any(), "test-user-id", "banner-1").Return(true, nil).Times(1)
+
+	if err := c.TrackBannerEvent(context.Background(), "banner-1", bannerevent.TypeClick); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(recorder.Events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(recorder.Events))
+	}
+	event := recorder.Events[0]
+	if event.BannerId != "banner-1" || event.UserId != "test-user-id" || event.EventType != bannerevent.TypeClick {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+}
+
+func TestController_TrackBannerEvent_DismissIsStored(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	recorder := fake_bannerevent.NewRecorder()
+	c := newTestController(mockRepo)
+	c.BannerEvents = recorder
+
+	mockRepo.EXPECT().IsUserBanner(gomock.Any(), "test-user-id", "banner-1").Return(true, nil).Times(1)
+	mockRepo.EXPECT().DismissBanner(gomock.Any(), "test-user-id", "banner-1").Return(nil).Times(1)
+
+	if err := c.TrackBannerEvent(context.Background(), "banner-1", bannerevent.TypeDismiss); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(recorder.Events) != 1 || recorder.Events[0].EventType != bannerevent.TypeDismiss {
+		t.Fatalf("unexpected events: %+v", recorder.Events)
+	}
+}
+
+func TestController_TrackBannerEvent_DroppedEventDoesNotFail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	recorder := fake_bannerevent.NewRecorder()
+	recorder.Err = bannerevent.ErrBufferFull
+	c := newTestController(mockRepo)
+	c.BannerEvents = recorder
+
+	mockRepo.EXPECT().IsUserBanner(gomock.Any(), "test-user-id", "banner-1").Return(true, nil).Times(1)
+
+	if err := c.TrackBannerEvent(context.Background(), "banner-1", bannerevent.TypeImpression); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestController_TrackBannerEvent_DismissRepoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	recorder := fake_bannerevent.NewRecorder()
+	c := newTestController(mockRepo)
+	c.BannerEvents = recorder
+
+	mockRepo.EXPECT().IsUserBanner(gomock.Any(), "test-user-id", "banner-1").Return(true, nil).Times(1)
+	mockRepo.EXPECT().DismissBanner(gomock.Any(), "test-user-id", "banner-1").Return(errors.New("boom")).Times(1)
+
+	err := c.TrackBannerEvent(context.Background(), "banner-1", bannerevent.TypeDismiss)
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.DatabaseError {
+		t.Fatalf("expected DatabaseError, got %v", err)
+	}
+	if len(recorder.Events) != 0 {
+		t.Fatalf("expected no event when the dismissal is not stored")
+	}
+}
+
+func TestController_TrackBannerEvent_BannerNotShown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	recorder := fake_bannerevent.NewRecorder()
+	c := newTestController(mockRepo)
+	c.BannerEvents = recorder
+
+	mockRepo.EXPECT().IsUserBanner(gomock.Any(), "test-user-id", "banner-9").Return(false, nil).Times(1)
+	mockRepo.EXPECT().DismissBanner(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	err := c.TrackBannerEvent(context.Background(), "banner-9", bannerevent.TypeDismiss)
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.BannerNotFound {
+		t.Fatalf("expected BannerNotFound, got %v", err)
+	}
+	if len(recorder.Events) != 0 {
+		t.Fatalf("expected no event for a banner the user is not shown")
+	}
+}
+
+func TestController_GetBannerReport_InclusiveRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2026, 10, 8, 0, 0, 0, 0, time.Local)
+	mockRepo.EXPECT().GetBannerEventReport(gomock.Any(), from, to).
+		Return([]model_mysql.BannerEventStats{{BannerId: "banner-1", Impressions: 10, Clicks: 1}}, nil).Times(1)
+
+	out, err := c.GetBannerReport(context.Background(), BannerReportInput{From: "2026-10-01", To: "2026-10-07"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.From != "2026-10-01" || out.To != "2026-10-07" || len(out.Banners) != 1 {
+		t.Fatalf("unexpected output: %+v", out)
+	}
+}
+
+func TestController_GetBannerReport_InvalidRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	for _, input := range []BannerReportInput{
+		{From: "2026-10-08", To: "2026-10-01"},
+		{From: "2025-01-01", To: "2026-10-01"},
+	} {
+		_, err := c.GetBannerReport(context.Background(), input)
+		sysErr, ok := err.(global.SystemError)
+		if !ok || sysErr.Code != global.InvalidBannerReport {
+			t.Fatalf("expected InvalidBannerReport for %+v, got %v", input, err)
+		}
+	}
+}
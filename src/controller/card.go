@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"assignment/global"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+)
+
+const (
+	CardStatusActive = "active"
+	CardStatusFrozen = "frozen"
+	CardStatusLost   = "lost"
+	CardStatusClosed = "closed"
+)
+
+// cardStatusTransitions lists the statuses a card may move to from each status. Lost and closed cards can never be
+// used again, a lost card can only be closed once it has been replaced.
+var cardStatusTransitions = map[string][]string{
+	CardStatusActive: {CardStatusFrozen, CardStatusLost, CardStatusClosed},
+	CardStatusFrozen: {CardStatusActive, CardStatusLost, CardStatusClosed},
+	CardStatusLost:   {CardStatusClosed},
+	CardStatusClosed: {},
+}
+
+func canTransitCardStatus(from, to string) bool {
+	return slices.Contains(cardStatusTransitions[from], to)
+}
+
+type CardStatusOutput struct {
+	CardId string `json:"card_id"`
+	Status string `json:"status"`
+}
+
+func (controller Controller) FreezeCard(ctx context.Context, cardId string) (CardStatusOutput, error) {
+	return controller.changeCardStatus(ctx, cardId, CardStatusFrozen)
+}
+
+func (controller Controller) UnfreezeCard(ctx context.Context, cardId string) (CardStatusOutput, error) {
+	return controller.changeCardStatus(ctx, cardId, CardStatusActive)
+}
+
+func (controller Controller) ReportLostCard(ctx context.Context, cardId string) (CardStatusOutput, error) {
+	return controller.changeCardStatus(ctx, cardId, CardStatusLost)
+}
+
+func (controller Controller) changeCardStatus(ctx context.Context, cardId, toStatus string) (CardStatusOutput, error) {
+	controller.Logger.Infof("start change card %s status to %s", cardId, toStatus)
+	output := CardStatusOutput{}
+
+	currentStatus, err := controller.ModelRepository.GetUserCardStatus(ctx, controller.UserId, cardId)
+	if err != nil {
+		controller.Logger.Errorf("get card status failed because: %s", err.Error())
+		return output, cardError(err)
+	}
+
+	fromStatus := strings.ToLower(currentStatus)
+	if !canTransitCardStatus(fromStatus, toStatus) {
+		controller.Logger.Errorf("card %s cannot be changed from %s to %s", cardId, fromStatus, toStatus)
+		return output, global.NewSystemError(global.InvalidCardTransition, fromStatus, toStatus)
+	}
+
+	if err := controller.ModelRepository.UpdateCardStatus(ctx, cardId, currentStatus, toStatus); err != nil {
+		controller.Logger.Errorf("update card status failed because: %s", err.Error())
+		return output, cardError(err)
+	}
+
+	output.CardId = cardId
+	output.Status = toStatus
+
+	controller.Logger.Info("change card status completed")
+	return output, nil
+}
+
+func cardError(err error) error {
+	switch {
+	case errors.Is(err, model_mysql.ErrCardNotFound):
+		return global.NewSystemError(global.CardNotFound)
+	case errors.Is(err, model_mysql.ErrCardStatusChanged):
+		return global.NewSystemError(global.CardStatusChanged)
+	}
+	return global.NewRawSystemError(global.DatabaseError, err.Error())
+}
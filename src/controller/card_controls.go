@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"assignment/entity"
+	"assignment/global"
+	"context"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+type UpdateCardControlsInput struct {
+	DailyLimit         *decimal.Decimal `json:"daily_limit" validate:"required"`
+	OnlineLimit        *decimal.Decimal `json:"online_limit" validate:"required"`
+	AtmLimit           *decimal.Decimal `json:"atm_limit" validate:"required"`
+	ContactlessEnabled *bool            `json:"contactless_enabled" validate:"required"`
+	OnlineEnabled      *bool            `json:"online_enabled" validate:"required"`
+	OverseasEnabled    *bool            `json:"overseas_enabled" validate:"required"`
+}
+
+func defaultCardControls() entity.DebitCardControls {
+	return entity.DebitCardControls{
+		DailyLimit:         global.CardDefaultDailyLimit,
+		OnlineLimit:        global.CardDefaultOnlineLimit,
+		AtmLimit:           global.CardDefaultAtmLimit,
+		ContactlessEnabled: global.CardDefaultContactlessEnabled,
+		OnlineEnabled:      global.CardDefaultOnlineEnabled,
+		OverseasEnabled:    global.CardDefaultOverseasEnabled,
+	}
+}
+
+func (controller Controller) GetCardControls(ctx context.Context, cardId string) (entity.DebitCardControls, error) {
+	controller.Logger.Infof("start get controls of card %s", cardId)
+
+	controls, err := controller.ModelRepository.GetCardControls(ctx, controller.UserId, cardId, defaultCardControls())
+	if err != nil {
+		controller.Logger.Errorf("get card controls failed because: %s", err.Error())
+		return entity.DebitCardControls{}, cardError(err)
+	}
+
+	controller.Logger.Info("get card controls completed")
+	return controls, nil
+}
+
+// UpdateCardControls replaces the limits and channel toggles of the card, every field has to be given
+func (controller Controller) UpdateCardControls(ctx context.Context, cardId string, input UpdateCardControlsInput) (entity.DebitCardControls, error) {
+	controller.Logger.Infof("start update controls of card %s", cardId)
+
+	controls := entity.DebitCardControls{
+		CardId:             cardId,
+		DailyLimit:         *input.DailyLimit,
+		OnlineLimit:        *input.OnlineLimit,
+		AtmLimit:           *input.AtmLimit,
+		ContactlessEnabled: *input.ContactlessEnabled,
+		OnlineEnabled:      *input.OnlineEnabled,
+		OverseasEnabled:    *input.OverseasEnabled,
+	}
+	if err := checkCardLimits(controls); err != nil {
+		controller.Logger.Errorf("user %s requested invalid card limits: %s", controller.UserId, err.Error())
+		return entity.DebitCardControls{}, err
+	}
+
+	if err := controller.ModelRepository.SaveCardControls(ctx, controller.UserId, controls); err != nil {
+		controller.Logger.Errorf("save card controls failed because: %s", err.Error())
+		return entity.DebitCardControls{}, cardError(err)
+	}
+
+	controller.Logger.Info("update card controls completed")
+	return controller.GetCardControls(ctx, cardId)
+}
+
+func checkCardLimits(controls entity.DebitCardControls) error {
+	for _, limit := range []struct {
+		name  string
+		value decimal.Decimal
+	}{
+		{"daily_limit", controls.DailyLimit},
+		{"online_limit", controls.OnlineLimit},
+		{"atm_limit", controls.AtmLimit},
+	} {
+		if limit.value.IsNegative() || !limit.value.Equal(limit.value.Round(2)) || limit.value.GreaterThan(global.CardMaxLimit) {
+			return invalidCardLimitsError(global.NewReason(global.ReasonInvalidCardLimit, limit.name, global.CardMaxLimit.String()))
+		}
+	}
+	if controls.OnlineLimit.GreaterThan(controls.DailyLimit) {
+		return invalidCardLimitsError(global.NewReason(global.ReasonOnlineAboveDailyLimit))
+	}
+	return nil
+}
+
+func invalidCardLimitsError(reason global.Reason) global.SystemError {
+	return global.NewSystemError(global.InvalidCardLimits, reason)
+}
+
+// Card transaction channels
+const (
+	CardChannelPos         = "pos"
+	CardChannelContactless = "contactless"
+	CardChannelOnline      = "online"
+	CardChannelAtm         = "atm"
+)
+
+type CardTransaction struct {
+	Channel  string
+	Overseas bool
+	Amount   decimal.Decimal
+}
+
+// CardUsage is what the card has already used today, it is kept by the transaction path.
+// SpentToday covers every purchase including online ones, ATM withdrawals only count towards WithdrawnToday.
+type CardUsage struct {
+	SpentToday       decimal.Decimal
+	SpentOnlineToday decimal.Decimal
+	WithdrawnToday   decimal.Decimal
+}
+
+// AuthorizeCardTransaction checks a card transaction against the card status, channel toggles and daily limits.
+// It is meant to be called by the transaction path before money moves, usage is what the card used so far today.
+func (controller Controller) AuthorizeCardTransaction(ctx context.Context, cardId string, transaction CardTransaction, usage CardUsage) error {
+	status, err := controller.ModelRepository.GetUserCardStatus(ctx, controller.UserId, cardId)
+	if err != nil {
+		controller.Logger.Errorf("get card status failed because: %s", err.Error())
+		return cardError(err)
+	}
+
+	controls, err := controller.ModelRepository.GetCardControls(ctx, controller.UserId, cardId, defaultCardControls())
+	if err != nil {
+		controller.Logger.Errorf("get card controls failed because: %s", err.Error())
+		return cardError(err)
+	}
+
+	if err := authorizeCardTransaction(strings.ToLower(status), controls, transaction, usage); err != nil {
+		controller.Logger.Errorf("card %s transaction is declined because: %s", cardId, err.Error())
+		return err
+	}
+	return nil
+}
+
+func authorizeCardTransaction(status string, controls entity.DebitCardControls, transaction CardTransaction, usage CardUsage) error {
+	if status != CardStatusActive {
+		return global.NewSystemError(global.CardNotUsable, status)
+	}
+
+	channelEnabled := map[string]bool{
+		CardChannelPos:         true,
+		CardChannelContactless: controls.ContactlessEnabled,
+		CardChannelOnline:      controls.OnlineEnabled,
+		CardChannelAtm:         true,
+	}
+	if !channelEnabled[transaction.Channel] {
+		return cardChannelDisabledError(transaction.Channel)
+	}
+	if transaction.Overseas && !controls.OverseasEnabled {
+		return cardChannelDisabledError("overseas")
+	}
+
+	if transaction.Channel == CardChannelAtm {
+		return checkCardLimit("atm", controls.AtmLimit, usage.WithdrawnToday, transaction.Amount)
+	}
+	if transaction.Channel == CardChannelOnline {
+		if err := checkCardLimit("online", controls.OnlineLimit, usage.SpentOnlineToday, transaction.Amount); err != nil {
+			return err
+		}
+	}
+	return checkCardLimit("daily", controls.DailyLimit, usage.SpentToday, transaction.Amount)
+}
+
+func cardChannelDisabledError(channel string) global.SystemError {
+	return global.NewSystemError(global.CardChannelDisabled, channel)
+}
+
+func checkCardLimit(name string, limit, used, amount decimal.Decimal) error {
+	if used.Add(amount).GreaterThan(limit) {
+		return global.NewSystemError(global.CardLimitExceeded, name, limit.StringFixed(2))
+	}
+	return nil
+}
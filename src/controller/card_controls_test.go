@@ -0,0 +1,165 @@
+package controller
+
+import (
+	"assignment/entity"
+	"assignment/global"
+	mock_model "assignment/mocks/model"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+)
+
+func testCardControls() entity.DebitCardControls {
+	return entity.DebitCardControls{
+		CardId:             "card-1",
+		DailyLimit:         decimal.NewFromInt(1000),
+		OnlineLimit:        decimal.NewFromInt(300),
+		AtmLimit:           decimal.NewFromInt(500),
+		ContactlessEnabled: true,
+		OnlineEnabled:      true,
+		OverseasEnabled:    false,
+	}
+}
+
+func TestController_GetCardControls_Defaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().GetCardControls(gomock.Any(), "test-user-id", "card-1", defaultCardControls()).
+		DoAndReturn(func(_ context.Context, _, cardId string, defaults entity.DebitCardControls) (entity.DebitCardControls, error) {
+			defaults.CardId = cardId
+			return defaults, nil
+		}).
+		Times(1)
+
+	out, err := c.GetCardControls(context.Background(), "card-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !out.DailyLimit.Equal(global.CardDefaultDailyLimit) || out.OverseasEnabled != global.CardDefaultOverseasEnabled {
+		t.Fatalf("expected default controls, got %+v", out)
+	}
+}
+
+func TestController_UpdateCardControls_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	expected := testCardControls()
+	mockRepo.EXPECT().SaveCardControls(gomock.Any(), "test-user-id", expected).Return(nil).Times(1)
+	mockRepo.EXPECT().GetCardControls(gomock.Any(), "test-user-id", "card-1", gomock.Any()).Return(expected, nil).Times(1)
+
+	out, err := c.UpdateCardControls(context.Background(), "card-1", UpdateCardControlsInput{
+		DailyLimit:         &expected.DailyLimit,
+		OnlineLimit:        &expected.OnlineLimit,
+		AtmLimit:           &expected.AtmLimit,
+		ContactlessEnabled: &expected.ContactlessEnabled,
+		OnlineEnabled:      &expected.OnlineEnabled,
+		OverseasEnabled:    &expected.OverseasEnabled,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.CardId != "card-1" {
+		t.Fatalf("unexpected output: %+v", out)
+	}
+}
+
+func TestController_UpdateCardControls_InvalidLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	enabled := true
+	for _, limits := range [][3]decimal.Decimal{
+		{decimal.NewFromInt(-1), decimal.Zero, decimal.Zero},
+		{decimal.RequireFromString("10.001"), decimal.Zero, decimal.Zero},
+		{global.CardMaxLimit.Add(decimal.NewFromInt(1)), decimal.Zero, decimal.Zero},
+		{decimal.NewFromInt(100), decimal.NewFromInt(200), decimal.Zero},
+	} {
+		_, err := c.UpdateCardControls(context.Background(), "card-1", UpdateCardControlsInput{
+			DailyLimit:         &limits[0],
+			OnlineLimit:        &limits[1],
+			AtmLimit:           &limits[2],
+			ContactlessEnabled: &enabled,
+			OnlineEnabled:      &enabled,
+			OverseasEnabled:    &enabled,
+		})
+		sysErr, ok := err.(global.SystemError)
+		if !ok || sysErr.Code != global.InvalidCardLimits {
+			t.Fatalf("limits %v: expected InvalidCardLimits error, got %v", limits, err)
+		}
+	}
+}
+
+func TestAuthorizeCardTransaction(t *testing.T) {
+	controls := testCardControls()
+	noUsage := CardUsage{}
+
+	cases := []struct {
+		name        string
+		status      string
+		transaction CardTransaction
+		usage       CardUsage
+		code        int64
+	}{
+		{"pos within limit", CardStatusActive, CardTransaction{Channel: CardChannelPos, Amount: decimal.NewFromInt(1000)}, noUsage, 0},
+		{"frozen card", CardStatusFrozen, CardTransaction{Channel: CardChannelPos, Amount: decimal.NewFromInt(1)}, noUsage, global.CardNotUsable},
+		{"overseas disabled", CardStatusActive, CardTransaction{Channel: CardChannelPos, Overseas: true, Amount: decimal.NewFromInt(1)}, noUsage, global.CardChannelDisabled},
+		{"unknown channel", CardStatusActive, CardTransaction{Channel: "telepathy", Amount: decimal.NewFromInt(1)}, noUsage, global.CardChannelDisabled},
+		{"daily limit", CardStatusActive, CardTransaction{Channel: CardChannelContactless, Amount: decimal.NewFromInt(2)},
+			CardUsage{SpentToday: decimal.NewFromInt(999)}, global.CardLimitExceeded},
+		{"online limit", CardStatusActive, CardTransaction{Channel: CardChannelOnline, Amount: decimal.NewFromInt(301)}, noUsage, global.CardLimitExceeded},
+		{"atm does not use daily limit", CardStatusActive, CardTransaction{Channel: CardChannelAtm, Amount: decimal.NewFromInt(500)},
+			CardUsage{SpentToday: decimal.NewFromInt(1000)}, 0},
+		{"atm limit", CardStatusActive, CardTransaction{Channel: CardChannelAtm, Amount: decimal.NewFromInt(1)},
+			CardUsage{WithdrawnToday: decimal.NewFromInt(500)}, global.CardLimitExceeded},
+	}
+
+	for _, tc := range cases {
+		err := authorizeCardTransaction(tc.status, controls, tc.transaction, tc.usage)
+		if tc.code == 0 {
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+		sysErr, ok := err.(global.SystemError)
+		if !ok || sysErr.Code != tc.code {
+			t.Fatalf("%s: expected error code %d, got %v", tc.name, tc.code, err)
+		}
+	}
+
+	contactlessOff := controls
+	contactlessOff.ContactlessEnabled = false
+	err := authorizeCardTransaction(CardStatusActive, contactlessOff, CardTransaction{Channel: CardChannelContactless, Amount: decimal.NewFromInt(1)}, noUsage)
+	if sysErr, ok := err.(global.SystemError); !ok || sysErr.Code != global.CardChannelDisabled {
+		t.Fatalf("expected CardChannelDisabled error, got %v", err)
+	}
+}
+
+func TestController_AuthorizeCardTransaction_NotOwned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().GetUserCardStatus(gomock.Any(), "test-user-id", "card-1").Return("", model_mysql.ErrCardNotFound).Times(1)
+
+	err := c.AuthorizeCardTransaction(context.Background(), "card-1", CardTransaction{Channel: CardChannelPos, Amount: decimal.NewFromInt(1)}, CardUsage{})
+	if sysErr, ok := err.(global.SystemError); !ok || sysErr.Code != global.CardNotFound {
+		t.Fatalf("expected CardNotFound error, got %v", err)
+	}
+}
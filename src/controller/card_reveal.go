@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"assignment/global"
+	model_mysql "assignment/model/mysql"
+	"assignment/util"
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+type RequestCardRevealInput struct {
+	Pin       string `json:"pin" validate:"required"`
+	IpAddress string `json:"-"`
+}
+
+type RequestCardRevealOutput struct {
+	RevealToken string    `json:"reveal_token"`
+	ExpiredAt   time.Time `json:"expired_at"`
+}
+
+// RequestCardReveal is the pin step-up of the card reveal, it issues a short-lived token for a single card
+func (controller Controller) RequestCardReveal(ctx context.Context, cardId string, input RequestCardRevealInput) (RequestCardRevealOutput, error) {
+	controller.Logger.Infof("start request reveal of card %s", cardId)
+	output := RequestCardRevealOutput{}
+
+	status, err := controller.ModelRepository.GetUserCardStatus(ctx, controller.UserId, cardId)
+	if err != nil {
+		controller.Logger.Errorf("get card status failed because: %s", err.Error())
+		return output, cardError(err)
+	}
+	status = strings.ToLower(status)
+	if status != CardStatusActive && status != CardStatusFrozen {
+		return output, global.NewSystemError(global.CardNotRevealable, status)
+	}
+
+	issued, err := controller.ModelRepository.CountCardRevealEvents(ctx, controller.UserId,
+		model_mysql.CardRevealEventTokenIssued, time.Now().Add(-time.Hour))
+	if err != nil {
+		controller.Logger.Errorf("count card reveal events failed because: %s", err.Error())
+		return output, global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+	if issued >= int64(global.CardRevealMaxPerHour) {
+		controller.Logger.Errorf("user %s reached the card reveal limit", controller.UserId)
+		controller.auditCardReveal(ctx, cardId, model_mysql.CardRevealEventRateLimited, input.IpAddress)
+		return output, global.NewSystemError(global.CardRevealRateLimited)
+	}
+
+	if _, err := controller.verifyPin(ctx, controller.UserId, input.Pin); err != nil {
+		var sysErr global.SystemError
+		if errors.As(err, &sysErr) && (sysErr.Code == global.IncorrectPin || sysErr.Code == global.PinLocked) {
+			controller.auditCardReveal(ctx, cardId, model_mysql.CardRevealEventPinFailed, input.IpAddress)
+		}
+		return output, err
+	}
+
+	output.RevealToken, output.ExpiredAt, err = controller.ModelRepository.CreateCardRevealToken(ctx, controller.UserId, cardId, global.CardRevealTokenLifetime)
+	if err != nil {
+		controller.Logger.Errorf("create card reveal token failed because: %s", err.Error())
+		return RequestCardRevealOutput{}, global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	// The audit row is what the rate limit counts, so a token is not handed out without it
+	if err := controller.ModelRepository.AuditCardReveal(ctx, controller.UserId, cardId, model_mysql.CardRevealEventTokenIssued, input.IpAddress); err != nil {
+		controller.Logger.Errorf("audit card reveal failed because: %s", err.Error())
+		return RequestCardRevealOutput{}, global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	controller.Logger.Info("request card reveal completed")
+	return output, nil
+}
+
+type RevealCardInput struct {
+	RevealToken string `json:"reveal_token" validate:"required"`
+	IpAddress   string `json:"-"`
+}
+
+type RevealCardOutput struct {
+	CardId          string    `json:"card_id"`
+	Number          string    `json:"number"`
+	Secret          string    `json:"secret"`
+	SecretExpiredAt time.Time `json:"secret_expired_at"`
+}
+
+// RevealCard trades a reveal token for the unmasked card number and the current card secret
+func (controller Controller) RevealCard(ctx context.Context, cardId string, input RevealCardInput) (RevealCardOutput, error) {
+	controller.Logger.Infof("start reveal card %s", cardId)
+	output := RevealCardOutput{}
+
+	if err := controller.ModelRepository.UseCardRevealToken(ctx, controller.UserId, cardId, input.RevealToken); err != nil {
+		controller.Logger.Errorf("use card reveal token failed because: %s", err.Error())
+		if errors.Is(err, model_mysql.ErrCardRevealTokenInvalid) {
+			controller.auditCardReveal(ctx, cardId, model_mysql.CardRevealEventTokenRejected, input.IpAddress)
+			return output, global.NewSystemError(global.InvalidRevealToken)
+		}
+		return output, global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	number, err := controller.ModelRepository.GetUserCardNumber(ctx, controller.UserId, cardId)
+	if err != nil {
+		controller.Logger.Errorf("get card number failed because: %s", err.Error())
+		return output, cardError(err)
+	}
+
+	// Never reveal a card without an audit trail
+	if err := controller.ModelRepository.AuditCardReveal(ctx, controller.UserId, cardId, model_mysql.CardRevealEventRevealed, input.IpAddress); err != nil {
+		controller.Logger.Errorf("audit card reveal failed because: %s", err.Error())
+		return output, global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	output.CardId = cardId
+	output.Number = number
+	output.Secret, output.SecretExpiredAt = util.CardSecret(global.CardSecretKey, number, time.Now(), global.CardSecretPeriod)
+
+	controller.Logger.Info("reveal card completed")
+	return output, nil
+}
+
+// auditCardReveal records a rejected step of the card reveal, failing to do so must not hide the rejection itself
+func (controller Controller) auditCardReveal(ctx context.Context, cardId, event, ipAddress string) {
+	if err := controller.ModelRepository.AuditCardReveal(ctx, controller.UserId, cardId, event, ipAddress); err != nil {
+		controller.Logger.Errorf("audit card reveal %s failed because: %s", event, err.Error())
+	}
+}
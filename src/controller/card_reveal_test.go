@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"assignment/entity"
+	"assignment/global"
+	mock_model "assignment/mocks/model"
+	model_mysql "assignment/model/mysql"
+	"assignment/util"
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestController_RequestCardReveal_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	hashedPin, _ := util.HashPassword("739146")
+	expiredAt := time.Now().Add(global.CardRevealTokenLifetime)
+
+	mockRepo.EXPECT().GetUserCardStatus(gomock.Any(), "test-user-id", "card-1").Return("Active", nil).Times(1)
+	mockRepo.EXPECT().CountCardRevealEvents(gomock.Any(), "test-user-id", model_mysql.CardRevealEventTokenIssued, gomock.Any()).Return(int64(0), nil).Times(1)
+	mockRepo.EXPECT().ReservePinAttempt(gomock.Any(), "test-user-id", gomock.Any()).Return(entity.UserPin{Pin: hashedPin}, nil).Times(1)
+	mockRepo.EXPECT().ResetPinLockout(gomock.Any(), "test-user-id").Return(nil).Times(1)
+	mockRepo.EXPECT().CreateCardRevealToken(gomock.Any(), "test-user-id", "card-1", global.CardRevealTokenLifetime).Return("reveal-token", expiredAt, nil).Times(1)
+	mockRepo.EXPECT().AuditCardReveal(gomock.Any(), "test-user-id", "card-1", model_mysql.CardRevealEventTokenIssued, "10.0.0.1").Return(nil).Times(1)
+
+	out, err := c.RequestCardReveal(context.Background(), "card-1", RequestCardRevealInput{Pin: "739146", IpAddress: "10.0.0.1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.RevealToken != "reveal-token" || !out.ExpiredAt.Equal(expiredAt) {
+		t.Fatalf("unexpected output: %+v", out)
+	}
+}
+
+func TestController_RequestCardReveal_IncorrectPinIsAudited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	hashedPin, _ := util.HashPassword("739146")
+
+	mockRepo.EXPECT().GetUserCardStatus(gomock.Any(), "test-user-id", "card-1").Return(CardStatusFrozen, nil).Times(1)
+	mockRepo.EXPECT().CountCardRevealEvents(gomock.Any(), "test-user-id", model_mysql.CardRevealEventTokenIssued, gomock.Any()).Return(int64(0), nil).Times(1)
+	mockRepo.EXPECT().ReservePinAttempt(gomock.Any(), "test-user-id", gomock.Any()).Return(entity.UserPin{Pin: hashedPin}, nil).Times(1)
+	mockRepo.EXPECT().AuditCardReveal(gomock.Any(), "test-user-id", "card-1", model_mysql.CardRevealEventPinFailed, "").Return(nil).Times(1)
+
+	_, err := c.RequestCardReveal(context.Background(), "card-1", RequestCardRevealInput{Pin: "000000"})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.IncorrectPin {
+		t.Fatalf("expected IncorrectPin error, got %v", err)
+	}
+}
+
+func TestController_RequestCardReveal_RateLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().GetUserCardStatus(gomock.Any(), "test-user-id", "card-1").Return(CardStatusActive, nil).Times(1)
+	mockRepo.EXPECT().CountCardRevealEvents(gomock.Any(), "test-user-id", model_mysql.CardRevealEventTokenIssued, gomock.Any()).
+		Return(int64(global.CardRevealMaxPerHour), nil).Times(1)
+	mockRepo.EXPECT().AuditCardReveal(gomock.Any(), "test-user-id", "card-1", model_mysql.CardRevealEventRateLimited, "").Return(nil).Times(1)
+
+	_, err := c.RequestCardReveal(context.Background(), "card-1", RequestCardRevealInput{Pin: "739146"})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.CardRevealRateLimited {
+		t.Fatalf("expected CardRevealRateLimited error, got %v", err)
+	}
+}
+
+func TestController_RequestCardReveal_LostCard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().GetUserCardStatus(gomock.Any(), "test-user-id", "card-1").Return(CardStatusLost, nil).Times(1)
+
+	_, err := c.RequestCardReveal(context.Background(), "card-1", RequestCardRevealInput{Pin: "739146"})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.CardNotRevealable {
+		t.Fatalf("expected CardNotRevealable error, got %v", err)
+	}
+}
+
+func TestController_RevealCard_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().UseCardRevealToken(gomock.Any(), "test-user-id", "card-1", "reveal-token").Return(nil).Times(1)
+	mockRepo.EXPECT().GetUserCardNumber(gomock.Any(), "test-user-id", "card-1").Return("4772 1234 5678 1428", nil).Times(1)
+	mockRepo.EXPECT().AuditCardReveal(gomock.Any(), "test-user-id", "card-1", model_mysql.CardRevealEventRevealed, "").Return(nil).Times(1)
+
+	out, err := c.RevealCard(context.Background(), "card-1", RevealCardInput{RevealToken: "reveal-token"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.CardId != "card-1" || out.Number != "4772 1234 5678 1428" {
+		t.Fatalf("unexpected output: %+v", out)
+	}
+	if !regexp.MustCompile(`^\d{3}$`).MatchString(out.Secret) {
+		t.Fatalf("unexpected secret: %q", out.Secret)
+	}
+	if !out.SecretExpiredAt.After(time.Now()) || out.SecretExpiredAt.After(time.Now().Add(global.CardSecretPeriod)) {
+		t.Fatalf("unexpected secret expiry: %s", out.SecretExpiredAt)
+	}
+}
+
+func TestController_RevealCard_InvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().UseCardRevealToken(gomock.Any(), "test-user-id", "card-1", "used-token").Return(model_mysql.ErrCardRevealTokenInvalid).Times(1)
+	mockRepo.EXPECT().AuditCardReveal(gomock.Any(), "test-user-id", "card-1", model_mysql.CardRevealEventTokenRejected, "").Return(nil).Times(1)
+
+	_, err := c.RevealCard(context.Background(), "card-1", RevealCardInput{RevealToken: "used-token"})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.InvalidRevealToken {
+		t.Fatalf("expected InvalidRevealToken error, got %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"assignment/global"
+	mock_model "assignment/mocks/model"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestCanTransitCardStatus(t *testing.T) {
+	cases := []struct {
+		from, to string
+		allowed  bool
+	}{
+		{CardStatusActive, CardStatusFrozen, true},
+		{CardStatusFrozen, CardStatusActive, true},
+		{CardStatusActive, CardStatusLost, true},
+		{CardStatusFrozen, CardStatusLost, true},
+		{CardStatusLost, CardStatusClosed, true},
+		{CardStatusActive, CardStatusActive, false},
+		{CardStatusFrozen, CardStatusFrozen, false},
+		{CardStatusLost, CardStatusActive, false},
+		{CardStatusLost, CardStatusFrozen, false},
+		{CardStatusClosed, CardStatusActive, false},
+		{"inactive", CardStatusActive, false},
+	}
+
+	for _, tc := range cases {
+		if got := canTransitCardStatus(tc.from, tc.to); got != tc.allowed {
+			t.Fatalf("%s -> %s: got %v, want %v", tc.from, tc.to, got, tc.allowed)
+		}
+	}
+}
+
+func TestController_FreezeCard_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().GetUserCardStatus(gomock.Any(), "test-user-id", "card-1").Return("Active", nil).Times(1)
+	mockRepo.EXPECT().UpdateCardStatus(gomock.Any(), "card-1", "Active", CardStatusFrozen).Return(nil).Times(1)
+
+	out, err := c.FreezeCard(context.Background(), "card-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != (CardStatusOutput{CardId: "card-1", Status: CardStatusFrozen}) {
+		t.Fatalf("unexpected output: %+v", out)
+	}
+}
+
+func TestController_UnfreezeCard_LostCardRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().GetUserCardStatus(gomock.Any(), "test-user-id", "card-1").Return(CardStatusLost, nil).Times(1)
+
+	_, err := c.UnfreezeCard(context.Background(), "card-1")
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.InvalidCardTransition {
+		t.Fatalf("expected InvalidCardTransition error, got %v", err)
+	}
+}
+
+func TestController_ReportLostCard_NotOwned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().GetUserCardStatus(gomock.Any(), "test-user-id", "card-of-other-user").Return("", model_mysql.ErrCardNotFound).Times(1)
+
+	_, err := c.ReportLostCard(context.Background(), "card-of-other-user")
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.CardNotFound {
+		t.Fatalf("expected CardNotFound error, got %v", err)
+	}
+}
+
+func TestController_ReportLostCard_ConcurrentChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().GetUserCardStatus(gomock.Any(), "test-user-id", "card-1").Return(CardStatusFrozen, nil).Times(1)
+	mockRepo.EXPECT().UpdateCardStatus(gomock.Any(), "card-1", CardStatusFrozen, CardStatusLost).Return(model_mysql.ErrCardStatusChanged).Times(1)
+
+	_, err := c.ReportLostCard(context.Background(), "card-1")
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.CardStatusChanged {
+		t.Fatalf("expected CardStatusChanged error, got %v", err)
+	}
+}
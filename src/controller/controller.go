@@ -1,15 +1,19 @@
 package controller
 
 import (
+	"assignment/bannerevent"
 	"assignment/global"
 	"assignment/logger"
 	"assignment/model"
+	"assignment/notifier"
 )
 
 type Controller struct {
 	RequestId       string
 	UserId          string
 	Logger          logger.LoggerIface
+	Notifier        notifier.NotifierIface
+	BannerEvents    bannerevent.RecorderIface
 	ModelRepository model.ModelRepository
 }
 
@@ -17,6 +21,8 @@ func New(requestId, userId *string, modelRepository model.ModelRepository) Contr
 	controllerObj := Controller{
 		RequestId:       *requestId,
 		UserId:          *userId,
+		Notifier:        notifier.Notifier,
+		BannerEvents:    bannerevent.Recorder,
 		ModelRepository: modelRepository,
 	}
 
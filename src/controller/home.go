@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"assignment/global"
+	"assignment/locale"
+	"context"
+	"errors"
+	"sync"
+)
+
+// HomeSection is one part of the home screen, it carries its own code and message so a failing section does not
+// fail the others. Data is nil when the section failed.
+type HomeSection struct {
+	Code    int64       `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data"`
+}
+
+type GetHomeOutput struct {
+	Accounts      HomeSection `json:"accounts"`
+	DebitCards    HomeSection `json:"debit_cards"`
+	SavedAccounts HomeSection `json:"saved_accounts"`
+	Banners       HomeSection `json:"banners"`
+}
+
+// GetHome loads everything the home screen shows at once. The sections run concurrently, each within
+// global.HomeSectionTimeout, and are always returned whether they succeeded or not.
+func (controller Controller) GetHome(ctx context.Context) GetHomeOutput {
+	controller.Logger.Info("start get home")
+	output := GetHomeOutput{}
+
+	var wg sync.WaitGroup
+	load := func(section *HomeSection, name string, get func(ctx context.Context) (interface{}, error)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			*section = controller.loadHomeSection(ctx, name, get)
+		}()
+	}
+	load(&output.Accounts, "accounts", func(ctx context.Context) (interface{}, error) {
+		return controller.GetUserAccounts(ctx)
+	})
+	load(&output.DebitCards, "debit_cards", func(ctx context.Context) (interface{}, error) {
+		return controller.GetUserDebitCards(ctx)
+	})
+	load(&output.SavedAccounts, "saved_accounts", func(ctx context.Context) (interface{}, error) {
+		return controller.GetUserSavedAccounts(ctx, GetSavedAccountsInput{})
+	})
+	load(&output.Banners, "banners", func(ctx context.Context) (interface{}, error) {
+		return controller.GetUserBanners(ctx)
+	})
+	wg.Wait()
+
+	controller.Logger.Info("get home completed")
+	return output
+}
+
+type homeSectionResult struct {
+	data interface{}
+	err  error
+}
+
+// loadHomeSection runs get with its own deadline. A section that misses the deadline, or whose request is cancelled,
+// is reported right away, its context is cancelled so the query behind it stops as well. Errors without a catalogue
+// message, e.g. of the database, are only logged and reported as HomeSectionFailed.
+func (controller Controller) loadHomeSection(ctx context.Context, name string, get func(ctx context.Context) (interface{}, error)) HomeSection {
+	ctx, cancel := context.WithTimeout(ctx, global.HomeSectionTimeout)
+	defer cancel()
+
+	done := make(chan homeSectionResult, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				controller.Logger.Errorf("home section %s panicked: %v", name, r)
+				done <- homeSectionResult{err: global.NewSystemError(global.HomeSectionFailed, name)}
+			}
+		}()
+		data, err := get(ctx)
+		done <- homeSectionResult{data: data, err: err}
+	}()
+
+	var result homeSectionResult
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		result.err = ctx.Err()
+	}
+	// a query cut off by the deadline or the cancelled request fails with its own error, the client is told which
+	// of the two it was
+	if result.err != nil && ctx.Err() != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			controller.Logger.Warnf("home section %s did not load within %s", name, global.HomeSectionTimeout)
+			result.err = global.NewSystemError(global.HomeSectionTimedOut, name)
+		} else {
+			controller.Logger.Warnf("home section %s was cancelled", name)
+			result.err = global.NewSystemError(global.HomeSectionFailed, name)
+		}
+	}
+
+	if result.err != nil {
+		var systemError global.SystemError
+		if !errors.As(result.err, &systemError) || systemError.Raw {
+			controller.Logger.Errorf("home section %s failed because: %s", name, result.err)
+			systemError = global.NewSystemError(global.HomeSectionFailed, name)
+		}
+		return HomeSection{
+			Code:    systemError.Code,
+			Message: global.LocalizeError(systemError, locale.FromContext(ctx)),
+		}
+	}
+	return HomeSection{Message: global.RESULT_SUCCESS, Data: result.data}
+}
@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"assignment/entity"
+	"assignment/global"
+	mock_model "assignment/mocks/model"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestController_GetHome_AllSections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().GetUserAccounts(gomock.Any(), "test-user-id").Return([]model_mysql.AccountWithDetails{{}}, nil).Times(1)
+	mockRepo.EXPECT().GetUserCards(gomock.Any(), "test-user-id").Return([]model_mysql.CardsWithDetails{{}}, nil).Times(1)
+	mockRepo.EXPECT().GetUserSavedAccounts(gomock.Any(), "test-user-id", "").Return([]model_mysql.SavedAccounts{{}}, nil).Times(1)
+	mockRepo.EXPECT().GetUserBanners(gomock.Any(), "test-user-id").Return([]entity.Banners{{}}, nil).Times(1)
+
+	out := c.GetHome(context.Background())
+	for name, section := range map[string]HomeSection{
+		"accounts": out.Accounts, "debit_cards": out.DebitCards, "saved_accounts": out.SavedAccounts, "banners": out.Banners,
+	} {
+		if section.Code != 0 || section.Message != global.RESULT_SUCCESS || section.Data == nil {
+			t.Fatalf("unexpected %s section: %+v", name, section)
+		}
+	}
+	if accounts, ok := out.Accounts.Data.(GetAccountsOutput); !ok || len(accounts.Accounts) != 1 {
+		t.Fatalf("unexpected accounts data: %+v", out.Accounts.Data)
+	}
+}
+
+func TestController_GetHome_PartialFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().GetUserAccounts(gomock.Any(), "test-user-id").Return([]model_mysql.AccountWithDetails{{}}, nil).Times(1)
+	mockRepo.EXPECT().GetUserCards(gomock.Any(), "test-user-id").Return(nil, errors.New("boom")).Times(1)
+	mockRepo.EXPECT().GetUserSavedAccounts(gomock.Any(), "test-user-id", "").Return(nil, nil).Times(1)
+	mockRepo.EXPECT().GetUserBanners(gomock.Any(), "test-user-id").Return(nil, nil).Times(1)
+
+	out := c.GetHome(context.Background())
+	if out.DebitCards.Code != global.HomeSectionFailed || out.DebitCards.Message != "debit_cards could not be loaded" || out.DebitCards.Data != nil {
+		t.Fatalf("unexpected debit cards section: %+v", out.DebitCards)
+	}
+	if out.Accounts.Code != 0 || out.Accounts.Data == nil {
+		t.Fatalf("expected accounts to load, got %+v", out.Accounts)
+	}
+}
+
+func TestController_GetHome_SectionTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	timeout := global.HomeSectionTimeout
+	defer func() { global.HomeSectionTimeout = timeout }()
+	global.HomeSectionTimeout = 50 * time.Millisecond
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().GetUserAccounts(gomock.Any(), "test-user-id").Return([]model_mysql.AccountWithDetails{{}}, nil).Times(1)
+	mockRepo.EXPECT().GetUserCards(gomock.Any(), "test-user-id").Return(nil, nil).Times(1)
+	mockRepo.EXPECT().GetUserSavedAccounts(gomock.Any(), "test-user-id", "").Return(nil, nil).Times(1)
+	mockRepo.EXPECT().GetUserBanners(gomock.Any(), "test-user-id").
+		DoAndReturn(func(ctx context.Context, _ string) ([]entity.Banners, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}).
+		Times(1)
+
+	start := time.Now()
+	out := c.GetHome(context.Background())
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the slow section to be cut off, took %s", elapsed)
+	}
+	if out.Banners.Code != global.HomeSectionTimedOut || out.Banners.Message != "banners did not load in time" {
+		t.Fatalf("unexpected banners section: %+v", out.Banners)
+	}
+	if out.Accounts.Code != 0 || out.Accounts.Data == nil {
+		t.Fatalf("expected accounts to load, got %+v", out.Accounts)
+	}
+}
+
+func TestController_GetHome_RequestCancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	mockRepo.EXPECT().GetUserAccounts(gomock.Any(), "test-user-id").Return(nil, context.Canceled).AnyTimes()
+	mockRepo.EXPECT().GetUserCards(gomock.Any(), "test-user-id").Return(nil, context.Canceled).AnyTimes()
+	mockRepo.EXPECT().GetUserSavedAccounts(gomock.Any(), "test-user-id", "").Return(nil, context.Canceled).AnyTimes()
+	mockRepo.EXPECT().GetUserBanners(gomock.Any(), "test-user-id").Return(nil, context.Canceled).AnyTimes()
+
+	out := c.GetHome(ctx)
+	for name, section := range map[string]HomeSection{
+		"accounts": out.Accounts, "debit_cards": out.DebitCards, "saved_accounts": out.SavedAccounts, "banners": out.Banners,
+	} {
+		if section.Code != global.HomeSectionFailed || section.Message != name+" could not be loaded" || section.Data != nil {
+			t.Fatalf("unexpected %s section: %+v", name, section)
+		}
+	}
+}
+
+func TestController_GetHome_PanicIsContained(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().GetUserAccounts(gomock.Any(), "test-user-id").
+		DoAndReturn(func(context.Context, string) ([]model_mysql.AccountWithDetails, error) {
+			panic("boom")
+		}).
+		Times(1)
+	mockRepo.EXPECT().GetUserCards(gomock.Any(), "test-user-id").Return(nil, nil).Times(1)
+	mockRepo.EXPECT().GetUserSavedAccounts(gomock.Any(), "test-user-id", "").Return(nil, nil).Times(1)
+	mockRepo.EXPECT().GetUserBanners(gomock.Any(), "test-user-id").Return(nil, nil).Times(1)
+
+	out := c.GetHome(context.Background())
+	if out.Accounts.Code != global.HomeSectionFailed {
+		t.Fatalf("unexpected accounts section: %+v", out.Accounts)
+	}
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"assignment/entity"
+	"assignment/global"
+	"assignment/locale"
+	"context"
+	"slices"
+)
+
+// translationLanguages lists the languages to look up translations in, best first. Base texts are stored in the
+// default language so the chain stops there, nil means the base texts are already in the right language.
+func translationLanguages(ctx context.Context) []string {
+	language := locale.FromContext(ctx)
+	if language == "" {
+		return nil
+	}
+	chain := global.LanguageChain(language)
+	return chain[:slices.Index(chain, global.DefaultLanguage)]
+}
+
+// localizeBanners replaces the title and description of the banners with their best translation. Translations are
+// cosmetic, a failed lookup keeps the base texts.
+func (controller Controller) localizeBanners(ctx context.Context, banners []entity.Banners) {
+	languages := translationLanguages(ctx)
+	if len(languages) == 0 || len(banners) == 0 {
+		return
+	}
+
+	bannerIds := make([]string, 0, len(banners))
+	for _, banner := range banners {
+		bannerIds = append(bannerIds, banner.BannerId)
+	}
+	translations, err := controller.ModelRepository.GetBannerTranslations(ctx, bannerIds, languages)
+	if err != nil {
+		controller.Logger.Warnf("get banner translations failed because: %s", err.Error())
+		return
+	}
+
+	best := map[string]entity.BannerTranslations{}
+	for _, translation := range translations {
+		current, ok := best[translation.BannerId]
+		if !ok || slices.Index(languages, translation.Language) < slices.Index(languages, current.Language) {
+			best[translation.BannerId] = translation
+		}
+	}
+	for i := range banners {
+		if translation, ok := best[banners[i].BannerId]; ok {
+			banners[i].Title = translation.Title
+			banners[i].Description = translation.Description
+		}
+	}
+}
+
+// localizeGreeting returns the best translation of the greeting of the user, or the greeting itself
+func (controller Controller) localizeGreeting(ctx context.Context, userId, greeting string) string {
+	languages := translationLanguages(ctx)
+	if len(languages) == 0 {
+		return greeting
+	}
+
+	translations, err := controller.ModelRepository.GetGreetingTranslations(ctx, userId, languages)
+	if err != nil {
+		controller.Logger.Warnf("get greeting translations failed because: %s", err.Error())
+		return greeting
+	}
+	for _, language := range languages {
+		for _, translation := range translations {
+			if translation.Language == language {
+				return translation.Greeting
+			}
+		}
+	}
+	return greeting
+}
@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"assignment/accountnumber"
+	"assignment/entity"
+	"assignment/global"
+	"assignment/locale"
+	mock_model "assignment/mocks/model"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestController_GetUserBanners_Translated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	banners := []entity.Banners{
+		{BannerId: "banner-1", Title: "Cashback", Description: "Get 5% back"},
+		{BannerId: "banner-2", Title: "Travel", Description: "Fly for less"},
+	}
+	mockRepo.EXPECT().GetUserBanners(gomock.Any(), "test-user-id").Return(banners, nil).Times(1)
+	mockRepo.EXPECT().GetBannerTranslations(gomock.Any(), []string{"banner-1", "banner-2"}, []string{"th"}).
+		Return([]entity.BannerTranslations{{BannerId: "banner-1", Language: "th", Title: "เงินคืน", Description: "รับเงินคืน 5%"}}, nil).
+		Times(1)
+
+	out, err := c.GetUserBanners(locale.WithLanguage(context.Background(), "th"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Banners[0].Title != "เงินคืน" || out.Banners[0].Description != "รับเงินคืน 5%" {
+		t.Fatalf("expected translated banner, got %+v", out.Banners[0])
+	}
+	if out.Banners[1].Title != "Travel" {
+		t.Fatalf("expected untranslated banner to fall back, got %+v", out.Banners[1])
+	}
+}
+
+func TestController_GetUserBanners_DefaultLanguageSkipsTranslations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().GetUserBanners(gomock.Any(), "test-user-id").Return([]entity.Banners{{BannerId: "banner-1"}}, nil).Times(1)
+	mockRepo.EXPECT().GetBannerTranslations(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	if _, err := c.GetUserBanners(locale.WithLanguage(context.Background(), global.DefaultLanguage)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestController_GetMe_GreetingFallsBackOnError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().GetUserProfile(gomock.Any(), "test-user-id").
+		Return(model_mysql.UserProfile{Greeting: "Hello Alice"}, nil).Times(1)
+	mockRepo.EXPECT().GetGreetingTranslations(gomock.Any(), "test-user-id", []string{"th"}).
+		Return(nil, errors.New("boom")).Times(1)
+
+	out, err := c.GetMe(locale.WithLanguage(context.Background(), "th"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Greeting != "Hello Alice" {
+		t.Fatalf("expected base greeting, got %q", out.Greeting)
+	}
+}
+
+func TestTranslationLanguages_FollowsFallbacks(t *testing.T) {
+	fallbacks := global.LanguageFallbacks
+	defer func() { global.LanguageFallbacks = fallbacks }()
+	global.LanguageFallbacks = map[string][]string{"lo": {"th", "en"}}
+
+	languages := translationLanguages(locale.WithLanguage(context.Background(), "lo"))
+	if len(languages) != 2 || languages[0] != "lo" || languages[1] != "th" {
+		t.Fatalf("unexpected languages: %v", languages)
+	}
+	if languages := translationLanguages(context.Background()); languages != nil {
+		t.Fatalf("expected no languages without a negotiated language, got %v", languages)
+	}
+}
+
+func TestController_LocalizeGreeting_PrefersChainOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fallbacks := global.LanguageFallbacks
+	defer func() { global.LanguageFallbacks = fallbacks }()
+	global.LanguageFallbacks = map[string][]string{"lo": {"th"}}
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().GetGreetingTranslations(gomock.Any(), "user-1", []string{"lo", "th"}).
+		Return([]entity.UserGreetingTranslations{
+			{UserId: "user-1", Language: "th", Greeting: "สวัสดี"},
+			{UserId: "user-1", Language: "lo", Greeting: "ສະບາຍດີ"},
+		}, nil).
+		Times(1)
+
+	if got := c.localizeGreeting(locale.WithLanguage(context.Background(), "lo"), "user-1", "Hello"); got != "ສະບາຍດີ" {
+		t.Fatalf("expected the greeting of the first language in the chain, got %q", got)
+	}
+}
+
+func TestLocalizeError(t *testing.T) {
+	err := global.NewSystemError(global.PinLocked, "10:00")
+	if got := global.LocalizeError(err, "th"); got != "รหัส PIN ถูกล็อกจนถึง 10:00" {
+		t.Fatalf("unexpected thai message: %q", got)
+	}
+	if got := global.LocalizeError(err, "en"); got != err.Error() {
+		t.Fatalf("unexpected english message: %q", got)
+	}
+
+	if got := global.LocalizeError(global.NewSystemError(global.AccountNotFound), "th"); got != "ไม่พบบัญชี" {
+		t.Fatalf("unexpected thai message without args: %q", got)
+	}
+
+	raw := global.NewRawSystemError(global.DatabaseError, "boom")
+	if got := global.LocalizeError(raw, "th"); got != "boom" {
+		t.Fatalf("expected raw message to be kept, got %q", got)
+	}
+}
+
+func TestLocalizeError_TranslatesReason(t *testing.T) {
+	err := checkAccountFlag("badge", "unknown", nil, time.Now())
+	if got := global.LocalizeError(err, "th"); got != "flag_value ของแฟล็ก badge ต้องตรงกับ oneof=new popular recommended" {
+		t.Fatalf("unexpected thai reason: %q", got)
+	}
+	if got := global.LocalizeError(err, "en"); got != err.Error() || got != "flag_value of a badge flag must match oneof=new popular recommended" {
+		t.Fatalf("unexpected english reason: %q", got)
+	}
+
+	err = invalidAccountNumber(accountnumber.ErrInvalidCheckDigit)
+	if got := global.LocalizeError(err, "th"); got != "เลขตรวจสอบของเลขบัญชีไม่ถูกต้อง" {
+		t.Fatalf("unexpected thai account number reason: %q", got)
+	}
+}
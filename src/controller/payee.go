@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"assignment/accountnumber"
+	"assignment/global"
+	model_mysql "assignment/model/mysql"
+	"assignment/util"
+	"context"
+	"errors"
+	"time"
+)
+
+type LookupPayeeInput struct {
+	AccountNumber string `query:"account_number" validate:"required,max=30"`
+}
+
+type PayeeOutput struct {
+	AccountNumber string `json:"account_number"`
+	Issuer        string `json:"issuer"`
+	OwnerName     string `json:"owner_name,omitempty"`
+	Internal      bool   `json:"internal"`
+}
+
+// LookupPayee checks an account number before it is saved, accounts held in this service resolve to the masked
+// name of their owner. Lookups are limited per user and hour.
+func (controller Controller) LookupPayee(ctx context.Context, input LookupPayeeInput) (PayeeOutput, error) {
+	controller.Logger.Info("start lookup payee")
+
+	// Malformed numbers are rejected before they count against the limit
+	if _, err := accountnumber.Digits(input.AccountNumber); err != nil {
+		return PayeeOutput{}, invalidAccountNumber(err)
+	}
+
+	lookups, err := controller.ModelRepository.CountPayeeLookups(ctx, controller.UserId, time.Now().Add(-time.Hour))
+	if err != nil {
+		controller.Logger.Errorf("count payee lookups failed because: %s", err.Error())
+		return PayeeOutput{}, global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+	if lookups >= int64(global.PayeeLookupMaxPerHour) {
+		controller.Logger.Errorf("user %s reached the payee lookup limit", controller.UserId)
+		return PayeeOutput{}, global.NewSystemError(global.PayeeLookupRateLimited)
+	}
+	if err := controller.ModelRepository.RecordPayeeLookup(ctx, controller.UserId); err != nil {
+		controller.Logger.Errorf("record payee lookup failed because: %s", err.Error())
+		return PayeeOutput{}, global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	payee, err := controller.resolvePayee(ctx, input.AccountNumber)
+	if err != nil {
+		return payee, err
+	}
+
+	controller.Logger.Info("lookup payee completed")
+	return payee, nil
+}
+
+// resolvePayee looks the number up in this service first, other numbers have to match the format of a known issuer.
+// The returned account number is the one to store: as held for internal accounts, digits only otherwise.
+func (controller Controller) resolvePayee(ctx context.Context, accountNumber string) (PayeeOutput, error) {
+	digits, err := accountnumber.Digits(accountNumber)
+	if err != nil {
+		return PayeeOutput{}, invalidAccountNumber(err)
+	}
+
+	owner, err := controller.ModelRepository.LookupAccountOwner(ctx, digits)
+	if err == nil {
+		if owner.UserId == controller.UserId {
+			return PayeeOutput{}, global.NewSystemError(global.OwnAccountAsPayee)
+		}
+		return PayeeOutput{
+			AccountNumber: owner.AccountNumber,
+			Issuer:        owner.Issuer,
+			OwnerName:     util.MaskName(owner.Name),
+			Internal:      true,
+		}, nil
+	}
+	if !errors.Is(err, model_mysql.ErrAccountNotFound) {
+		controller.Logger.Errorf("lookup account owner failed because: %s", err.Error())
+		return PayeeOutput{}, global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	format, err := accountnumber.Identify(accountnumber.Formats, digits)
+	if err != nil {
+		return PayeeOutput{}, invalidAccountNumber(err)
+	}
+	return PayeeOutput{AccountNumber: digits, Issuer: format.Issuer}, nil
+}
+
+func invalidAccountNumber(err error) error {
+	reason := global.NewReason(global.ReasonMalformedAccountNumber)
+	switch {
+	case errors.Is(err, accountnumber.ErrUnknownFormat):
+		reason = global.NewReason(global.ReasonUnknownAccountFormat)
+	case errors.Is(err, accountnumber.ErrInvalidCheckDigit):
+		reason = global.NewReason(global.ReasonInvalidCheckDigit)
+	}
+	return global.NewSystemError(global.InvalidAccountNumber, reason)
+}
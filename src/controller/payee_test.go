@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"assignment/global"
+	mock_model "assignment/mocks/model"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func expectPayeeLookupAllowed(mockRepo *mock_model.MockModelRepository) {
+	mockRepo.EXPECT().CountPayeeLookups(gomock.Any(), "test-user-id", gomock.Any()).Return(int64(0), nil).Times(1)
+	mockRepo.EXPECT().RecordPayeeLookup(gomock.Any(), "test-user-id").Return(nil).Times(1)
+}
+
+func TestController_LookupPayee_InternalAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	owner := model_mysql.AccountOwner{AccountNumber: "568-2-71318", Issuer: "TestLab", UserId: "other-user", Name: "Jane Doe"}
+	expectPayeeLookupAllowed(mockRepo)
+	mockRepo.EXPECT().LookupAccountOwner(gomock.Any(), "568271318").Return(owner, nil).Times(1)
+
+	out, err := c.LookupPayee(context.Background(), LookupPayeeInput{AccountNumber: "568 2 71318"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := PayeeOutput{AccountNumber: "568-2-71318", Issuer: "TestLab", OwnerName: "J*** D**", Internal: true}
+	if out != want {
+		t.Fatalf("unexpected output: got %+v, want %+v", out, want)
+	}
+}
+
+func TestController_LookupPayee_ExternalAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	expectPayeeLookupAllowed(mockRepo)
+	mockRepo.EXPECT().LookupAccountOwner(gomock.Any(), "201234567898").Return(model_mysql.AccountOwner{}, model_mysql.ErrAccountNotFound).Times(1)
+
+	out, err := c.LookupPayee(context.Background(), LookupPayeeInput{AccountNumber: "20-1234-56789-8"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != (PayeeOutput{AccountNumber: "201234567898", Issuer: "Harbor Bank"}) {
+		t.Fatalf("unexpected output: %+v", out)
+	}
+}
+
+func TestController_LookupPayee_OwnAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	owner := model_mysql.AccountOwner{AccountNumber: "568-2-90992", UserId: "test-user-id"}
+	expectPayeeLookupAllowed(mockRepo)
+	mockRepo.EXPECT().LookupAccountOwner(gomock.Any(), "568290992").Return(owner, nil).Times(1)
+
+	_, err := c.LookupPayee(context.Background(), LookupPayeeInput{AccountNumber: "568-2-90992"})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.OwnAccountAsPayee {
+		t.Fatalf("expected OwnAccountAsPayee, got %v", err)
+	}
+}
+
+func TestController_LookupPayee_InvalidNumber(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	// Malformed numbers never reach the database
+	_, err := c.LookupPayee(context.Background(), LookupPayeeInput{AccountNumber: "12ab"})
+	if sysErr, ok := err.(global.SystemError); !ok || sysErr.Code != global.InvalidAccountNumber {
+		t.Fatalf("expected InvalidAccountNumber, got %v", err)
+	}
+
+	expectPayeeLookupAllowed(mockRepo)
+	mockRepo.EXPECT().LookupAccountOwner(gomock.Any(), "1234567890").Return(model_mysql.AccountOwner{}, model_mysql.ErrAccountNotFound).Times(1)
+
+	_, err = c.LookupPayee(context.Background(), LookupPayeeInput{AccountNumber: "1234567890"})
+	if sysErr, ok := err.(global.SystemError); !ok || sysErr.Code != global.InvalidAccountNumber {
+		t.Fatalf("expected InvalidAccountNumber, got %v", err)
+	}
+}
+
+func TestController_LookupPayee_RateLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().CountPayeeLookups(gomock.Any(), "test-user-id", gomock.Any()).
+		Return(int64(global.PayeeLookupMaxPerHour), nil).Times(1)
+	mockRepo.EXPECT().RecordPayeeLookup(gomock.Any(), gomock.Any()).Times(0)
+	mockRepo.EXPECT().LookupAccountOwner(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := c.LookupPayee(context.Background(), LookupPayeeInput{AccountNumber: "568-2-71318"})
+	if sysErr, ok := err.(global.SystemError); !ok || sysErr.Code != global.PayeeLookupRateLimited {
+		t.Fatalf("expected PayeeLookupRateLimited, got %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package controller
+
+import (
+	"assignment/entity"
+	"assignment/global"
+	model_mysql "assignment/model/mysql"
+	"assignment/util"
+	"context"
+	"errors"
+	"time"
+)
+
+type ChangePinInput struct {
+	OldPin string `json:"old_pin" validate:"required"`
+	NewPin string `json:"new_pin" validate:"required"`
+}
+
+// ChangePin replaces the pin of the logged-in user. Every session including the current one is revoked
+// so the user has to log in again with the new pin.
+func (controller Controller) ChangePin(ctx context.Context, input ChangePinInput) error {
+	controller.Logger.Info("start changing pin")
+
+	if err := checkNewPin(input.NewPin); err != nil {
+		controller.Logger.Errorf("user %s requested a weak pin", controller.UserId)
+		return err
+	}
+	if input.NewPin == input.OldPin {
+		controller.Logger.Errorf("user %s requested the same pin", controller.UserId)
+		return global.NewSystemError(global.PinReused)
+	}
+
+	if _, err := controller.verifyPin(ctx, controller.UserId, input.OldPin); err != nil {
+		return err
+	}
+
+	if err := controller.storeNewPin(ctx, controller.UserId, input.NewPin); err != nil {
+		return err
+	}
+
+	controller.Logger.Info("change pin completed")
+	return nil
+}
+
+type ForgotPinInput struct {
+	UserId string `json:"user_id" validate:"required"`
+}
+
+// ForgotPin sends a one-time reset code through the notifier. Unknown users get the same answer as known
+// ones so the endpoint cannot be used to find out which user ids exist.
+func (controller Controller) ForgotPin(ctx context.Context, input ForgotPinInput) error {
+	controller.Logger.Info("start forgot pin")
+
+	if _, err := controller.ModelRepository.GetUserHashedPin(ctx, input.UserId); err != nil {
+		if errors.Is(err, model_mysql.ErrUserNotFound) {
+			controller.Logger.Errorf("forgot pin requested for unknown user %s", input.UserId)
+			return nil
+		}
+		controller.Logger.Errorf("get user hashed pin failed because: %s", err.Error())
+		return global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	now := time.Now()
+	existingCode, err := controller.ModelRepository.GetPinResetCode(ctx, input.UserId)
+	if err != nil && !errors.Is(err, model_mysql.ErrPinResetCodeNotFound) {
+		controller.Logger.Errorf("get pin reset code failed because: %s", err.Error())
+		return global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+	if err == nil && now.Before(existingCode.CreatedAt.Add(global.PinResetCodeResendTime)) {
+		controller.Logger.Infof("pin reset code of user %s was just sent, skip resending", input.UserId)
+		return nil
+	}
+
+	code, err := util.GenerateRandomStringFromSpecificCharacters("0123456789", 6)
+	if err != nil {
+		return global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+	codeHash, err := util.HashPassword(code)
+	if err != nil {
+		return global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	resetCode := entity.PinResetCodes{
+		UserId:    input.UserId,
+		CodeHash:  codeHash,
+		CreatedAt: now,
+		ExpiredAt: now.Add(global.PinResetCodeExpireTime),
+	}
+	if err := controller.ModelRepository.SavePinResetCode(ctx, resetCode); err != nil {
+		controller.Logger.Errorf("save pin reset code failed because: %s", err.Error())
+		return global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	if err := controller.Notifier.SendPinResetCode(ctx, input.UserId, code, resetCode.ExpiredAt); err != nil {
+		controller.Logger.Errorf("send pin reset code failed because: %s", err.Error())
+		return global.NewSystemError(global.NotificationError)
+	}
+
+	controller.Logger.Info("forgot pin completed")
+	return nil
+}
+
+type ResetPinInput struct {
+	UserId string `json:"user_id" validate:"required"`
+	Code   string `json:"code" validate:"required"`
+	NewPin string `json:"new_pin" validate:"required"`
+}
+
+// ResetPin sets a new pin using the code sent by ForgotPin
+func (controller Controller) ResetPin(ctx context.Context, input ResetPinInput) error {
+	controller.Logger.Info("start resetting pin")
+
+	if err := checkNewPin(input.NewPin); err != nil {
+		controller.Logger.Errorf("user %s requested a weak pin", input.UserId)
+		return err
+	}
+
+	resetCode, err := controller.ModelRepository.ReservePinResetCodeAttempt(ctx, input.UserId, global.PinResetCodeMaxAttempts)
+	if err != nil {
+		switch {
+		case errors.Is(err, model_mysql.ErrPinResetCodeNotFound):
+			controller.Logger.Errorf("user %s has no pin reset code", input.UserId)
+			return invalidResetCodeError()
+		case errors.Is(err, model_mysql.ErrPinResetCodeUsedUp):
+			controller.Logger.Errorf("pin reset code of user %s is expired or used up", input.UserId)
+			return invalidResetCodeError()
+		}
+		controller.Logger.Errorf("reserve pin reset code attempt failed because: %s", err.Error())
+		return global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	if same, err := util.ValidatePin(input.Code, resetCode.CodeHash); !same {
+		if err != nil {
+			controller.Logger.Errorf("cannot validate pin reset code for user %s", input.UserId)
+			return global.NewRawSystemError(global.DatabaseError, err.Error())
+		}
+		controller.Logger.Errorf("user %s just input an incorrect pin reset code", input.UserId)
+		return invalidResetCodeError()
+	}
+
+	hashedPin, err := util.HashPassword(input.NewPin)
+	if err != nil {
+		controller.Logger.Errorf("hash new pin failed because: %s", err.Error())
+		return global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	// The code is consumed together with the pin change, a code replaced or used by a parallel request is rejected
+	if err := controller.ModelRepository.ResetUserPin(ctx, input.UserId, resetCode.CodeHash, hashedPin); err != nil {
+		if errors.Is(err, model_mysql.ErrPinResetCodeNotFound) {
+			controller.Logger.Errorf("pin reset code of user %s was used or replaced meanwhile", input.UserId)
+			return invalidResetCodeError()
+		}
+		controller.Logger.Errorf("reset user pin failed because: %s", err.Error())
+		return global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	controller.Logger.Info("reset pin completed")
+	return nil
+}
+
+func (controller Controller) storeNewPin(ctx context.Context, userId, newPin string) error {
+	hashedPin, err := util.HashPassword(newPin)
+	if err != nil {
+		controller.Logger.Errorf("hash new pin failed because: %s", err.Error())
+		return global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	if err := controller.ModelRepository.ChangeUserPin(ctx, userId, hashedPin); err != nil {
+		controller.Logger.Errorf("change user pin failed because: %s", err.Error())
+		return global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+	return nil
+}
+
+func checkNewPin(pin string) error {
+	if !util.IsStrongPin(pin) {
+		return global.NewSystemError(global.WeakPin)
+	}
+	return nil
+}
+
+func invalidResetCodeError() global.SystemError {
+	return global.NewSystemError(global.InvalidResetCode)
+}
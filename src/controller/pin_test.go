@@ -0,0 +1,310 @@
+package controller
+
+import (
+	"assignment/entity"
+	"assignment/global"
+	mock_model "assignment/mocks/model"
+	fake_notifier "assignment/mocks/notifier"
+	model_mysql "assignment/model/mysql"
+	"assignment/util"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestController_ChangePin_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	oldPin, _ := util.HashPassword("123456")
+
+	mockRepo.EXPECT().ReservePinAttempt(gomock.Any(), "test-user-id", gomock.Any()).Return(entity.UserPin{Pin: oldPin}, nil).Times(1)
+	mockRepo.EXPECT().ResetPinLockout(gomock.Any(), "test-user-id").Return(nil).Times(1)
+	mockRepo.EXPECT().ChangeUserPin(gomock.Any(), "test-user-id", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, hashedPin string) error {
+			if same, _ := util.ValidatePin("739146", hashedPin); !same {
+				t.Fatalf("stored pin is not the hash of the new pin")
+			}
+			return nil
+		}).
+		Times(1)
+
+	if err := c.ChangePin(context.Background(), ChangePinInput{OldPin: "123456", NewPin: "739146"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestController_ChangePin_WeakPin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	for _, pin := range []string{"12345", "1234567", "12a456", "111234", "123456", "987654", "900012"} {
+		err := c.ChangePin(context.Background(), ChangePinInput{OldPin: "739146", NewPin: pin})
+		sysErr, ok := err.(global.SystemError)
+		if !ok {
+			t.Fatalf("pin %s: expected global.SystemError, got %T: %v", pin, err, err)
+		}
+		if sysErr.Code != global.WeakPin {
+			t.Fatalf("pin %s: unexpected error code: got %v, want %v", pin, sysErr.Code, global.WeakPin)
+		}
+	}
+}
+
+func TestController_ChangePin_SamePin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	err := c.ChangePin(context.Background(), ChangePinInput{OldPin: "739146", NewPin: "739146"})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.PinReused {
+		t.Fatalf("expected PinReused error, got %v", err)
+	}
+}
+
+func TestController_ChangePin_IncorrectOldPin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	oldPin, _ := util.HashPassword("123456")
+
+	mockRepo.EXPECT().ReservePinAttempt(gomock.Any(), "test-user-id", gomock.Any()).Return(entity.UserPin{Pin: oldPin}, nil).Times(1)
+
+	err := c.ChangePin(context.Background(), ChangePinInput{OldPin: "000000", NewPin: "739146"})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.IncorrectPin {
+		t.Fatalf("expected IncorrectPin error, got %v", err)
+	}
+}
+
+func TestController_ForgotPin_SendsCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	notifier := fake_notifier.NewNotifier()
+	c := newTestController(mockRepo)
+	c.Notifier = notifier
+
+	var saved entity.PinResetCodes
+	mockRepo.EXPECT().GetUserHashedPin(gomock.Any(), "user-1").Return(entity.UserPin{UserId: "user-1"}, nil).Times(1)
+	mockRepo.EXPECT().GetPinResetCode(gomock.Any(), "user-1").Return(entity.PinResetCodes{}, model_mysql.ErrPinResetCodeNotFound).Times(1)
+	mockRepo.EXPECT().SavePinResetCode(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, resetCode entity.PinResetCodes) error {
+			saved = resetCode
+			return nil
+		}).
+		Times(1)
+
+	if err := c.ForgotPin(context.Background(), ForgotPinInput{UserId: "user-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(notifier.PinResetCodes) != 1 {
+		t.Fatalf("expected 1 code to be sent, got %d", len(notifier.PinResetCodes))
+	}
+	sent := notifier.PinResetCodes[0]
+	if same, _ := util.ValidatePin(sent.Code, saved.CodeHash); !same {
+		t.Fatalf("sent code does not match stored hash")
+	}
+	if !sent.ExpiredAt.Equal(saved.ExpiredAt) {
+		t.Fatalf("unexpected expiry: sent %s, saved %s", sent.ExpiredAt, saved.ExpiredAt)
+	}
+}
+
+func TestController_ForgotPin_UnknownUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	notifier := fake_notifier.NewNotifier()
+	c := newTestController(mockRepo)
+	c.Notifier = notifier
+
+	mockRepo.EXPECT().GetUserHashedPin(gomock.Any(), "missing").Return(entity.UserPin{}, model_mysql.ErrUserNotFound).Times(1)
+
+	if err := c.ForgotPin(context.Background(), ForgotPinInput{UserId: "missing"}); err != nil {
+		t.Fatalf("expected nil error for unknown user, got %v", err)
+	}
+	if len(notifier.PinResetCodes) != 0 {
+		t.Fatalf("expected no code to be sent")
+	}
+}
+
+func TestController_ForgotPin_ResendTooSoon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	notifier := fake_notifier.NewNotifier()
+	c := newTestController(mockRepo)
+	c.Notifier = notifier
+
+	mockRepo.EXPECT().GetUserHashedPin(gomock.Any(), "user-1").Return(entity.UserPin{UserId: "user-1"}, nil).Times(1)
+	mockRepo.EXPECT().GetPinResetCode(gomock.Any(), "user-1").Return(entity.PinResetCodes{CreatedAt: time.Now()}, nil).Times(1)
+
+	if err := c.ForgotPin(context.Background(), ForgotPinInput{UserId: "user-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifier.PinResetCodes) != 0 {
+		t.Fatalf("expected no code to be resent")
+	}
+}
+
+func TestController_ForgotPin_NotifierError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	notifier := fake_notifier.NewNotifier()
+	notifier.Err = errors.New("sms gateway down")
+	c := newTestController(mockRepo)
+	c.Notifier = notifier
+
+	mockRepo.EXPECT().GetUserHashedPin(gomock.Any(), "user-1").Return(entity.UserPin{UserId: "user-1"}, nil).Times(1)
+	mockRepo.EXPECT().GetPinResetCode(gomock.Any(), "user-1").Return(entity.PinResetCodes{}, model_mysql.ErrPinResetCodeNotFound).Times(1)
+	mockRepo.EXPECT().SavePinResetCode(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	err := c.ForgotPin(context.Background(), ForgotPinInput{UserId: "user-1"})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.NotificationError {
+		t.Fatalf("expected NotificationError, got %v", err)
+	}
+}
+
+func TestController_ResetPin_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	codeHash, _ := util.HashPassword("482913")
+	resetCode := entity.PinResetCodes{UserId: "user-1", CodeHash: codeHash, FailedAttempts: 1, ExpiredAt: time.Now().Add(time.Minute)}
+
+	mockRepo.EXPECT().ReservePinResetCodeAttempt(gomock.Any(), "user-1", global.PinResetCodeMaxAttempts).Return(resetCode, nil).Times(1)
+	mockRepo.EXPECT().ResetUserPin(gomock.Any(), "user-1", codeHash, gomock.Any()).Return(nil).Times(1)
+
+	err := c.ResetPin(context.Background(), ResetPinInput{UserId: "user-1", Code: "482913", NewPin: "739146"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestController_ResetPin_WrongCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	codeHash, _ := util.HashPassword("482913")
+	resetCode := entity.PinResetCodes{UserId: "user-1", CodeHash: codeHash, FailedAttempts: 1, ExpiredAt: time.Now().Add(time.Minute)}
+
+	mockRepo.EXPECT().ReservePinResetCodeAttempt(gomock.Any(), "user-1", global.PinResetCodeMaxAttempts).Return(resetCode, nil).Times(1)
+
+	err := c.ResetPin(context.Background(), ResetPinInput{UserId: "user-1", Code: "000000", NewPin: "739146"})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.InvalidResetCode {
+		t.Fatalf("expected InvalidResetCode, got %v", err)
+	}
+}
+
+func TestController_ResetPin_ExpiredOrUsedUpCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	for _, reserveErr := range []error{model_mysql.ErrPinResetCodeUsedUp, model_mysql.ErrPinResetCodeNotFound} {
+		mockRepo.EXPECT().ReservePinResetCodeAttempt(gomock.Any(), "user-1", global.PinResetCodeMaxAttempts).Return(entity.PinResetCodes{}, reserveErr).Times(1)
+
+		err := c.ResetPin(context.Background(), ResetPinInput{UserId: "user-1", Code: "482913", NewPin: "739146"})
+		sysErr, ok := err.(global.SystemError)
+		if !ok || sysErr.Code != global.InvalidResetCode {
+			t.Fatalf("expected InvalidResetCode, got %v", err)
+		}
+	}
+}
+
+func TestController_ResetPin_CodeUsedMeanwhile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	codeHash, _ := util.HashPassword("482913")
+	resetCode := entity.PinResetCodes{UserId: "user-1", CodeHash: codeHash, FailedAttempts: 1, ExpiredAt: time.Now().Add(time.Minute)}
+
+	mockRepo.EXPECT().ReservePinResetCodeAttempt(gomock.Any(), "user-1", global.PinResetCodeMaxAttempts).Return(resetCode, nil).Times(1)
+	mockRepo.EXPECT().ResetUserPin(gomock.Any(), "user-1", codeHash, gomock.Any()).Return(model_mysql.ErrPinResetCodeNotFound).Times(1)
+
+	err := c.ResetPin(context.Background(), ResetPinInput{UserId: "user-1", Code: "482913", NewPin: "739146"})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.InvalidResetCode {
+		t.Fatalf("expected InvalidResetCode, got %v", err)
+	}
+}
+
+func TestController_ResetPin_ConcurrentWrongCodesAreLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	codeHash, _ := util.HashPassword("482913")
+
+	// The reservation behaves like the locked pin_reset_codes row, one attempt at a time
+	var mu sync.Mutex
+	resetCode := entity.PinResetCodes{UserId: "user-1", CodeHash: codeHash, ExpiredAt: time.Now().Add(time.Minute)}
+	reserved := 0
+	mockRepo.EXPECT().ReservePinResetCodeAttempt(gomock.Any(), "user-1", global.PinResetCodeMaxAttempts).
+		DoAndReturn(func(_ context.Context, _ string, maxAttempts int) (entity.PinResetCodes, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if resetCode.FailedAttempts >= maxAttempts {
+				return entity.PinResetCodes{}, model_mysql.ErrPinResetCodeUsedUp
+			}
+			reserved++
+			resetCode.FailedAttempts++
+			return resetCode, nil
+		}).
+		AnyTimes()
+
+	const requests = 20
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := c.ResetPin(context.Background(), ResetPinInput{UserId: "user-1", Code: "000000", NewPin: "739146"})
+			if sysErr, ok := err.(global.SystemError); !ok || sysErr.Code != global.InvalidResetCode {
+				t.Errorf("expected InvalidResetCode, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// a code is only compared after a granted reservation
+	if reserved != global.PinResetCodeMaxAttempts {
+		t.Fatalf("expected %d codes to be checked, got %d", global.PinResetCodeMaxAttempts, reserved)
+	}
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"assignment/global"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+)
+
+type UpdateProfileInput struct {
+	Name     *string `json:"name" validate:"omitnil,max=100"`
+	Greeting *string `json:"greeting" validate:"omitnil,max=255"`
+}
+
+type NotificationSettingsInput struct {
+	Push  *bool `json:"push"`
+	Email *bool `json:"email"`
+	Sms   *bool `json:"sms"`
+}
+
+type UpdatePreferencesInput struct {
+	Language      *string                    `json:"language" validate:"omitnil,max=10"`
+	Notifications *NotificationSettingsInput `json:"notifications"`
+}
+
+func profileUpdateError(err error) error {
+	if errors.Is(err, model_mysql.ErrUserNotFound) {
+		return global.NewSystemError(global.UserNotFound)
+	}
+	return global.NewRawSystemError(global.DatabaseError, err.Error())
+}
+
+// UpdateProfile changes the display name and custom greeting of the logged-in user, an empty greeting clears it
+func (controller Controller) UpdateProfile(ctx context.Context, input UpdateProfileInput) (GetMeOutput, error) {
+	controller.Logger.Info("start update profile")
+
+	changes := model_mysql.ProfileChanges{Greeting: input.Greeting}
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return GetMeOutput{}, global.NewSystemError(global.InvalidDisplayName)
+		}
+		changes.Name = &name
+	}
+
+	if err := controller.ModelRepository.UpdateUserProfile(ctx, controller.UserId, changes); err != nil {
+		controller.Logger.Errorf("update user profile failed because: %s", err.Error())
+		return GetMeOutput{}, profileUpdateError(err)
+	}
+
+	controller.Logger.Info("update profile completed")
+	return controller.GetMe(ctx)
+}
+
+// UpdatePreferences changes the preferred language and notification settings of the logged-in user
+func (controller Controller) UpdatePreferences(ctx context.Context, input UpdatePreferencesInput) (GetMeOutput, error) {
+	controller.Logger.Info("start update preferences")
+
+	changes := model_mysql.PreferenceChanges{Language: input.Language}
+	if input.Language != nil && !slices.Contains(global.SupportedLanguages, *input.Language) {
+		return GetMeOutput{}, global.NewSystemError(global.UnsupportedLanguage, strings.Join(global.SupportedLanguages, ", "))
+	}
+	if input.Notifications != nil {
+		changes.NotifyPush = input.Notifications.Push
+		changes.NotifyEmail = input.Notifications.Email
+		changes.NotifySms = input.Notifications.Sms
+	}
+
+	if err := controller.ModelRepository.UpdateUserPreferences(ctx, controller.UserId, global.DefaultLanguage, changes); err != nil {
+		controller.Logger.Errorf("update user preferences failed because: %s", err.Error())
+		return GetMeOutput{}, profileUpdateError(err)
+	}
+
+	controller.Logger.Info("update preferences completed")
+	return controller.GetMe(ctx)
+}
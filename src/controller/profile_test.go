@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"assignment/global"
+	mock_model "assignment/mocks/model"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestController_UpdateProfile_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	name := "  Alice B.  "
+	greeting := "Have a nice day"
+	mockRepo.EXPECT().UpdateUserProfile(gomock.Any(), "test-user-id", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, changes model_mysql.ProfileChanges) error {
+			if changes.Name == nil || *changes.Name != "Alice B." {
+				t.Fatalf("expected trimmed name, got %v", changes.Name)
+			}
+			if changes.Greeting == nil || *changes.Greeting != greeting {
+				t.Fatalf("unexpected greeting: %v", changes.Greeting)
+			}
+			return nil
+		}).
+		Times(1)
+	mockRepo.EXPECT().GetUserProfile(gomock.Any(), "test-user-id").
+		Return(model_mysql.UserProfile{UserId: "user-0001", Name: "Alice B.", Greeting: greeting}, nil).
+		Times(1)
+
+	out, err := c.UpdateProfile(context.Background(), UpdateProfileInput{Name: &name, Greeting: &greeting})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Name != "Alice B." || out.Greeting != greeting {
+		t.Fatalf("unexpected profile: %+v", out)
+	}
+}
+
+func TestController_UpdateProfile_BlankName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	name := "   "
+	_, err := c.UpdateProfile(context.Background(), UpdateProfileInput{Name: &name})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.InvalidDisplayName {
+		t.Fatalf("expected InvalidDisplayName error, got %v", err)
+	}
+}
+
+func TestController_UpdatePreferences_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	language := "th"
+	off := false
+	mockRepo.EXPECT().UpdateUserPreferences(gomock.Any(), "test-user-id", global.DefaultLanguage, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, changes model_mysql.PreferenceChanges) error {
+			if changes.Language == nil || *changes.Language != "th" {
+				t.Fatalf("unexpected language: %v", changes.Language)
+			}
+			if changes.NotifySms == nil || *changes.NotifySms || changes.NotifyPush != nil || changes.NotifyEmail != nil {
+				t.Fatalf("unexpected notification changes: %+v", changes)
+			}
+			return nil
+		}).
+		Times(1)
+	mockRepo.EXPECT().GetUserProfile(gomock.Any(), "test-user-id").
+		Return(model_mysql.UserProfile{UserId: "user-0001", Language: "th", NotifyPush: true, NotifyEmail: true}, nil).
+		Times(1)
+
+	out, err := c.UpdatePreferences(context.Background(), UpdatePreferencesInput{
+		Language:      &language,
+		Notifications: &NotificationSettingsInput{Sms: &off},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := Preferences{Language: "th", Notifications: NotificationSettings{Push: true, Email: true, Sms: false}}
+	if out.Preferences != expected {
+		t.Fatalf("unexpected preferences: got %+v, want %+v", out.Preferences, expected)
+	}
+}
+
+func TestController_UpdatePreferences_UnsupportedLanguage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	language := "xx"
+	_, err := c.UpdatePreferences(context.Background(), UpdatePreferencesInput{Language: &language})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.UnsupportedLanguage {
+		t.Fatalf("expected UnsupportedLanguage error, got %v", err)
+	}
+}
+
+func TestController_UpdatePreferences_UserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().UpdateUserPreferences(gomock.Any(), "test-user-id", gomock.Any(), gomock.Any()).
+		Return(model_mysql.ErrUserNotFound).
+		Times(1)
+
+	_, err := c.UpdatePreferences(context.Background(), UpdatePreferencesInput{})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.UserNotFound {
+		t.Fatalf("expected UserNotFound error, got %v", err)
+	}
+}
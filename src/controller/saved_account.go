@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"assignment/global"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"errors"
+	"strings"
+)
+
+type CreateSavedAccountInput struct {
+	Name          string `json:"name" validate:"required,max=100"`
+	AccountNumber string `json:"account_number" validate:"required,max=30"`
+	Image         string `json:"image" validate:"omitempty,url,max=255"`
+}
+
+type RenameSavedAccountInput struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type FavoriteSavedAccountInput struct {
+	Favorite *bool `json:"favorite" validate:"required"`
+}
+
+func savedAccountError(err error) error {
+	if errors.Is(err, model_mysql.ErrPayeeNotFound) {
+		return global.NewSystemError(global.SavedAccountNotFound)
+	}
+	return global.NewRawSystemError(global.DatabaseError, err.Error())
+}
+
+func savedAccountName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", global.NewSystemError(global.InvalidSavedAccountName)
+	}
+	return name, nil
+}
+
+func (controller Controller) CreateSavedAccount(ctx context.Context, input CreateSavedAccountInput) (model_mysql.SavedAccounts, error) {
+	controller.Logger.Info("start create saved account")
+
+	name, err := savedAccountName(input.Name)
+	if err != nil {
+		return model_mysql.SavedAccounts{}, err
+	}
+
+	payee, err := controller.resolvePayee(ctx, input.AccountNumber)
+	if err != nil {
+		return model_mysql.SavedAccounts{}, err
+	}
+
+	saved, err := controller.ModelRepository.CreateSavedAccount(ctx, controller.UserId, name, payee.AccountNumber, input.Image)
+	if err != nil {
+		controller.Logger.Errorf("create saved account failed because: %s", err.Error())
+		if errors.Is(err, model_mysql.ErrSavedAccountExists) {
+			return saved, global.NewSystemError(global.SavedAccountExists, payee.AccountNumber)
+		}
+		return saved, savedAccountError(err)
+	}
+
+	controller.Logger.Info("create saved account completed")
+	return saved, nil
+}
+
+func (controller Controller) RenameSavedAccount(ctx context.Context, savedAccountId string, input RenameSavedAccountInput) (model_mysql.SavedAccounts, error) {
+	controller.Logger.Infof("start rename saved account %s", savedAccountId)
+
+	name, err := savedAccountName(input.Name)
+	if err != nil {
+		return model_mysql.SavedAccounts{}, err
+	}
+
+	saved, err := controller.ModelRepository.UpdateSavedAccount(ctx, controller.UserId, savedAccountId,
+		model_mysql.SavedAccountChanges{AccountName: &name})
+	if err != nil {
+		controller.Logger.Errorf("rename saved account failed because: %s", err.Error())
+		return saved, savedAccountError(err)
+	}
+
+	controller.Logger.Info("rename saved account completed")
+	return saved, nil
+}
+
+func (controller Controller) FavoriteSavedAccount(ctx context.Context, savedAccountId string, input FavoriteSavedAccountInput) (model_mysql.SavedAccounts, error) {
+	controller.Logger.Infof("start set favorite of saved account %s to %t", savedAccountId, *input.Favorite)
+
+	saved, err := controller.ModelRepository.UpdateSavedAccount(ctx, controller.UserId, savedAccountId,
+		model_mysql.SavedAccountChanges{IsFavorite: input.Favorite})
+	if err != nil {
+		controller.Logger.Errorf("favorite saved account failed because: %s", err.Error())
+		return saved, savedAccountError(err)
+	}
+
+	controller.Logger.Info("favorite saved account completed")
+	return saved, nil
+}
+
+func (controller Controller) DeleteSavedAccount(ctx context.Context, savedAccountId string) error {
+	controller.Logger.Infof("start delete saved account %s", savedAccountId)
+
+	if err := controller.ModelRepository.DeleteSavedAccount(ctx, controller.UserId, savedAccountId); err != nil {
+		controller.Logger.Errorf("delete saved account failed because: %s", err.Error())
+		return savedAccountError(err)
+	}
+
+	controller.Logger.Info("delete saved account completed")
+	return nil
+}
@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"assignment/global"
+	mock_model "assignment/mocks/model"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestController_CreateSavedAccount_TrimsName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	want := model_mysql.SavedAccounts{SavedAccountId: "saved-1", AccountName: "Alice", AccountNumber: "1234567897"}
+	mockRepo.EXPECT().LookupAccountOwner(gomock.Any(), "1234567897").Return(model_mysql.AccountOwner{}, model_mysql.ErrAccountNotFound).Times(1)
+	mockRepo.EXPECT().CreateSavedAccount(gomock.Any(), "test-user-id", "Alice", "1234567897", "").Return(want, nil).Times(1)
+
+	out, err := c.CreateSavedAccount(context.Background(), CreateSavedAccountInput{Name: " Alice ", AccountNumber: "123-4-56789-7"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != want {
+		t.Fatalf("unexpected output: %+v", out)
+	}
+}
+
+func TestController_CreateSavedAccount_BlankName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	_, err := c.CreateSavedAccount(context.Background(), CreateSavedAccountInput{Name: "   ", AccountNumber: "1234567890"})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.InvalidSavedAccountName {
+		t.Fatalf("expected InvalidSavedAccountName, got %v", err)
+	}
+}
+
+func TestController_CreateSavedAccount_AlreadySaved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().LookupAccountOwner(gomock.Any(), "1234567897").Return(model_mysql.AccountOwner{}, model_mysql.ErrAccountNotFound).Times(1)
+	mockRepo.EXPECT().CreateSavedAccount(gomock.Any(), "test-user-id", "Alice", "1234567897", "").
+		Return(model_mysql.SavedAccounts{}, model_mysql.ErrSavedAccountExists).Times(1)
+
+	_, err := c.CreateSavedAccount(context.Background(), CreateSavedAccountInput{Name: "Alice", AccountNumber: "1234567897"})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.SavedAccountExists {
+		t.Fatalf("expected SavedAccountExists, got %v", err)
+	}
+}
+
+func TestController_CreateSavedAccount_StoresInternalNumberAsHeld(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	owner := model_mysql.AccountOwner{AccountNumber: "568-2-71318", Issuer: "TestLab", UserId: "other-user", Name: "Jane Doe"}
+	mockRepo.EXPECT().LookupAccountOwner(gomock.Any(), "568271318").Return(owner, nil).Times(1)
+	mockRepo.EXPECT().CreateSavedAccount(gomock.Any(), "test-user-id", "Jane", "568-2-71318", "").
+		Return(model_mysql.SavedAccounts{AccountNumber: "568-2-71318"}, nil).Times(1)
+
+	if _, err := c.CreateSavedAccount(context.Background(), CreateSavedAccountInput{Name: "Jane", AccountNumber: "568271318"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestController_FavoriteSavedAccount_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	favorite := true
+	mockRepo.EXPECT().UpdateSavedAccount(gomock.Any(), "test-user-id", "saved-1", model_mysql.SavedAccountChanges{IsFavorite: &favorite}).
+		Return(model_mysql.SavedAccounts{}, model_mysql.ErrPayeeNotFound).Times(1)
+
+	_, err := c.FavoriteSavedAccount(context.Background(), "saved-1", FavoriteSavedAccountInput{Favorite: &favorite})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.SavedAccountNotFound {
+		t.Fatalf("expected SavedAccountNotFound, got %v", err)
+	}
+}
+
+func TestController_DeleteSavedAccount_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().DeleteSavedAccount(gomock.Any(), "test-user-id", "saved-1").Return(nil).Times(1)
+
+	if err := c.DeleteSavedAccount(context.Background(), "saved-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"assignment/global"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"errors"
+	"github.com/shopspring/decimal"
+	"strings"
+	"time"
+)
+
+const savingsGoalDeadlineLayout = "2006-01-02"
+
+type CreateSavingsGoalInput struct {
+	AccountId    string          `json:"account_id" validate:"required,max=50"`
+	Name         string          `json:"name" validate:"required,max=100"`
+	TargetAmount decimal.Decimal `json:"target_amount"`
+	Deadline     string          `json:"deadline" validate:"required,datetime=2006-01-02"`
+}
+
+type UpdateSavingsGoalInput struct {
+	Name         *string          `json:"name" validate:"omitnil,max=100"`
+	TargetAmount *decimal.Decimal `json:"target_amount"`
+	Deadline     *string          `json:"deadline" validate:"omitnil,datetime=2006-01-02"`
+}
+
+func invalidSavingsGoal(reason global.Reason) error {
+	return global.NewSystemError(global.InvalidSavingsGoal, reason)
+}
+
+func savingsGoalName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", invalidSavingsGoal(global.NewReason(global.ReasonBlankName))
+	}
+	return name, nil
+}
+
+// Balances are stored as DECIMAL(15,2) so the target is held to the same precision
+func checkSavingsGoalTarget(target decimal.Decimal) error {
+	if !target.IsPositive() || !target.Equal(target.Round(2)) {
+		return invalidSavingsGoal(global.NewReason(global.ReasonInvalidTarget))
+	}
+	return nil
+}
+
+// savingsGoalDeadline parses the deadline as a local date, it has to be after today
+func savingsGoalDeadline(deadline string, now time.Time) (time.Time, error) {
+	parsed, err := time.ParseInLocation(savingsGoalDeadlineLayout, deadline, time.Local)
+	if err != nil {
+		return time.Time{}, invalidSavingsGoal(global.NewReason(global.ReasonInvalidDate, "deadline"))
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if !parsed.After(today) {
+		return time.Time{}, invalidSavingsGoal(global.NewReason(global.ReasonNotInFuture, "deadline"))
+	}
+	return parsed, nil
+}
+
+func savingsGoalError(err error) error {
+	var code int64
+	switch {
+	case errors.Is(err, model_mysql.ErrAccountNotFound):
+		code = global.AccountNotFound
+	case errors.Is(err, model_mysql.ErrSavingsGoalNotFound):
+		code = global.SavingsGoalNotFound
+	case errors.Is(err, model_mysql.ErrSavingsGoalExists):
+		code = global.SavingsGoalExists
+	case errors.Is(err, model_mysql.ErrSavingsGoalClosed):
+		code = global.SavingsGoalClosed
+	default:
+		return global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+	return global.NewSystemError(code)
+}
+
+func (controller Controller) CreateSavingsGoal(ctx context.Context, input CreateSavingsGoalInput) (model_mysql.SavingsGoal, error) {
+	controller.Logger.Infof("start create savings goal for account %s", input.AccountId)
+
+	name, err := savingsGoalName(input.Name)
+	if err != nil {
+		return model_mysql.SavingsGoal{}, err
+	}
+	if err := checkSavingsGoalTarget(input.TargetAmount); err != nil {
+		return model_mysql.SavingsGoal{}, err
+	}
+	deadline, err := savingsGoalDeadline(input.Deadline, time.Now())
+	if err != nil {
+		return model_mysql.SavingsGoal{}, err
+	}
+
+	goal, err := controller.ModelRepository.CreateSavingsGoal(ctx, controller.UserId, input.AccountId, name, input.TargetAmount, deadline)
+	if err != nil {
+		controller.Logger.Errorf("create savings goal failed because: %s", err.Error())
+		return goal, savingsGoalError(err)
+	}
+
+	controller.Logger.Info("create savings goal completed")
+	return goal, nil
+}
+
+// UpdateSavingsGoal changes only the fields that are given
+func (controller Controller) UpdateSavingsGoal(ctx context.Context, goalId string, input UpdateSavingsGoalInput) (model_mysql.SavingsGoal, error) {
+	controller.Logger.Infof("start update savings goal %s", goalId)
+
+	changes := model_mysql.SavingsGoalChanges{TargetAmount: input.TargetAmount}
+	if input.Name != nil {
+		name, err := savingsGoalName(*input.Name)
+		if err != nil {
+			return model_mysql.SavingsGoal{}, err
+		}
+		changes.Name = &name
+	}
+	if input.TargetAmount != nil {
+		if err := checkSavingsGoalTarget(*input.TargetAmount); err != nil {
+			return model_mysql.SavingsGoal{}, err
+		}
+	}
+	if input.Deadline != nil {
+		deadline, err := savingsGoalDeadline(*input.Deadline, time.Now())
+		if err != nil {
+			return model_mysql.SavingsGoal{}, err
+		}
+		changes.Deadline = &deadline
+	}
+
+	goal, err := controller.ModelRepository.UpdateSavingsGoal(ctx, controller.UserId, goalId, changes)
+	if err != nil {
+		controller.Logger.Errorf("update savings goal failed because: %s", err.Error())
+		return goal, savingsGoalError(err)
+	}
+
+	controller.Logger.Info("update savings goal completed")
+	return goal, nil
+}
+
+func (controller Controller) CloseSavingsGoal(ctx context.Context, goalId string) (model_mysql.SavingsGoal, error) {
+	controller.Logger.Infof("start close savings goal %s", goalId)
+
+	goal, err := controller.ModelRepository.CloseSavingsGoal(ctx, controller.UserId, goalId)
+	if err != nil {
+		controller.Logger.Errorf("close savings goal failed because: %s", err.Error())
+		return goal, savingsGoalError(err)
+	}
+
+	controller.Logger.Info("close savings goal completed")
+	return goal, nil
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"assignment/global"
+	mock_model "assignment/mocks/model"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+)
+
+func TestController_CreateSavingsGoal_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	deadline := time.Now().AddDate(1, 0, 0).Format(savingsGoalDeadlineLayout)
+	want := model_mysql.SavingsGoal{GoalId: "goal-1", AccountId: "acc-1", Name: "Trip", Deadline: deadline, Progress: 10}
+	mockRepo.EXPECT().CreateSavingsGoal(gomock.Any(), "test-user-id", "acc-1", "Trip", decimal.NewFromInt(5000), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _ string, _ decimal.Decimal, at time.Time) (model_mysql.SavingsGoal, error) {
+			if at.Format(savingsGoalDeadlineLayout) != deadline {
+				t.Fatalf("unexpected deadline: %s", at)
+			}
+			return want, nil
+		}).
+		Times(1)
+
+	out, err := c.CreateSavingsGoal(context.Background(), CreateSavingsGoalInput{
+		AccountId: "acc-1", Name: " Trip ", TargetAmount: decimal.NewFromInt(5000), Deadline: deadline,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != want {
+		t.Fatalf("unexpected output: %+v", out)
+	}
+}
+
+func TestController_CreateSavingsGoal_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	nextYear := time.Now().AddDate(1, 0, 0).Format(savingsGoalDeadlineLayout)
+	for _, input := range []CreateSavingsGoalInput{
+		{AccountId: "acc-1", Name: "  ", TargetAmount: decimal.NewFromInt(5000), Deadline: nextYear},
+		{AccountId: "acc-1", Name: "Trip", TargetAmount: decimal.Zero, Deadline: nextYear},
+		{AccountId: "acc-1", Name: "Trip", TargetAmount: decimal.RequireFromString("10.001"), Deadline: nextYear},
+		{AccountId: "acc-1", Name: "Trip", TargetAmount: decimal.NewFromInt(5000), Deadline: time.Now().Format(savingsGoalDeadlineLayout)},
+	} {
+		_, err := c.CreateSavingsGoal(context.Background(), input)
+		sysErr, ok := err.(global.SystemError)
+		if !ok || sysErr.Code != global.InvalidSavingsGoal {
+			t.Fatalf("%+v: expected InvalidSavingsGoal, got %v", input, err)
+		}
+	}
+}
+
+func TestController_UpdateSavingsGoal_OnlyGivenFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	target := decimal.NewFromInt(8000)
+	mockRepo.EXPECT().UpdateSavingsGoal(gomock.Any(), "test-user-id", "goal-1", model_mysql.SavingsGoalChanges{TargetAmount: &target}).
+		Return(model_mysql.SavingsGoal{GoalId: "goal-1"}, nil).Times(1)
+
+	if _, err := c.UpdateSavingsGoal(context.Background(), "goal-1", UpdateSavingsGoalInput{TargetAmount: &target}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestController_CloseSavingsGoal_AlreadyClosed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().CloseSavingsGoal(gomock.Any(), "test-user-id", "goal-1").
+		Return(model_mysql.SavingsGoal{}, model_mysql.ErrSavingsGoalClosed).Times(1)
+
+	_, err := c.CloseSavingsGoal(context.Background(), "goal-1")
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.SavingsGoalClosed {
+		t.Fatalf("expected SavingsGoalClosed, got %v", err)
+	}
+}
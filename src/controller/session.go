@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"assignment/entity"
+	"assignment/global"
+	"assignment/jwt"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"errors"
+)
+
+type RefreshTokenInput struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// RefreshToken rotates the access and refresh token of a session. Replaying a refresh token that was
+// already used ends the session, since it means the token leaked.
+func (controller Controller) RefreshToken(ctx context.Context, input RefreshTokenInput) (TokenOutput, error) {
+	controller.Logger.Info("start refreshing token")
+
+	tokens, err := controller.ModelRepository.RefreshSessionToken(ctx, input.RefreshToken, sessionPolicy())
+	if err != nil {
+		if errors.Is(err, model_mysql.ErrRefreshTokenReused) {
+			controller.Logger.Errorf("refresh token was replayed, session is revoked")
+			return TokenOutput{}, global.NewSystemError(global.RefreshTokenReused)
+		}
+		if errors.Is(err, model_mysql.ErrRefreshTokenInvalid) {
+			controller.Logger.Errorf("refresh token is invalid or expired")
+			return TokenOutput{}, global.NewSystemError(global.InvalidRefreshToken)
+		}
+		controller.Logger.Errorf("refresh session token failed because: %s", err.Error())
+		return TokenOutput{}, global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	controller.Logger.Info("refresh token completed")
+	return newTokenOutput(tokens), nil
+}
+
+type Session struct {
+	entity.Tokens
+	Current bool `json:"current"`
+}
+
+type GetSessionsOutput struct {
+	Sessions []Session `json:"sessions"`
+}
+
+// GetSessions lists the active sessions of the user and marks the one the request came from
+func (controller Controller) GetSessions(ctx context.Context, currentSessionId string) (GetSessionsOutput, error) {
+	controller.Logger.Info("start getting sessions")
+	output := GetSessionsOutput{Sessions: []Session{}}
+
+	tokens, err := controller.ModelRepository.GetActiveSessions(ctx, controller.UserId)
+	if err != nil {
+		controller.Logger.Errorf("get active sessions failed because: %s", err.Error())
+		return output, global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	for _, token := range tokens {
+		output.Sessions = append(output.Sessions, Session{
+			Tokens:  token,
+			Current: token.SessionId == currentSessionId,
+		})
+	}
+
+	controller.Logger.Info("get sessions completed")
+	return output, nil
+}
+
+// Logout ends the session the request came from
+func (controller Controller) Logout(ctx context.Context, currentSessionId string) error {
+	controller.Logger.Info("start logging out")
+
+	if err := controller.revokeSession(ctx, currentSessionId); err != nil {
+		return err
+	}
+
+	controller.Logger.Info("logout completed")
+	return nil
+}
+
+type RevokeSessionInput struct {
+	SessionId string `json:"session_id" validate:"required"`
+}
+
+// RevokeSession ends one of the user's sessions, e.g. a lost device
+func (controller Controller) RevokeSession(ctx context.Context, input RevokeSessionInput) error {
+	controller.Logger.Info("start revoking session")
+
+	if err := controller.revokeSession(ctx, input.SessionId); err != nil {
+		return err
+	}
+
+	controller.Logger.Info("revoke session completed")
+	return nil
+}
+
+type RevokeOtherSessionsOutput struct {
+	RevokedCount int64 `json:"revoked_count"`
+}
+
+// RevokeOtherSessions ends every session of the user except the one the request came from
+func (controller Controller) RevokeOtherSessions(ctx context.Context, currentSessionId string) (RevokeOtherSessionsOutput, error) {
+	controller.Logger.Info("start revoking other sessions")
+	output := RevokeOtherSessionsOutput{}
+
+	revokedCount, err := controller.ModelRepository.RevokeOtherSessions(ctx, controller.UserId, currentSessionId)
+	if err != nil {
+		controller.Logger.Errorf("revoke other sessions failed because: %s", err.Error())
+		return output, global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+	output.RevokedCount = revokedCount
+
+	controller.Logger.Info("revoke other sessions completed")
+	return output, nil
+}
+
+func (controller Controller) revokeSession(ctx context.Context, sessionId string) error {
+	if err := controller.ModelRepository.RevokeSession(ctx, controller.UserId, sessionId); err != nil {
+		if errors.Is(err, model_mysql.ErrSessionNotFound) {
+			controller.Logger.Errorf("user %s has no active session %s", controller.UserId, sessionId)
+			return global.NewSystemError(global.SessionNotFound)
+		}
+		controller.Logger.Errorf("revoke session failed because: %s", err.Error())
+		return global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+	return nil
+}
+
+func sessionPolicy() model_mysql.SessionPolicy {
+	policy := model_mysql.SessionPolicy{
+		MaxSessions:          global.MaxConcurrentSessions,
+		AccessTokenLifetime:  global.AccessTokenLifetime,
+		RefreshTokenLifetime: global.RefreshTokenLifetime,
+	}
+	if global.AuthMode == global.AUTH_MODE_JWT && jwt.DefaultSigner != nil {
+		policy.AccessTokenIssuer = jwt.DefaultSigner
+	}
+	return policy
+}
+
+func newTokenOutput(tokens model_mysql.SessionTokens) TokenOutput {
+	return TokenOutput{
+		Token:        tokens.AccessToken,
+		ExpiredAt:    tokens.AccessTokenExpiredAt,
+		RefreshToken: tokens.RefreshToken,
+	}
+}
@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"assignment/entity"
+	"assignment/global"
+	mock_model "assignment/mocks/model"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestController_GetSessions_MarksCurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().GetActiveSessions(gomock.Any(), "test-user-id").Return([]entity.Tokens{
+		{SessionId: "s1", DeviceName: "Pixel"},
+		{SessionId: "s2", DeviceName: "iPad"},
+	}, nil).Times(1)
+
+	out, err := c.GetSessions(context.Background(), "s2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.Sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(out.Sessions))
+	}
+	if out.Sessions[0].Current || !out.Sessions[1].Current {
+		t.Fatalf("unexpected current flags: %+v", out.Sessions)
+	}
+}
+
+func TestController_GetSessions_Empty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().GetActiveSessions(gomock.Any(), "test-user-id").Return(nil, nil).Times(1)
+
+	out, err := c.GetSessions(context.Background(), "s1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Sessions == nil || len(out.Sessions) != 0 {
+		t.Fatalf("expected an empty, non-nil slice, got %#v", out.Sessions)
+	}
+}
+
+func TestController_Logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().RevokeSession(gomock.Any(), "test-user-id", "current").Return(nil).Times(1)
+
+	if err := c.Logout(context.Background(), "current"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestController_RevokeSession_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().RevokeSession(gomock.Any(), "test-user-id", "other").Return(model_mysql.ErrSessionNotFound).Times(1)
+
+	err := c.RevokeSession(context.Background(), RevokeSessionInput{SessionId: "other"})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.SessionNotFound {
+		t.Fatalf("expected SessionNotFound, got %v", err)
+	}
+}
+
+func TestController_RevokeSession_DatabaseError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().RevokeSession(gomock.Any(), "test-user-id", "other").Return(errors.New("db down")).Times(1)
+
+	err := c.RevokeSession(context.Background(), RevokeSessionInput{SessionId: "other"})
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.DatabaseError {
+		t.Fatalf("expected DatabaseError, got %v", err)
+	}
+}
+
+func TestController_RevokeOtherSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().RevokeOtherSessions(gomock.Any(), "test-user-id", "current").Return(int64(2), nil).Times(1)
+
+	out, err := c.RevokeOtherSessions(context.Background(), "current")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.RevokedCount != 2 {
+		t.Fatalf("expected 2 revoked sessions, got %d", out.RevokedCount)
+	}
+}
+
+func TestController_RefreshToken_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	expiredAt := time.Now().Add(15 * time.Minute)
+	mockRepo.EXPECT().RefreshSessionToken(gomock.Any(), "refresh-1", sessionPolicy()).
+		Return(model_mysql.SessionTokens{AccessToken: "access-2", AccessTokenExpiredAt: expiredAt, RefreshToken: "refresh-2"}, nil).
+		Times(1)
+
+	out, err := c.RefreshToken(context.Background(), RefreshTokenInput{RefreshToken: "refresh-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Token != "access-2" || out.RefreshToken != "refresh-2" || !out.ExpiredAt.Equal(expiredAt) {
+		t.Fatalf("unexpected output: %+v", out)
+	}
+}
+
+func TestController_RefreshToken_Errors(t *testing.T) {
+	cases := map[string]struct {
+		repoErr  error
+		wantCode int64
+	}{
+		"invalid":  {repoErr: model_mysql.ErrRefreshTokenInvalid, wantCode: global.InvalidRefreshToken},
+		"reused":   {repoErr: model_mysql.ErrRefreshTokenReused, wantCode: global.RefreshTokenReused},
+		"database": {repoErr: errors.New("db down"), wantCode: global.DatabaseError},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_model.NewMockModelRepository(ctrl)
+			c := newTestController(mockRepo)
+
+			mockRepo.EXPECT().RefreshSessionToken(gomock.Any(), "refresh-1", gomock.Any()).
+				Return(model_mysql.SessionTokens{}, tc.repoErr).
+				Times(1)
+
+			_, err := c.RefreshToken(context.Background(), RefreshTokenInput{RefreshToken: "refresh-1"})
+			sysErr, ok := err.(global.SystemError)
+			if !ok || sysErr.Code != tc.wantCode {
+				t.Fatalf("expected code %d, got %v", tc.wantCode, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"assignment/entity"
+	"assignment/global"
+	"context"
+)
+
+const defaultTransactionPageSize = 20
+
+type GetTransactionsInput struct {
+	Cursor string `query:"cursor"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+type GetTransactionsOutput struct {
+	Transactions []entity.Transactions `json:"transactions"`
+	NextCursor   string                `json:"next_cursor"`
+	HasMore      bool                  `json:"has_more"`
+}
+
+func (controller Controller) GetUserTransactions(ctx context.Context, input GetTransactionsInput) (GetTransactionsOutput, error) {
+	controller.Logger.Info("getting user transactions")
+	output := GetTransactionsOutput{}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultTransactionPageSize
+	}
+
+	// Fetch one extra row to know whether another page exists
+	transactions, err := controller.ModelRepository.GetUserTransactions(ctx, controller.UserId, input.Cursor, limit+1)
+	if err != nil {
+		controller.Logger.Errorf("get user transactions failed because: %s", err.Error())
+		return output, global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	if len(transactions) > limit {
+		transactions = transactions[:limit]
+		output.HasMore = true
+		output.NextCursor = transactions[limit-1].TransactionId
+	}
+	output.Transactions = transactions
+
+	controller.Logger.Info("get user transactions completed")
+
+	return output, nil
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"assignment/entity"
+	"assignment/global"
+	mock_model "assignment/mocks/model"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestController_GetUserTransactions_LastPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+
+	expected := []entity.Transactions{{TransactionId: "tx-1"}, {TransactionId: "tx-2"}}
+	mockRepo.EXPECT().GetUserTransactions(gomock.Any(), "test-user-id", "", defaultTransactionPageSize+1).Return(expected, nil).Times(1)
+
+	c := newTestController(mockRepo)
+
+	out, err := c.GetUserTransactions(context.Background(), GetTransactionsInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(out.Transactions, expected) {
+		t.Fatalf("unexpected transactions: got %+v, want %+v", out.Transactions, expected)
+	}
+	if out.HasMore || out.NextCursor != "" {
+		t.Fatalf("expected no next page, got has_more=%v next_cursor=%q", out.HasMore, out.NextCursor)
+	}
+}
+
+func TestController_GetUserTransactions_HasMore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+
+	repoRows := []entity.Transactions{{TransactionId: "tx-3"}, {TransactionId: "tx-4"}, {TransactionId: "tx-5"}}
+	mockRepo.EXPECT().GetUserTransactions(gomock.Any(), "test-user-id", "tx-2", 3).Return(repoRows, nil).Times(1)
+
+	c := newTestController(mockRepo)
+
+	out, err := c.GetUserTransactions(context.Background(), GetTransactionsInput{Cursor: "tx-2", Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(out.Transactions, repoRows[:2]) {
+		t.Fatalf("unexpected transactions: got %+v, want %+v", out.Transactions, repoRows[:2])
+	}
+	if !out.HasMore || out.NextCursor != "tx-4" {
+		t.Fatalf("expected next cursor tx-4, got has_more=%v next_cursor=%q", out.HasMore, out.NextCursor)
+	}
+}
+
+func TestController_GetUserTransactions_RepoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+
+	mockRepo.EXPECT().GetUserTransactions(gomock.Any(), "test-user-id", "", gomock.Any()).Return(nil, errors.New("boom")).Times(1)
+
+	c := newTestController(mockRepo)
+
+	_, err := c.GetUserTransactions(context.Background(), GetTransactionsInput{})
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	sysErr, ok := err.(global.SystemError)
+	if !ok {
+		t.Fatalf("expected global.SystemError, got %T: %v", err, err)
+	}
+	if sysErr.Code != global.DatabaseError {
+		t.Fatalf("unexpected error code: got %v, want %v", sysErr.Code, global.DatabaseError)
+	}
+}
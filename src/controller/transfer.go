@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"assignment/entity"
+	"assignment/global"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"errors"
+	"github.com/shopspring/decimal"
+)
+
+type TransferInput struct {
+	FromAccountId   string          `json:"from_account_id" validate:"required"`
+	ToAccountId     string          `json:"to_account_id" validate:"required_without=ToAccountNumber,excluded_with=ToAccountNumber"`
+	ToAccountNumber string          `json:"to_account_number" validate:"required_without=ToAccountId"`
+	Amount          decimal.Decimal `json:"amount"`
+	Note            string          `json:"note" validate:"max=255"`
+}
+
+type TransferOutput struct {
+	Transfer entity.Transfers `json:"transfer"`
+	Balance  decimal.Decimal  `json:"balance"`
+}
+
+func (controller Controller) Transfer(ctx context.Context, input TransferInput) (TransferOutput, error) {
+	controller.Logger.Info("start transfer")
+	output := TransferOutput{}
+
+	// Balances are stored as DECIMAL(15,2) so anything finer than satang would be silently rounded
+	if !input.Amount.IsPositive() || !input.Amount.Equal(input.Amount.Round(2)) {
+		controller.Logger.Errorf("user %s requested an invalid transfer amount %s", controller.UserId, input.Amount.String())
+		return output, global.NewSystemError(global.InvalidTransferAmount)
+	}
+
+	transfer, balance, err := controller.ModelRepository.Transfer(ctx, controller.UserId, model_mysql.TransferRequest{
+		FromAccountId:   input.FromAccountId,
+		ToAccountId:     input.ToAccountId,
+		ToAccountNumber: input.ToAccountNumber,
+		Amount:          input.Amount,
+		Note:            input.Note,
+	})
+	if err != nil {
+		controller.Logger.Errorf("transfer failed because: %s", err.Error())
+		return output, transferError(err)
+	}
+
+	output.Transfer = transfer
+	output.Balance = balance
+
+	controller.Logger.Info("transfer completed")
+	return output, nil
+}
+
+func transferError(err error) global.SystemError {
+	var code int64
+	switch {
+	case errors.Is(err, model_mysql.ErrAccountNotFound), errors.Is(err, model_mysql.ErrPayeeNotFound):
+		code = global.AccountNotFound
+	case errors.Is(err, model_mysql.ErrSameAccount):
+		code = global.SameAccountTransfer
+	case errors.Is(err, model_mysql.ErrInsufficientFunds):
+		code = global.InsufficientFunds
+	case errors.Is(err, model_mysql.ErrCurrencyMismatch):
+		code = global.CurrencyMismatch
+	default:
+		return global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+	return global.NewSystemError(code)
+}
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"assignment/entity"
+	"assignment/global"
+	mock_model "assignment/mocks/model"
+	model_mysql "assignment/model/mysql"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+)
+
+func TestController_Transfer_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+
+	input := TransferInput{
+		FromAccountId: "acc-1",
+		ToAccountId:   "acc-2",
+		Amount:        decimal.RequireFromString("150.25"),
+	}
+	wantTransfer := entity.Transfers{TransferId: "tr-1", FromAccountId: "acc-1", ToAccountId: "acc-2", Amount: input.Amount}
+	wantBalance := decimal.RequireFromString("849.75")
+
+	mockRepo.EXPECT().
+		Transfer(gomock.Any(), "test-user-id", model_mysql.TransferRequest{
+			FromAccountId: "acc-1",
+			ToAccountId:   "acc-2",
+			Amount:        input.Amount,
+		}).
+		Return(wantTransfer, wantBalance, nil).
+		Times(1)
+
+	c := newTestController(mockRepo)
+
+	out, err := c.Transfer(context.Background(), input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Transfer.TransferId != wantTransfer.TransferId {
+		t.Fatalf("unexpected transfer: got %+v, want %+v", out.Transfer, wantTransfer)
+	}
+	if !out.Balance.Equal(wantBalance) {
+		t.Fatalf("unexpected balance: got %s, want %s", out.Balance, wantBalance)
+	}
+}
+
+func TestController_Transfer_InvalidAmount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	for _, amount := range []string{"0", "-10", "10.001"} {
+		_, err := c.Transfer(context.Background(), TransferInput{
+			FromAccountId: "acc-1",
+			ToAccountId:   "acc-2",
+			Amount:        decimal.RequireFromString(amount),
+		})
+		sysErr, ok := err.(global.SystemError)
+		if !ok {
+			t.Fatalf("amount %s: expected global.SystemError, got %T: %v", amount, err, err)
+		}
+		if sysErr.Code != global.InvalidTransferAmount {
+			t.Fatalf("amount %s: unexpected error code: got %v, want %v", amount, sysErr.Code, global.InvalidTransferAmount)
+		}
+	}
+}
+
+func TestController_Transfer_RepoErrors(t *testing.T) {
+	cases := []struct {
+		repoErr  error
+		wantCode int64
+	}{
+		{model_mysql.ErrAccountNotFound, global.AccountNotFound},
+		{model_mysql.ErrPayeeNotFound, global.AccountNotFound},
+		{model_mysql.ErrSameAccount, global.SameAccountTransfer},
+		{model_mysql.ErrInsufficientFunds, global.InsufficientFunds},
+		{model_mysql.ErrCurrencyMismatch, global.CurrencyMismatch},
+		{errors.New("boom"), global.DatabaseError},
+	}
+
+	for _, tc := range cases {
+		ctrl := gomock.NewController(t)
+		mockRepo := mock_model.NewMockModelRepository(ctrl)
+		mockRepo.EXPECT().Transfer(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.Transfers{}, decimal.Zero, tc.repoErr).Times(1)
+
+		c := newTestController(mockRepo)
+
+		_, err := c.Transfer(context.Background(), TransferInput{
+			FromAccountId:   "acc-1",
+			ToAccountNumber: "1234567890",
+			Amount:          decimal.NewFromInt(100),
+		})
+		sysErr, ok := err.(global.SystemError)
+		if !ok {
+			t.Fatalf("%v: expected global.SystemError, got %T: %v", tc.repoErr, err, err)
+		}
+		if sysErr.Code != tc.wantCode {
+			t.Fatalf("%v: unexpected error code: got %v, want %v", tc.repoErr, sysErr.Code, tc.wantCode)
+		}
+		ctrl.Finish()
+	}
+}
@@ -3,9 +3,14 @@ package controller
 import (
 	"assignment/global"
 	model_mysql "assignment/model/mysql"
+	"assignment/util"
 	"context"
+	"errors"
 )
 
+// Number of trailing characters left readable in masked identifiers
+const maskVisibleLength = 4
+
 type GetUserInput struct {
 	UserId string `json:"user_id" validate:"required"`
 }
@@ -22,12 +27,62 @@ func (controller Controller) GetUser(ctx context.Context, input GetUserInput) (G
 	output.UserInfo, err = controller.ModelRepository.GetUser(ctx, input.UserId)
 	if err != nil {
 		controller.Logger.Errorf("get user failed because: %s", err.Error())
-		return output, global.SystemError{
-			Code:    global.DatabaseError,
-			Message: err.Error(),
-		}
+		return output, global.NewRawSystemError(global.DatabaseError, err.Error())
 	}
 
 	controller.Logger.Info("get user completed")
 	return output, nil
 }
+
+type NotificationSettings struct {
+	Push  bool `json:"push"`
+	Email bool `json:"email"`
+	Sms   bool `json:"sms"`
+}
+
+type Preferences struct {
+	Language      string               `json:"language"`
+	Notifications NotificationSettings `json:"notifications"`
+}
+
+type GetMeOutput struct {
+	UserId            string      `json:"user_id"`
+	Name              string      `json:"name"`
+	Greeting          string      `json:"greeting"`
+	MainAccountNumber string      `json:"main_account_number"`
+	Preferences       Preferences `json:"preferences"`
+}
+
+// GetMe returns the profile of the logged-in user, identifiers are masked
+func (controller Controller) GetMe(ctx context.Context) (GetMeOutput, error) {
+	controller.Logger.Info("start get me")
+	output := GetMeOutput{}
+
+	profile, err := controller.ModelRepository.GetUserProfile(ctx, controller.UserId)
+	if err != nil {
+		controller.Logger.Errorf("get user profile failed because: %s", err.Error())
+		if errors.Is(err, model_mysql.ErrUserNotFound) {
+			return output, global.NewSystemError(global.UserNotFound)
+		}
+		return output, global.NewRawSystemError(global.DatabaseError, err.Error())
+	}
+
+	output.UserId = util.MaskIdentifier(profile.UserId, maskVisibleLength)
+	output.Name = profile.Name
+	output.Greeting = controller.localizeGreeting(ctx, controller.UserId, profile.Greeting)
+	output.MainAccountNumber = util.MaskIdentifier(profile.MainAccountNumber, maskVisibleLength)
+	output.Preferences = Preferences{
+		Language: profile.Language,
+		Notifications: NotificationSettings{
+			Push:  profile.NotifyPush,
+			Email: profile.NotifyEmail,
+			Sms:   profile.NotifySms,
+		},
+	}
+	if output.Preferences.Language == "" {
+		output.Preferences.Language = global.DefaultLanguage
+	}
+
+	controller.Logger.Info("get me completed")
+	return output, nil
+}
@@ -71,3 +71,50 @@ func TestController_GetUser_Error(t *testing.T) {
 		t.Fatalf("expected zero GetUserOutput on error, got %+v", out)
 	}
 }
+
+func TestController_GetMe_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	profile := model_mysql.UserProfile{
+		UserId:            "000018b0e1a211ef95a30242ac180002",
+		Name:              "Alice",
+		Greeting:          "Hello Alice",
+		MainAccountNumber: "568-2-81740-9",
+	}
+	mockRepo.EXPECT().GetUserProfile(gomock.Any(), "test-user-id").Return(profile, nil).Times(1)
+
+	out, err := c.GetMe(context.Background())
+	if err != nil {
+		t.Fatalf("GetMe returned error: %v", err)
+	}
+	expected := GetMeOutput{
+		UserId:            "****************************0002",
+		Name:              "Alice",
+		Greeting:          "Hello Alice",
+		MainAccountNumber: "***-*-**740-9",
+		Preferences:       Preferences{Language: global.DefaultLanguage},
+	}
+	if out != expected {
+		t.Fatalf("unexpected profile: got %+v, want %+v", out, expected)
+	}
+}
+
+func TestController_GetMe_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_model.NewMockModelRepository(ctrl)
+	c := newTestController(mockRepo)
+
+	mockRepo.EXPECT().GetUserProfile(gomock.Any(), "test-user-id").Return(model_mysql.UserProfile{}, model_mysql.ErrUserNotFound).Times(1)
+
+	_, err := c.GetMe(context.Background())
+	sysErr, ok := err.(global.SystemError)
+	if !ok || sysErr.Code != global.UserNotFound {
+		t.Fatalf("expected UserNotFound error, got %v", err)
+	}
+}
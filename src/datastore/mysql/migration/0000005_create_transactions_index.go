@@ -0,0 +1,27 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var createTransactionsIndexMigration = &Migration{
+	Number: 5,
+	Name:   "create transactions index",
+	Forwards: func(db *gorm.DB) error {
+
+		const sql = `
+			CREATE INDEX idx_tx_user_tx ON transactions (user_id, transaction_id);
+		`
+
+		err := db.Exec(sql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to create transactions index")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, createTransactionsIndexMigration)
+}
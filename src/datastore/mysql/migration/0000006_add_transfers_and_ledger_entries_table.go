@@ -0,0 +1,52 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var addTransfersAndLedgerEntriesTableMigration = &Migration{
+	Number: 6,
+	Name:   "create transfers and ledger entries table",
+	Forwards: func(db *gorm.DB) error {
+		const sql = `
+			CREATE TABLE IF NOT EXISTS transfers (
+				transfer_id VARCHAR(50) NOT NULL,
+				user_id VARCHAR(50) NOT NULL,
+				from_account_id VARCHAR(50) NOT NULL,
+				to_account_id VARCHAR(50),
+				to_account_number VARCHAR(20) NOT NULL,
+				amount DECIMAL(15,2) NOT NULL,
+				currency VARCHAR(10) NOT NULL,
+				note VARCHAR(255),
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (transfer_id),
+				INDEX idx_transfers_user (user_id, created_at)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+			CREATE TABLE IF NOT EXISTS ledger_entries (
+				entry_id INT NOT NULL AUTO_INCREMENT,
+				transfer_id VARCHAR(50) NOT NULL,
+				account_id VARCHAR(50),
+				account_number VARCHAR(20) NOT NULL,
+				entry_type VARCHAR(10) NOT NULL,
+				amount DECIMAL(15,2) NOT NULL,
+				currency VARCHAR(10) NOT NULL,
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (entry_id),
+				INDEX idx_le_transfer (transfer_id),
+				INDEX idx_le_account (account_id, created_at)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+		`
+
+		err := db.Exec(sql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to create transfers and ledger entries table")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, addTransfersAndLedgerEntriesTableMigration)
+}
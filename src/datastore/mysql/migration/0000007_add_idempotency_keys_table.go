@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var addIdempotencyKeysTableMigration = &Migration{
+	Number: 7,
+	Name:   "create idempotency keys table",
+	Forwards: func(db *gorm.DB) error {
+		const sql = `
+			CREATE TABLE IF NOT EXISTS idempotency_keys (
+				scope VARCHAR(50) NOT NULL,
+				idempotency_key VARCHAR(255) NOT NULL,
+				request_hash CHAR(64) NOT NULL,
+				status_code INT NOT NULL DEFAULT 0,
+				response_body MEDIUMTEXT,
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				expired_at timestamp NOT NULL,
+				PRIMARY KEY (scope, idempotency_key),
+				INDEX idx_ik_expired_at (expired_at)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+		`
+
+		err := db.Exec(sql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to create idempotency keys table")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, addIdempotencyKeysTableMigration)
+}
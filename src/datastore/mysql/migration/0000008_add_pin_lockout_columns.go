@@ -0,0 +1,29 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var addPinLockoutColumnsMigration = &Migration{
+	Number: 8,
+	Name:   "add pin lockout columns to user pin table",
+	Forwards: func(db *gorm.DB) error {
+		const sql = `
+			ALTER TABLE user_pin
+				ADD COLUMN failed_attempts INT NOT NULL DEFAULT 0 AFTER pin,
+				ADD COLUMN lockout_count INT NOT NULL DEFAULT 0 AFTER failed_attempts,
+				ADD COLUMN locked_until timestamp NULL DEFAULT NULL AFTER lockout_count;
+		`
+
+		err := db.Exec(sql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to add pin lockout columns")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, addPinLockoutColumnsMigration)
+}
@@ -0,0 +1,33 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var addPinResetCodesTableMigration = &Migration{
+	Number: 9,
+	Name:   "create pin reset codes table",
+	Forwards: func(db *gorm.DB) error {
+		const sql = `
+			CREATE TABLE IF NOT EXISTS pin_reset_codes (
+				user_id VARCHAR(50) NOT NULL,
+				code_hash VARCHAR(255) NOT NULL,
+				failed_attempts INT NOT NULL DEFAULT 0,
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				expired_at timestamp NOT NULL,
+				PRIMARY KEY (user_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+		`
+
+		err := db.Exec(sql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to create pin reset codes table")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, addPinResetCodesTableMigration)
+}
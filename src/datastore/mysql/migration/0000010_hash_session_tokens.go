@@ -0,0 +1,26 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// Session tokens used to be stored as is. They are converted in place to the SHA-256 that is stored from now
+// on, so sessions issued before the migration keep working.
+var hashSessionTokensMigration = &Migration{
+	Number: 10,
+	Name:   "hash session tokens",
+	Forwards: func(db *gorm.DB) error {
+		const sql = `UPDATE tokens SET session_id = SHA2(session_id, 256);`
+
+		err := db.Exec(sql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to hash session tokens")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, hashSessionTokensMigration)
+}
@@ -0,0 +1,32 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var addSessionDeviceColumnsMigration = &Migration{
+	Number: 11,
+	Name:   "add session device columns to tokens table",
+	Forwards: func(db *gorm.DB) error {
+		const sql = `
+			ALTER TABLE tokens
+				ADD COLUMN device_id VARCHAR(100) NOT NULL DEFAULT '' AFTER user_id,
+				ADD COLUMN device_name VARCHAR(100) NOT NULL DEFAULT '' AFTER device_id,
+				ADD COLUMN user_agent VARCHAR(255) NOT NULL DEFAULT '' AFTER device_name,
+				ADD COLUMN ip_address VARCHAR(45) NOT NULL DEFAULT '' AFTER user_agent,
+				ADD COLUMN last_seen_at timestamp NULL DEFAULT NULL AFTER issued_at,
+				ADD INDEX idx_tokens_user_expired_at (user_id, expired_at);
+		`
+
+		err := db.Exec(sql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to add session device columns")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, addSessionDeviceColumnsMigration)
+}
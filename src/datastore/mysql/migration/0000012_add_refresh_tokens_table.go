@@ -0,0 +1,54 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var addRefreshTokensTableMigration = &Migration{
+	Number: 12,
+	Name:   "create refresh tokens table",
+	Forwards: func(db *gorm.DB) error {
+		sql := `
+			ALTER TABLE tokens
+				ADD COLUMN family_id VARCHAR(36) NOT NULL DEFAULT '' AFTER user_id,
+				ADD COLUMN session_expired_at timestamp NULL DEFAULT NULL AFTER expired_at,
+				ADD INDEX idx_tokens_family_id (family_id);
+		`
+		if err := db.Exec(sql).Error; err != nil {
+			return errors.Wrap(err, "unable to add session columns to tokens table")
+		}
+
+		// Sessions issued before refresh tokens end together with their access token
+		sql = `UPDATE tokens SET session_expired_at = expired_at;`
+		if err := db.Exec(sql).Error; err != nil {
+			return errors.Wrap(err, "unable to backfill session expiry")
+		}
+
+		sql = `ALTER TABLE tokens MODIFY COLUMN session_expired_at timestamp NOT NULL;`
+		if err := db.Exec(sql).Error; err != nil {
+			return errors.Wrap(err, "unable to make session expiry not null")
+		}
+
+		sql = `
+			CREATE TABLE IF NOT EXISTS refresh_tokens (
+				token_hash CHAR(64) NOT NULL,
+				family_id VARCHAR(36) NOT NULL,
+				user_id VARCHAR(50) NOT NULL,
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				expired_at timestamp NOT NULL,
+				used_at timestamp NULL DEFAULT NULL,
+				PRIMARY KEY (token_hash),
+				INDEX idx_rt_family_id (family_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+		`
+		if err := db.Exec(sql).Error; err != nil {
+			return errors.Wrap(err, "unable to create refresh tokens table")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, addRefreshTokensTableMigration)
+}
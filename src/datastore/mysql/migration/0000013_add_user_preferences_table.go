@@ -0,0 +1,54 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var addUserPreferencesTableMigration = &Migration{
+	Number: 13,
+	Name:   "create user preferences and profile audits tables",
+	Forwards: func(db *gorm.DB) error {
+		const preferencesSql = `
+			CREATE TABLE IF NOT EXISTS user_preferences (
+				user_id VARCHAR(50) NOT NULL,
+				language VARCHAR(10) NOT NULL DEFAULT 'en',
+				notify_push TINYINT(1) NOT NULL DEFAULT 1,
+				notify_email TINYINT(1) NOT NULL DEFAULT 1,
+				notify_sms TINYINT(1) NOT NULL DEFAULT 1,
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+				PRIMARY KEY (user_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+		`
+
+		err := db.Exec(preferencesSql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to create user preferences table")
+		}
+
+		const auditsSql = `
+			CREATE TABLE IF NOT EXISTS user_profile_audits (
+				id BIGINT NOT NULL AUTO_INCREMENT,
+				user_id VARCHAR(50) NOT NULL,
+				field VARCHAR(50) NOT NULL,
+				old_value TEXT,
+				new_value TEXT,
+				request_id VARCHAR(64) NOT NULL DEFAULT '',
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (id),
+				INDEX idx_user_profile_audits_user_created_at (user_id, created_at)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+		`
+
+		err = db.Exec(auditsSql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to create user profile audits table")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, addUserPreferencesTableMigration)
+}
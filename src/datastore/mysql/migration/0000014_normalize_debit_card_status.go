@@ -0,0 +1,27 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var normalizeDebitCardStatusMigration = &Migration{
+	Number: 14,
+	Name:   "normalize debit card status values",
+	Forwards: func(db *gorm.DB) error {
+		// The card status state machine works on lower case values, seed data has e.g. "Active"
+		const sql = `
+			UPDATE debit_card_status SET status = LOWER(TRIM(status)) WHERE status IS NOT NULL;
+		`
+
+		err := db.Exec(sql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to normalize debit card status")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, normalizeDebitCardStatusMigration)
+}
@@ -0,0 +1,54 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var addCardRevealTablesMigration = &Migration{
+	Number: 15,
+	Name:   "create card reveal tokens and audits tables",
+	Forwards: func(db *gorm.DB) error {
+		const tokensSql = `
+			CREATE TABLE IF NOT EXISTS card_reveal_tokens (
+				token_hash CHAR(64) NOT NULL,
+				user_id VARCHAR(50) NOT NULL,
+				card_id VARCHAR(50) NOT NULL,
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				expired_at timestamp NOT NULL,
+				used_at timestamp NULL DEFAULT NULL,
+				PRIMARY KEY (token_hash),
+				INDEX idx_card_reveal_tokens_expired_at (expired_at)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+		`
+
+		err := db.Exec(tokensSql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to create card reveal tokens table")
+		}
+
+		const auditsSql = `
+			CREATE TABLE IF NOT EXISTS card_reveal_audits (
+				id BIGINT NOT NULL AUTO_INCREMENT,
+				user_id VARCHAR(50) NOT NULL,
+				card_id VARCHAR(50) NOT NULL,
+				event VARCHAR(30) NOT NULL,
+				ip_address VARCHAR(45) NOT NULL DEFAULT '',
+				request_id VARCHAR(64) NOT NULL DEFAULT '',
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (id),
+				INDEX idx_card_reveal_audits_user_event_created_at (user_id, event, created_at)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+		`
+
+		err = db.Exec(auditsSql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to create card reveal audits table")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, addCardRevealTablesMigration)
+}
@@ -0,0 +1,40 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var addDebitCardControlsTableMigration = &Migration{
+	Number: 16,
+	Name:   "create debit card controls table",
+	Forwards: func(db *gorm.DB) error {
+		// Cards without a row use the default controls from config
+		const sql = `
+			CREATE TABLE IF NOT EXISTS debit_card_controls (
+				card_id VARCHAR(50) NOT NULL,
+				user_id VARCHAR(50) NOT NULL,
+				daily_limit DECIMAL(15,2) NOT NULL,
+				online_limit DECIMAL(15,2) NOT NULL,
+				atm_limit DECIMAL(15,2) NOT NULL,
+				contactless_enabled TINYINT(1) NOT NULL,
+				online_enabled TINYINT(1) NOT NULL,
+				overseas_enabled TINYINT(1) NOT NULL,
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+				PRIMARY KEY (card_id),
+				INDEX idx_debit_card_controls_user (user_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+		`
+
+		err := db.Exec(sql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to create debit card controls table")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, addDebitCardControlsTableMigration)
+}
@@ -0,0 +1,35 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var addSavedAccountsSurrogateKeyMigration = &Migration{
+	Number: 17,
+	Name:   "add saved accounts surrogate key",
+	Forwards: func(db *gorm.DB) error {
+		// user_id used to be the primary key which limited every user to a single saved account
+		const sql = `
+			ALTER TABLE saved_accounts
+				DROP PRIMARY KEY,
+				ADD COLUMN saved_account_id VARCHAR(50) NULL FIRST,
+				ADD COLUMN is_favorite TINYINT(1) NOT NULL DEFAULT 0 AFTER image;
+			UPDATE saved_accounts SET saved_account_id = UUID() WHERE saved_account_id IS NULL;
+			ALTER TABLE saved_accounts
+				MODIFY saved_account_id VARCHAR(50) NOT NULL,
+				ADD PRIMARY KEY (saved_account_id),
+				ADD UNIQUE INDEX uq_saved_accounts_user_number (user_id, account_number);
+		`
+
+		err := db.Exec(sql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to add saved accounts surrogate key")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, addSavedAccountsSurrogateKeyMigration)
+}
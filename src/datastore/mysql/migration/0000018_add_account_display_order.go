@@ -0,0 +1,33 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var addAccountDisplayOrderMigration = &Migration{
+	Number: 18,
+	Name:   "add account display order",
+	Forwards: func(db *gorm.DB) error {
+		// The initial order is the one get-user-accounts used so far: main account first, then by account id
+		const sql = `
+			ALTER TABLE account_details ADD COLUMN display_order INT NOT NULL DEFAULT 0 AFTER is_main_account;
+			UPDATE account_details ad
+			JOIN (
+				SELECT account_id, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY is_main_account DESC, account_id) AS position
+				FROM account_details
+			) ordered ON ordered.account_id = ad.account_id
+			SET ad.display_order = ordered.position;
+		`
+
+		err := db.Exec(sql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to add account display order")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, addAccountDisplayOrderMigration)
+}
@@ -0,0 +1,40 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var addSavingsGoalsTableMigration = &Migration{
+	Number: 19,
+	Name:   "create savings goals table",
+	Forwards: func(db *gorm.DB) error {
+		// Goal progress is computed from the account balance, account_details.progress is no longer read
+		const sql = `
+			CREATE TABLE IF NOT EXISTS savings_goals (
+				goal_id VARCHAR(50) NOT NULL,
+				account_id VARCHAR(50) NOT NULL,
+				user_id VARCHAR(50) NOT NULL,
+				name VARCHAR(100) NOT NULL,
+				target_amount DECIMAL(15,2) NOT NULL,
+				deadline DATE NOT NULL,
+				status VARCHAR(20) NOT NULL,
+				closed_at timestamp NULL,
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+				PRIMARY KEY (goal_id),
+				INDEX idx_savings_goals_user_account (user_id, account_id, status)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+		`
+
+		err := db.Exec(sql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to create savings goals table")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, addSavingsGoalsTableMigration)
+}
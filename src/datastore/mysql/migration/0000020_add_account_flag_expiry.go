@@ -0,0 +1,27 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var addAccountFlagExpiryMigration = &Migration{
+	Number: 20,
+	Name:   "add account flag expiry",
+	Forwards: func(db *gorm.DB) error {
+		// Flags without expiry stay until they are expired through the admin api
+		const sql = `
+			ALTER TABLE account_flags ADD COLUMN expired_at timestamp NULL AFTER flag_value;
+		`
+
+		err := db.Exec(sql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to add account flag expiry")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, addAccountFlagExpiryMigration)
+}
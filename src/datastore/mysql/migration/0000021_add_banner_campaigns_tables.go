@@ -0,0 +1,52 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var addBannerCampaignsTablesMigration = &Migration{
+	Number: 21,
+	Name:   "create banner campaigns tables",
+	Forwards: func(db *gorm.DB) error {
+		// banners keeps the legacy per-user rows, campaigns are resolved per user at request time
+		const campaignsSql = `
+			CREATE TABLE IF NOT EXISTS banner_campaigns (
+				campaign_id VARCHAR(50) NOT NULL,
+				title VARCHAR(255) NOT NULL,
+				description TEXT,
+				image VARCHAR(255),
+				priority INT NOT NULL DEFAULT 0,
+				starts_at timestamp NOT NULL,
+				ends_at timestamp NULL,
+				audience VARCHAR(20) NOT NULL,
+				account_type VARCHAR(50) NULL,
+				currency VARCHAR(10) NULL,
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+				PRIMARY KEY (campaign_id),
+				INDEX idx_banner_campaigns_window (starts_at, ends_at)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+		`
+		if err := db.Exec(campaignsSql).Error; err != nil {
+			return errors.Wrap(err, "unable to create banner campaigns table")
+		}
+
+		const usersSql = `
+			CREATE TABLE IF NOT EXISTS banner_campaign_users (
+				campaign_id VARCHAR(50) NOT NULL,
+				user_id VARCHAR(50) NOT NULL,
+				PRIMARY KEY (campaign_id, user_id),
+				INDEX idx_banner_campaign_users_user (user_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+		`
+		if err := db.Exec(usersSql).Error; err != nil {
+			return errors.Wrap(err, "unable to create banner campaign users table")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, addBannerCampaignsTablesMigration)
+}
@@ -0,0 +1,45 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var addBannerEventsTablesMigration = &Migration{
+	Number: 22,
+	Name:   "create banner events and dismissals tables",
+	Forwards: func(db *gorm.DB) error {
+		// banner_id is a legacy banner or a campaign id, events are append only and feed the admin report
+		const eventsSql = `
+			CREATE TABLE IF NOT EXISTS banner_events (
+				event_id BIGINT NOT NULL AUTO_INCREMENT,
+				banner_id VARCHAR(50) NOT NULL,
+				user_id VARCHAR(50) NOT NULL,
+				event_type VARCHAR(20) NOT NULL,
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (event_id),
+				INDEX idx_banner_events_created (created_at, banner_id, event_type)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+		`
+		if err := db.Exec(eventsSql).Error; err != nil {
+			return errors.Wrap(err, "unable to create banner events table")
+		}
+
+		const dismissalsSql = `
+			CREATE TABLE IF NOT EXISTS banner_dismissals (
+				user_id VARCHAR(50) NOT NULL,
+				banner_id VARCHAR(50) NOT NULL,
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (user_id, banner_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+		`
+		if err := db.Exec(dismissalsSql).Error; err != nil {
+			return errors.Wrap(err, "unable to create banner dismissals table")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, addBannerEventsTablesMigration)
+}
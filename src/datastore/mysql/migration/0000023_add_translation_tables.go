@@ -0,0 +1,43 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var addTranslationTablesMigration = &Migration{
+	Number: 23,
+	Name:   "create banner and greeting translation tables",
+	Forwards: func(db *gorm.DB) error {
+		// banners, banner_campaigns and user_greetings keep the text in the default language
+		const bannersSql = `
+			CREATE TABLE IF NOT EXISTS banner_translations (
+				banner_id VARCHAR(50) NOT NULL,
+				language VARCHAR(10) NOT NULL,
+				title VARCHAR(255) NOT NULL,
+				description TEXT,
+				PRIMARY KEY (banner_id, language)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+		`
+		if err := db.Exec(bannersSql).Error; err != nil {
+			return errors.Wrap(err, "unable to create banner translations table")
+		}
+
+		const greetingsSql = `
+			CREATE TABLE IF NOT EXISTS user_greeting_translations (
+				user_id VARCHAR(50) NOT NULL,
+				language VARCHAR(10) NOT NULL,
+				greeting TEXT,
+				PRIMARY KEY (user_id, language)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+		`
+		if err := db.Exec(greetingsSql).Error; err != nil {
+			return errors.Wrap(err, "unable to create user greeting translations table")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, addTranslationTablesMigration)
+}
@@ -0,0 +1,65 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"strings"
+)
+
+var addAccountDigitsAndPayeeLookupsMigration = &Migration{
+	Number: 24,
+	Name:   "add account digits and payee lookups",
+	Forwards: func(db *gorm.DB) error {
+		// The unique index below cannot be added while two accounts share the same digits, those have to be fixed by
+		// hand first so the migration stops with the numbers instead of a bare duplicate key error
+		const duplicatesSql = `
+			SELECT REPLACE(REPLACE(account_number, '-', ''), ' ', '') AS digits
+			FROM accounts
+			GROUP BY digits
+			HAVING COUNT(*) > 1
+			LIMIT 10;
+		`
+
+		var duplicates []string
+		err := db.Raw(duplicatesSql).Scan(&duplicates).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to check account numbers for duplicates")
+		}
+		if len(duplicates) > 0 {
+			return errors.Errorf("account numbers %s are used by more than one account, make them unique before migrating", strings.Join(duplicates, ", "))
+		}
+
+		// Account numbers are looked up without separators, the digits are kept in their own column so the lookup can
+		// use an index. The index is unique so a number always resolves to one account.
+		const digitsSql = `
+			ALTER TABLE accounts
+				ADD COLUMN account_digits VARCHAR(20) GENERATED ALWAYS AS (REPLACE(REPLACE(account_number, '-', ''), ' ', '')) STORED AFTER account_number,
+				ADD UNIQUE INDEX idx_accounts_account_digits (account_digits);
+		`
+
+		err = db.Exec(digitsSql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to add account digits")
+		}
+
+		const lookupsSql = `
+			CREATE TABLE IF NOT EXISTS payee_lookups (
+				id BIGINT NOT NULL AUTO_INCREMENT,
+				user_id VARCHAR(50) NOT NULL,
+				created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (id),
+				INDEX idx_payee_lookups_user_created_at (user_id, created_at)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+		`
+
+		err = db.Exec(lookupsSql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to create payee lookups table")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, addAccountDigitsAndPayeeLookupsMigration)
+}
@@ -0,0 +1,27 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var addIdempotencyKeyLanguageMigration = &Migration{
+	Number: 25,
+	Name:   "add language to idempotency keys table",
+	Forwards: func(db *gorm.DB) error {
+		const sql = `
+			ALTER TABLE idempotency_keys
+				ADD COLUMN language VARCHAR(10) NOT NULL DEFAULT '' AFTER request_hash;
+		`
+
+		err := db.Exec(sql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to add idempotency key language")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, addIdempotencyKeyLanguageMigration)
+}
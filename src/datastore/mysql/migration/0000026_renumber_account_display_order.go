@@ -0,0 +1,32 @@
+package migration
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var renumberAccountDisplayOrderMigration = &Migration{
+	Number: 26,
+	Name:   "renumber account display order",
+	Forwards: func(db *gorm.DB) error {
+		// Accounts added since the display order was introduced still have 0, they go after the ordered ones
+		const renumberSql = `
+			UPDATE account_details ad
+			JOIN (
+				SELECT account_id, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY display_order = 0, display_order, account_id) AS position
+				FROM account_details
+			) ordered ON ordered.account_id = ad.account_id
+			SET ad.display_order = ordered.position;
+		`
+
+		err := db.Exec(renumberSql).Error
+		if err != nil {
+			return errors.Wrap(err, "unable to renumber account display order")
+		}
+		return nil
+	},
+}
+
+func init() {
+	Migrations = append(Migrations, renumberAccountDisplayOrderMigration)
+}
@@ -31,6 +31,7 @@ type AccountDetails struct {
 	UserId        string `json:"user_id" gorm:"column:user_id; type:VARCHAR(50)"`
 	Color         string `json:"color" gorm:"column:color; type:VARCHAR(10)"`
 	IsMainAccount bool   `json:"is_main_account" gorm:"column:is_main_account; type:TINYINT(1)"`
+	DisplayOrder  int    `json:"display_order" gorm:"column:display_order; type:INT; not null"`
 	Progress      int    `json:"progress" gorm:"column:progress; type:INT"`
 	DummyCol5     string `json:"dummy_col_5" gorm:"column:dummy_col_5; type:VARCHAR(255)"`
 }
@@ -38,23 +39,50 @@ type AccountDetails struct {
 func (AccountDetails) TableName() string { return "account_details" }
 
 type AccountFlags struct {
-	FlagId    int       `json:"flag_id" gorm:"column:flag_id; type:INT; primaryKey; autoIncrement"`
-	AccountId string    `json:"account_id" gorm:"column:account_id; type:VARCHAR(50); not null"`
-	UserId    string    `json:"user_id" gorm:"column:user_id; type:VARCHAR(50); not null"`
-	FlagType  string    `json:"flag_type" gorm:"column:flag_type; type:VARCHAR(50); not null"`
-	FlagValue string    `json:"flag_value" gorm:"column:flag_value; type:VARCHAR(30); not null"`
-	CreatedAt time.Time `json:"created_at" gorm:"<-:create; column:created_at; autoCreateTime"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at; autoUpdateTime"`
+	FlagId    int        `json:"flag_id" gorm:"column:flag_id; type:INT; primaryKey; autoIncrement"`
+	AccountId string     `json:"account_id" gorm:"column:account_id; type:VARCHAR(50); not null"`
+	UserId    string     `json:"user_id" gorm:"column:user_id; type:VARCHAR(50); not null"`
+	FlagType  string     `json:"flag_type" gorm:"column:flag_type; type:VARCHAR(50); not null"`
+	FlagValue string     `json:"flag_value" gorm:"column:flag_value; type:VARCHAR(30); not null"`
+	ExpiredAt *time.Time `json:"expired_at" gorm:"column:expired_at"`
+	CreatedAt time.Time  `json:"created_at" gorm:"<-:create; column:created_at; autoCreateTime"`
+	UpdatedAt time.Time  `json:"updated_at" gorm:"column:updated_at; autoUpdateTime"`
 }
 
 func (AccountFlags) TableName() string { return "account_flags" }
 
 type SavedAccounts struct {
-	UserId        string    `json:"user_id" gorm:"column:user_id; type:VARCHAR(50); primaryKey"`
-	AccountName   string    `json:"account_name" gorm:"column:account_name; type:VARCHAR(100)"`
-	AccountNumber string    `json:"account_number" gorm:"column:account_number; type:VARCHAR(20)"`
-	CreatedAt     time.Time `json:"created_at" gorm:"<-:create; column:created_at; autoCreateTime"`
-	UpdatedAt     time.Time `json:"updated_at" gorm:"column:updated_at; autoUpdateTime"`
+	SavedAccountId string    `json:"saved_account_id" gorm:"column:saved_account_id; type:VARCHAR(50); primaryKey"`
+	UserId         string    `json:"user_id" gorm:"column:user_id; type:VARCHAR(50); not null"`
+	AccountName    string    `json:"account_name" gorm:"column:account_name; type:VARCHAR(100)"`
+	AccountNumber  string    `json:"account_number" gorm:"column:account_number; type:VARCHAR(20)"`
+	Image          string    `json:"image" gorm:"column:image; type:VARCHAR(255)"`
+	IsFavorite     bool      `json:"is_favorite" gorm:"column:is_favorite; type:TINYINT(1); not null"`
+	CreatedAt      time.Time `json:"created_at" gorm:"<-:create; column:created_at; autoCreateTime"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"column:updated_at; autoUpdateTime"`
 }
 
 func (SavedAccounts) TableName() string { return "saved_accounts" }
+
+type PayeeLookups struct {
+	Id        int64     `json:"id" gorm:"column:id; primaryKey; autoIncrement"`
+	UserId    string    `json:"user_id" gorm:"column:user_id; type:VARCHAR(50); not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at; not null"`
+}
+
+func (PayeeLookups) TableName() string { return "payee_lookups" }
+
+type SavingsGoals struct {
+	GoalId       string          `json:"goal_id" gorm:"column:goal_id; type:VARCHAR(50); primaryKey"`
+	AccountId    string          `json:"account_id" gorm:"column:account_id; type:VARCHAR(50); not null"`
+	UserId       string          `json:"user_id" gorm:"column:user_id; type:VARCHAR(50); not null"`
+	Name         string          `json:"name" gorm:"column:name; type:VARCHAR(100); not null"`
+	TargetAmount decimal.Decimal `json:"target_amount" gorm:"column:target_amount; type:DECIMAL(15,2); not null"`
+	Deadline     time.Time       `json:"deadline" gorm:"column:deadline; type:DATE; not null"`
+	Status       string          `json:"status" gorm:"column:status; type:VARCHAR(20); not null"`
+	ClosedAt     *time.Time      `json:"closed_at" gorm:"column:closed_at"`
+	CreatedAt    time.Time       `json:"created_at" gorm:"<-:create; column:created_at; autoCreateTime"`
+	UpdatedAt    time.Time       `json:"updated_at" gorm:"column:updated_at; autoUpdateTime"`
+}
+
+func (SavingsGoals) TableName() string { return "savings_goals" }
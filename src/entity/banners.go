@@ -1,5 +1,7 @@
 package entity
 
+import "time"
+
 type Banners struct {
 	BannerId    string `json:"banner_id" gorm:"column:banner_id; type:VARCHAR(50); primaryKey"`
 	UserId      string `json:"-" gorm:"column:user_id; type:VARCHAR(50)"`
@@ -10,3 +12,55 @@ type Banners struct {
 }
 
 func (Banners) TableName() string { return "banners" }
+
+type BannerCampaigns struct {
+	CampaignId  string     `json:"campaign_id" gorm:"column:campaign_id; type:VARCHAR(50); primaryKey"`
+	Title       string     `json:"title" gorm:"column:title; type:VARCHAR(255); not null"`
+	Description string     `json:"description" gorm:"column:description; type:text"`
+	Image       string     `json:"image" gorm:"column:image; type:VARCHAR(255)"`
+	Priority    int        `json:"priority" gorm:"column:priority; type:INT; not null"`
+	StartsAt    time.Time  `json:"starts_at" gorm:"column:starts_at; not null"`
+	EndsAt      *time.Time `json:"ends_at" gorm:"column:ends_at"`
+	Audience    string     `json:"audience" gorm:"column:audience; type:VARCHAR(20); not null"`
+	AccountType *string    `json:"account_type" gorm:"column:account_type; type:VARCHAR(50)"`
+	Currency    *string    `json:"currency" gorm:"column:currency; type:VARCHAR(10)"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"<-:create; column:created_at; autoCreateTime"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"column:updated_at; autoUpdateTime"`
+}
+
+func (BannerCampaigns) TableName() string { return "banner_campaigns" }
+
+type BannerCampaignUsers struct {
+	CampaignId string `json:"campaign_id" gorm:"column:campaign_id; type:VARCHAR(50); primaryKey"`
+	UserId     string `json:"user_id" gorm:"column:user_id; type:VARCHAR(50); primaryKey"`
+}
+
+func (BannerCampaignUsers) TableName() string { return "banner_campaign_users" }
+
+type BannerEvents struct {
+	EventId   int64     `json:"event_id" gorm:"column:event_id; type:BIGINT; primaryKey; autoIncrement"`
+	BannerId  string    `json:"banner_id" gorm:"column:banner_id; type:VARCHAR(50); not null"`
+	UserId    string    `json:"user_id" gorm:"column:user_id; type:VARCHAR(50); not null"`
+	EventType string    `json:"event_type" gorm:"column:event_type; type:VARCHAR(20); not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"<-:create; column:created_at"`
+}
+
+func (BannerEvents) TableName() string { return "banner_events" }
+
+type BannerDismissals struct {
+	UserId    string    `json:"user_id" gorm:"column:user_id; type:VARCHAR(50); primaryKey"`
+	BannerId  string    `json:"banner_id" gorm:"column:banner_id; type:VARCHAR(50); primaryKey"`
+	CreatedAt time.Time `json:"created_at" gorm:"<-:create; column:created_at; autoCreateTime"`
+}
+
+func (BannerDismissals) TableName() string { return "banner_dismissals" }
+
+// BannerTranslations holds the title and description of a banner or campaign in another language than the default
+type BannerTranslations struct {
+	BannerId    string `json:"banner_id" gorm:"column:banner_id; type:VARCHAR(50); primaryKey"`
+	Language    string `json:"language" gorm:"column:language; type:VARCHAR(10); primaryKey"`
+	Title       string `json:"title" gorm:"column:title; type:VARCHAR(255); not null"`
+	Description string `json:"description" gorm:"column:description; type:text"`
+}
+
+func (BannerTranslations) TableName() string { return "banner_translations" }
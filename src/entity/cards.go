@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+type DebitCards struct {
+	CardId    string `json:"card_id" gorm:"column:card_id; type:VARCHAR(50); primaryKey"`
+	UserId    string `json:"user_id" gorm:"column:user_id; type:VARCHAR(50)"`
+	Name      string `json:"name" gorm:"column:name; type:VARCHAR(100)"`
+	DummyCol7 string `json:"dummy_col_7" gorm:"column:dummy_col_7; type:VARCHAR(255)"`
+}
+
+func (DebitCards) TableName() string { return "debit_cards" }
+
+type DebitCardDesign struct {
+	CardId      string `json:"card_id" gorm:"column:card_id; type:VARCHAR(50); primaryKey"`
+	UserId      string `json:"user_id" gorm:"column:user_id; type:VARCHAR(50)"`
+	Color       string `json:"color" gorm:"column:color; type:VARCHAR(10)"`
+	BorderColor string `json:"border_color" gorm:"column:border_color; type:VARCHAR(10)"`
+	DummyCol9   string `json:"dummy_col_9" gorm:"column:dummy_col_9; type:VARCHAR(255)"`
+}
+
+func (DebitCardDesign) TableName() string { return "debit_card_design" }
+
+type DebitCardStatus struct {
+	CardId    string `json:"card_id" gorm:"column:card_id; type:VARCHAR(50); primaryKey"`
+	UserId    string `json:"user_id" gorm:"column:user_id; type:VARCHAR(50)"`
+	Status    string `json:"status" gorm:"column:status; type:VARCHAR(20)"`
+	DummyCol8 string `json:"dummy_col_8" gorm:"column:dummy_col_8; type:VARCHAR(255)"`
+}
+
+func (DebitCardStatus) TableName() string { return "debit_card_status" }
+
+type CardRevealTokens struct {
+	TokenHash string     `json:"-" gorm:"column:token_hash; type:CHAR(64); primaryKey"`
+	UserId    string     `json:"user_id" gorm:"column:user_id; type:VARCHAR(50); not null"`
+	CardId    string     `json:"card_id" gorm:"column:card_id; type:VARCHAR(50); not null"`
+	CreatedAt time.Time  `json:"created_at" gorm:"column:created_at; not null"`
+	ExpiredAt time.Time  `json:"expired_at" gorm:"column:expired_at; not null"`
+	UsedAt    *time.Time `json:"used_at" gorm:"column:used_at"`
+}
+
+func (CardRevealTokens) TableName() string { return "card_reveal_tokens" }
+
+type CardRevealAudits struct {
+	Id        int64     `json:"id" gorm:"column:id; primaryKey; autoIncrement"`
+	UserId    string    `json:"user_id" gorm:"column:user_id; type:VARCHAR(50); not null"`
+	CardId    string    `json:"card_id" gorm:"column:card_id; type:VARCHAR(50); not null"`
+	Event     string    `json:"event" gorm:"column:event; type:VARCHAR(30); not null"`
+	IpAddress string    `json:"ip_address" gorm:"column:ip_address; type:VARCHAR(45); not null"`
+	RequestId string    `json:"request_id" gorm:"column:request_id; type:VARCHAR(64); not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at; not null"`
+}
+
+func (CardRevealAudits) TableName() string { return "card_reveal_audits" }
+
+type DebitCardControls struct {
+	CardId             string          `json:"card_id" gorm:"column:card_id; type:VARCHAR(50); primaryKey"`
+	UserId             string          `json:"-" gorm:"column:user_id; type:VARCHAR(50); not null"`
+	DailyLimit         decimal.Decimal `json:"daily_limit" gorm:"column:daily_limit; type:DECIMAL(15,2); not null"`
+	OnlineLimit        decimal.Decimal `json:"online_limit" gorm:"column:online_limit; type:DECIMAL(15,2); not null"`
+	AtmLimit           decimal.Decimal `json:"atm_limit" gorm:"column:atm_limit; type:DECIMAL(15,2); not null"`
+	ContactlessEnabled bool            `json:"contactless_enabled" gorm:"column:contactless_enabled; not null"`
+	OnlineEnabled      bool            `json:"online_enabled" gorm:"column:online_enabled; not null"`
+	OverseasEnabled    bool            `json:"overseas_enabled" gorm:"column:overseas_enabled; not null"`
+	CreatedAt          time.Time       `json:"-" gorm:"<-:create; column:created_at; not null; autoCreateTime"`
+	UpdatedAt          time.Time       `json:"updated_at" gorm:"column:updated_at; not null; autoUpdateTime"`
+}
+
+func (DebitCardControls) TableName() string { return "debit_card_controls" }
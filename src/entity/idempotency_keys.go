@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+type IdempotencyKeys struct {
+	Scope          string    `json:"scope" gorm:"column:scope; type:VARCHAR(50); primaryKey"`
+	IdempotencyKey string    `json:"idempotency_key" gorm:"column:idempotency_key; type:VARCHAR(255); primaryKey"`
+	RequestHash    string    `json:"request_hash" gorm:"column:request_hash; type:CHAR(64); not null"`
+	Language       string    `json:"language" gorm:"column:language; type:VARCHAR(10); not null"`
+	StatusCode     int       `json:"status_code" gorm:"column:status_code; type:INT; not null"`
+	ResponseBody   string    `json:"response_body" gorm:"column:response_body; type:MEDIUMTEXT"`
+	CreatedAt      time.Time `json:"created_at" gorm:"<-:create; column:created_at; autoCreateTime"`
+	ExpiredAt      time.Time `json:"expired_at" gorm:"column:expired_at; not null"`
+}
+
+func (IdempotencyKeys) TableName() string { return "idempotency_keys" }
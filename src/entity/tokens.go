@@ -2,11 +2,33 @@ package entity
 
 import "time"
 
+// Tokens is one login session. The access token of a session is rotated on every refresh, so SessionId
+// changes while FamilyId stays the same for the life of the session.
 type Tokens struct {
-	SessionId string    `json:"session_id" gorm:"column:session_id; type:VARCHAR(255); primaryKey"`
-	UserId    string    `json:"user_id" gorm:"column:user_id; type:VARCHAR(50)"`
-	IssuedAt  time.Time `json:"issued_at" gorm:"<-:create; column:issued_at; not null; autoCreateTime"`
-	ExpiredAt time.Time `json:"expired_at"`
+	// SessionId holds the SHA-256 of the access token, never the token itself
+	SessionId  string     `json:"session_id" gorm:"column:session_id; type:VARCHAR(255); primaryKey"`
+	UserId     string     `json:"-" gorm:"column:user_id; type:VARCHAR(50)"`
+	FamilyId   string     `json:"-" gorm:"column:family_id; type:VARCHAR(36)"`
+	DeviceId   string     `json:"device_id" gorm:"column:device_id; type:VARCHAR(100)"`
+	DeviceName string     `json:"device_name" gorm:"column:device_name; type:VARCHAR(100)"`
+	UserAgent  string     `json:"user_agent" gorm:"column:user_agent; type:VARCHAR(255)"`
+	IpAddress  string     `json:"ip_address" gorm:"column:ip_address; type:VARCHAR(45)"`
+	IssuedAt   time.Time  `json:"issued_at" gorm:"<-:create; column:issued_at; not null; autoCreateTime"`
+	LastSeenAt *time.Time `json:"last_seen_at" gorm:"column:last_seen_at"`
+	// ExpiredAt ends the access token, SessionExpiredAt ends the session and with it the refresh token
+	ExpiredAt        time.Time `json:"expired_at"`
+	SessionExpiredAt time.Time `json:"session_expired_at" gorm:"column:session_expired_at; not null"`
 }
 
 func (Tokens) TableName() string { return "tokens" }
+
+type RefreshTokens struct {
+	TokenHash string     `json:"-" gorm:"column:token_hash; type:CHAR(64); primaryKey"`
+	FamilyId  string     `json:"family_id" gorm:"column:family_id; type:VARCHAR(36); not null"`
+	UserId    string     `json:"-" gorm:"column:user_id; type:VARCHAR(50); not null"`
+	CreatedAt time.Time  `json:"created_at" gorm:"<-:create; column:created_at; not null; autoCreateTime"`
+	ExpiredAt time.Time  `json:"expired_at" gorm:"column:expired_at; not null"`
+	UsedAt    *time.Time `json:"used_at" gorm:"column:used_at"`
+}
+
+func (RefreshTokens) TableName() string { return "refresh_tokens" }
@@ -0,0 +1,12 @@
+package entity
+
+type Transactions struct {
+	TransactionId string `json:"transaction_id" gorm:"column:transaction_id; type:VARCHAR(50); primaryKey"`
+	UserId        string `json:"-" gorm:"column:user_id; type:VARCHAR(50)"`
+	Name          string `json:"name" gorm:"column:name; type:VARCHAR(100)"`
+	Image         string `json:"image" gorm:"column:image; type:VARCHAR(255)"`
+	IsBank        bool   `json:"is_bank" gorm:"column:isBank; type:TINYINT(1)"`
+	DummyCol6     string `json:"-" gorm:"column:dummy_col_6; type:VARCHAR(255)"`
+}
+
+func (Transactions) TableName() string { return "transactions" }
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+const (
+	LedgerEntryDebit  = "DEBIT"
+	LedgerEntryCredit = "CREDIT"
+)
+
+type Transfers struct {
+	TransferId      string          `json:"transfer_id" gorm:"column:transfer_id; type:VARCHAR(50); primaryKey"`
+	UserId          string          `json:"-" gorm:"column:user_id; type:VARCHAR(50)"`
+	FromAccountId   string          `json:"from_account_id" gorm:"column:from_account_id; type:VARCHAR(50)"`
+	ToAccountId     string          `json:"to_account_id" gorm:"column:to_account_id; type:VARCHAR(50)"`
+	ToAccountNumber string          `json:"to_account_number" gorm:"column:to_account_number; type:VARCHAR(20)"`
+	Amount          decimal.Decimal `json:"amount" gorm:"column:amount; type:DECIMAL(15,2)"`
+	Currency        string          `json:"currency" gorm:"column:currency; type:VARCHAR(10)"`
+	Note            string          `json:"note" gorm:"column:note; type:VARCHAR(255)"`
+	CreatedAt       time.Time       `json:"created_at" gorm:"<-:create; column:created_at; autoCreateTime"`
+}
+
+func (Transfers) TableName() string { return "transfers" }
+
+// LedgerEntries holds one side of a transfer. Every transfer writes exactly one DEBIT and one CREDIT
+// of the same amount so that the ledger always balances. AccountId is empty when the credited side is
+// an account outside this service.
+type LedgerEntries struct {
+	EntryId       int             `json:"entry_id" gorm:"column:entry_id; type:INT; primaryKey; autoIncrement"`
+	TransferId    string          `json:"transfer_id" gorm:"column:transfer_id; type:VARCHAR(50); not null"`
+	AccountId     string          `json:"account_id" gorm:"column:account_id; type:VARCHAR(50)"`
+	AccountNumber string          `json:"account_number" gorm:"column:account_number; type:VARCHAR(20); not null"`
+	EntryType     string          `json:"entry_type" gorm:"column:entry_type; type:VARCHAR(10); not null"`
+	Amount        decimal.Decimal `json:"amount" gorm:"column:amount; type:DECIMAL(15,2); not null"`
+	Currency      string          `json:"currency" gorm:"column:currency; type:VARCHAR(10); not null"`
+	CreatedAt     time.Time       `json:"created_at" gorm:"<-:create; column:created_at; autoCreateTime"`
+}
+
+func (LedgerEntries) TableName() string { return "ledger_entries" }
@@ -3,10 +3,13 @@ package entity
 import "time"
 
 type UserPin struct {
-	UserId    string    `json:"user_id" gorm:"column:user_id; type:VARCHAR(50); primaryKey"`
-	Pin       string    `json:"pin" gorm:"column:pin; type:VARCHAR(255)"`
-	CreatedAt time.Time `json:"created_at" gorm:"<-:create; column:created_at; not null; autoCreateTime"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"column:updated_at; not null; autoUpdateTime"`
+	UserId         string     `json:"user_id" gorm:"column:user_id; type:VARCHAR(50); primaryKey"`
+	Pin            string     `json:"pin" gorm:"column:pin; type:VARCHAR(255)"`
+	FailedAttempts int        `json:"failed_attempts" gorm:"column:failed_attempts; type:INT; not null"`
+	LockoutCount   int        `json:"lockout_count" gorm:"column:lockout_count; type:INT; not null"`
+	LockedUntil    *time.Time `json:"locked_until" gorm:"column:locked_until"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"<-:create; column:created_at; not null; autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"column:updated_at; not null; autoUpdateTime"`
 }
 
 func (UserPin) TableName() string { return "user_pin" }
@@ -26,3 +29,46 @@ type UserGreetings struct {
 }
 
 func (UserGreetings) TableName() string { return "user_greetings" }
+
+// UserGreetingTranslations holds the greeting of a user in another language than the default
+type UserGreetingTranslations struct {
+	UserId   string `json:"user_id" gorm:"column:user_id; type:VARCHAR(50); primaryKey"`
+	Language string `json:"language" gorm:"column:language; type:VARCHAR(10); primaryKey"`
+	Greeting string `json:"greeting" gorm:"column:greeting; type:text"`
+}
+
+func (UserGreetingTranslations) TableName() string { return "user_greeting_translations" }
+
+type PinResetCodes struct {
+	UserId         string    `json:"user_id" gorm:"column:user_id; type:VARCHAR(50); primaryKey"`
+	CodeHash       string    `json:"-" gorm:"column:code_hash; type:VARCHAR(255); not null"`
+	FailedAttempts int       `json:"failed_attempts" gorm:"column:failed_attempts; type:INT; not null"`
+	CreatedAt      time.Time `json:"created_at" gorm:"column:created_at; not null"`
+	ExpiredAt      time.Time `json:"expired_at" gorm:"column:expired_at; not null"`
+}
+
+func (PinResetCodes) TableName() string { return "pin_reset_codes" }
+
+type UserPreferences struct {
+	UserId      string    `json:"user_id" gorm:"column:user_id; type:VARCHAR(50); primaryKey"`
+	Language    string    `json:"language" gorm:"column:language; type:VARCHAR(10); not null"`
+	NotifyPush  bool      `json:"notify_push" gorm:"column:notify_push; not null"`
+	NotifyEmail bool      `json:"notify_email" gorm:"column:notify_email; not null"`
+	NotifySms   bool      `json:"notify_sms" gorm:"column:notify_sms; not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"<-:create; column:created_at; not null; autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"column:updated_at; not null; autoUpdateTime"`
+}
+
+func (UserPreferences) TableName() string { return "user_preferences" }
+
+type UserProfileAudits struct {
+	Id        int64     `json:"id" gorm:"column:id; primaryKey; autoIncrement"`
+	UserId    string    `json:"user_id" gorm:"column:user_id; type:VARCHAR(50); not null"`
+	Field     string    `json:"field" gorm:"column:field; type:VARCHAR(50); not null"`
+	OldValue  string    `json:"old_value" gorm:"column:old_value; type:text"`
+	NewValue  string    `json:"new_value" gorm:"column:new_value; type:text"`
+	RequestId string    `json:"request_id" gorm:"column:request_id; type:VARCHAR(64); not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at; not null"`
+}
+
+func (UserProfileAudits) TableName() string { return "user_profile_audits" }
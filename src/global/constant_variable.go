@@ -6,18 +6,31 @@ const (
 	BASE_SERVICE_NAME       = "Assignment"
 	BASE_SERVICE_SHORT_NAME = "assignment"
 
-	HEADER_REQUEST_ID = "Request-Id"
-	KEY_REQUEST_ID    = "request_id"
-	KEY_USER_ID       = "user_id"
-	KEY_LOGGER        = "logger"
-	KEY_PART          = "part"
+	HEADER_REQUEST_ID          = "Request-Id"
+	HEADER_IDEMPOTENCY_KEY     = "Idempotency-Key"
+	HEADER_IDEMPOTENT_REPLAYED = "Idempotent-Replayed"
+	HEADER_ADMIN_KEY           = "Admin-Key"
+	HEADER_ACCEPT_LANGUAGE     = "Accept-Language"
+	HEADER_CONTENT_LANGUAGE    = "Content-Language"
+
+	KEY_REQUEST_ID = "request_id"
+	KEY_USER_ID    = "user_id"
+	KEY_SESSION_ID = "session_id"
+	KEY_LOGGER     = "logger"
+	KEY_PART       = "part"
+	KEY_LANGUAGE   = "language"
 
 	PART_INTERFACE  = "interface"
 	PART_CONTROLLER = "controller"
 	PART_MODEL      = "model"
 
-	METHOD_GET  = "GET"
-	METHOD_POST = "POST"
+	METHOD_GET    = "GET"
+	METHOD_POST   = "POST"
+	METHOD_PUT    = "PUT"
+	METHOD_DELETE = "DELETE"
+
+	AUTH_MODE_DB  = "db"
+	AUTH_MODE_JWT = "jwt"
 
 	RESULT_SUCCESS = "success"
 )
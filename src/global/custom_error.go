@@ -1,8 +1,29 @@
 package global
 
 type SystemError struct {
-	Code    int64  `json:"code"`
-	Message string `json:"message"`
+	Code    int64         `json:"code"`
+	Message string        `json:"message"`
+	Args    []interface{} `json:"-"`
+	// Raw is set when Message is not from the catalogue, e.g. the text of a database error, so it cannot be translated
+	Raw bool `json:"-"`
+}
+
+// NewSystemError builds the error from the message catalogue and keeps the args so the message can be translated
+func NewSystemError(code int64, args ...interface{}) SystemError {
+	return SystemError{
+		Code:    code,
+		Message: GetErrorMessage(code, args...),
+		Args:    args,
+	}
+}
+
+// NewRawSystemError keeps a message that is not from the catalogue as it is, it is never translated
+func NewRawSystemError(code int64, message string) SystemError {
+	return SystemError{
+		Code:    code,
+		Message: message,
+		Raw:     true,
+	}
 }
 
 func (system SystemError) Error() string {
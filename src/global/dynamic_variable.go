@@ -1,18 +1,280 @@
 package global
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"assignment/accountnumber"
+	"assignment/locale"
 	"assignment/logger"
+	"assignment/util"
+	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
 	"github.com/spf13/viper"
 )
 
 var TimeZone string
 
+// DefaultLanguage is used until a user picks a language
+var DefaultLanguage = "en"
+
+// SupportedLanguages lists the languages a user may pick as preferred language
+var SupportedLanguages = []string{"en", "th"}
+
+// LanguageFallbacks lists the languages to try, in order, when a text is missing in a language. DefaultLanguage is
+// always tried last.
+var LanguageFallbacks = map[string][]string{"th": {"en"}}
+
+// LanguageChain is the fallback chain of the language, see locale.Chain
+func LanguageChain(language string) []string {
+	return locale.Chain(language, LanguageFallbacks, DefaultLanguage)
+}
+
+// EnableGetUserById keeps the deprecated public get-user-by-id API available, superseded by /me
+var EnableGetUserById = false
+
+// AuthMode selects how access tokens are issued and verified, AUTH_MODE_DB or AUTH_MODE_JWT
+var AuthMode = AUTH_MODE_DB
+
+// Pin lockout policy, overridable from config
+var (
+	PinMaxAttempts  = 5
+	PinBaseLockTime = 5 * time.Minute
+	PinMaxLockTime  = 24 * time.Hour
+)
+
+// Pin reset code policy, overridable from config
+var (
+	PinResetCodeExpireTime  = 10 * time.Minute
+	PinResetCodeResendTime  = time.Minute
+	PinResetCodeMaxAttempts = 5
+)
+
+// Session policy, overridable from config. MaxConcurrentSessions 0 means unlimited.
+var (
+	MaxConcurrentSessions = 5
+	AccessTokenLifetime   = 15 * time.Minute
+	RefreshTokenLifetime  = 30 * 24 * time.Hour
+)
+
+// PayeeLookupMaxPerHour limits payee lookups per user so owner names cannot be enumerated, overridable from config
+var PayeeLookupMaxPerHour = 30
+
+// Card reveal policy, overridable from config. CardRevealMaxPerHour limits pin step-ups per user.
+var (
+	CardRevealTokenLifetime = time.Minute
+	CardRevealMaxPerHour    = 5
+	CardSecretPeriod        = 5 * time.Minute
+	CardSecretKey           []byte
+)
+
+// Debit card controls of cards the user never configured, overridable from config. CardMaxLimit is the highest
+// limit a user may set.
+var (
+	CardDefaultDailyLimit         = decimal.NewFromInt(50000)
+	CardDefaultOnlineLimit        = decimal.NewFromInt(20000)
+	CardDefaultAtmLimit           = decimal.NewFromInt(20000)
+	CardDefaultContactlessEnabled = true
+	CardDefaultOnlineEnabled      = true
+	CardDefaultOverseasEnabled    = false
+	CardMaxLimit                  = decimal.NewFromInt(200000)
+)
+
+// AppearancePalette lists the colors users may pick for accounts and cards, overridable from config
+var AppearancePalette = []string{
+	"#24c875", "#00a1e2", "#ffffff", "#000000", "#ff6b6b", "#ffa94d",
+	"#ffd43b", "#845ef7", "#f783ac", "#495057", "#1864ab", "#2b8a3e",
+}
+
+// Banner event buffering, overridable from config. Events are written once BannerEventBatchSize are queued or
+// BannerEventFlushInterval has passed, events beyond BannerEventBufferSize are dropped.
+var (
+	BannerEventBufferSize    = 10000
+	BannerEventBatchSize     = 500
+	BannerEventFlushInterval = 5 * time.Second
+)
+
+// HomeSectionTimeout bounds each section of the home screen on its own, a slow section is reported as timed out
+// while the others are still returned
+var HomeSectionTimeout = 2 * time.Second
+
+// AccountFlagTypes is the registry of flag types that can be put on an account, each with the validator tag its
+// flag_value must pass. Overridable from config.
+var AccountFlagTypes = map[string]string{
+	"system": "alphanum,max=30",
+	"badge":  "oneof=new popular recommended",
+	"promo":  "printascii,max=30",
+}
+
 func InitVariable() {
 	TimeZone = viper.GetString("System.TimeZone")
 	if TimeZone == "" {
 		logger.Logger.Errorf("TimeZone variable is not config")
 		os.Exit(1)
 	}
+
+	if language := viper.GetString("System.DefaultLanguage"); language != "" {
+		DefaultLanguage = language
+	}
+	if languages := viper.GetStringSlice("System.SupportedLanguages"); len(languages) > 0 {
+		SupportedLanguages = languages
+	}
+	if viper.IsSet("System.LanguageFallbacks") {
+		LanguageFallbacks = viper.GetStringMapStringSlice("System.LanguageFallbacks")
+	}
+	EnableGetUserById = viper.GetBool("Deprecation.EnableGetUserById")
+
+	if authMode := viper.GetString("Auth.Mode"); authMode != "" {
+		if authMode != AUTH_MODE_DB && authMode != AUTH_MODE_JWT {
+			logger.Logger.Errorf("Auth.Mode %q is not supported", authMode)
+			os.Exit(1)
+		}
+		AuthMode = authMode
+	}
+
+	if maxAttempts := viper.GetInt("PinLockout.MaxAttempts"); maxAttempts > 0 {
+		PinMaxAttempts = maxAttempts
+	}
+	if baseLockMinutes := viper.GetInt("PinLockout.BaseLockMinutes"); baseLockMinutes > 0 {
+		PinBaseLockTime = time.Duration(baseLockMinutes) * time.Minute
+	}
+	if maxLockMinutes := viper.GetInt("PinLockout.MaxLockMinutes"); maxLockMinutes > 0 {
+		PinMaxLockTime = time.Duration(maxLockMinutes) * time.Minute
+	}
+
+	if expireMinutes := viper.GetInt("PinReset.CodeExpireMinutes"); expireMinutes > 0 {
+		PinResetCodeExpireTime = time.Duration(expireMinutes) * time.Minute
+	}
+	if resendSeconds := viper.GetInt("PinReset.ResendSeconds"); resendSeconds > 0 {
+		PinResetCodeResendTime = time.Duration(resendSeconds) * time.Second
+	}
+	if maxAttempts := viper.GetInt("PinReset.MaxAttempts"); maxAttempts > 0 {
+		PinResetCodeMaxAttempts = maxAttempts
+	}
+
+	if memory := viper.GetUint32("PinHash.MemoryKiB"); memory > 0 {
+		util.PinHashParams.Memory = memory
+	}
+	if iterations := viper.GetUint32("PinHash.Iterations"); iterations > 0 {
+		util.PinHashParams.Iterations = iterations
+	}
+	if parallelism := viper.GetUint("PinHash.Parallelism"); parallelism > 0 && parallelism <= 255 {
+		util.PinHashParams.Parallelism = uint8(parallelism)
+	}
+	util.PinPepper = viper.GetString("PinHash.Pepper")
+
+	if viper.IsSet("Session.MaxConcurrent") {
+		MaxConcurrentSessions = viper.GetInt("Session.MaxConcurrent")
+	}
+	if accessTokenMinutes := viper.GetInt("Session.AccessTokenMinutes"); accessTokenMinutes > 0 {
+		AccessTokenLifetime = time.Duration(accessTokenMinutes) * time.Minute
+	}
+	if refreshTokenMinutes := viper.GetInt("Session.RefreshTokenMinutes"); refreshTokenMinutes > 0 {
+		RefreshTokenLifetime = time.Duration(refreshTokenMinutes) * time.Minute
+	}
+
+	if maxPerHour := viper.GetInt("PayeeLookup.MaxPerHour"); maxPerHour > 0 {
+		PayeeLookupMaxPerHour = maxPerHour
+	}
+	if revealTokenSeconds := viper.GetInt("CardReveal.TokenSeconds"); revealTokenSeconds > 0 {
+		CardRevealTokenLifetime = time.Duration(revealTokenSeconds) * time.Second
+	}
+	if maxPerHour := viper.GetInt("CardReveal.MaxPerHour"); maxPerHour > 0 {
+		CardRevealMaxPerHour = maxPerHour
+	}
+	if secretPeriodSeconds := viper.GetInt("CardReveal.SecretPeriodSeconds"); secretPeriodSeconds > 0 {
+		CardSecretPeriod = time.Duration(secretPeriodSeconds) * time.Second
+	}
+	CardSecretKey = []byte(viper.GetString("CardReveal.SecretKey"))
+	if len(CardSecretKey) == 0 {
+		// Every instance must share the key, otherwise the card secret depends on the instance serving the request
+		logger.Logger.Warnf("CardReveal.SecretKey is not config, using a random key for this instance")
+		CardSecretKey, _ = util.GenerateRandomBytes(32)
+	}
+
+	for key, limit := range map[string]*decimal.Decimal{
+		"CardControls.DefaultDailyLimit":  &CardDefaultDailyLimit,
+		"CardControls.DefaultOnlineLimit": &CardDefaultOnlineLimit,
+		"CardControls.DefaultAtmLimit":    &CardDefaultAtmLimit,
+		"CardControls.MaxLimit":           &CardMaxLimit,
+	} {
+		if value := viper.GetString(key); value != "" {
+			parsed, err := decimal.NewFromString(value)
+			if err != nil || parsed.IsNegative() {
+				logger.Logger.Errorf("%s %q is not a valid amount", key, value)
+				os.Exit(1)
+			}
+			*limit = parsed
+		}
+	}
+	if viper.IsSet("CardControls.DefaultContactlessEnabled") {
+		CardDefaultContactlessEnabled = viper.GetBool("CardControls.DefaultContactlessEnabled")
+	}
+	if viper.IsSet("CardControls.DefaultOnlineEnabled") {
+		CardDefaultOnlineEnabled = viper.GetBool("CardControls.DefaultOnlineEnabled")
+	}
+	if viper.IsSet("CardControls.DefaultOverseasEnabled") {
+		CardDefaultOverseasEnabled = viper.GetBool("CardControls.DefaultOverseasEnabled")
+	}
+
+	if palette := viper.GetStringSlice("Appearance.Palette"); len(palette) > 0 {
+		AppearancePalette = make([]string, len(palette))
+		for i, color := range palette {
+			AppearancePalette[i] = strings.ToLower(color)
+		}
+	}
+
+	if viper.IsSet("AccountNumber.Formats") {
+		var formats []accountnumber.Format
+		if err := viper.UnmarshalKey("AccountNumber.Formats", &formats); err != nil {
+			logger.Logger.Errorf("AccountNumber.Formats is not valid because: %s", err.Error())
+			os.Exit(1)
+		}
+		accountnumber.Formats = formats
+	}
+	if err := accountnumber.ValidateFormats(accountnumber.Formats); err != nil {
+		logger.Logger.Errorf("AccountNumber.Formats is not valid because: %s", err.Error())
+		os.Exit(1)
+	}
+
+	if bufferSize := viper.GetInt("BannerEvents.BufferSize"); bufferSize > 0 {
+		BannerEventBufferSize = bufferSize
+	}
+	if batchSize := viper.GetInt("BannerEvents.BatchSize"); batchSize > 0 {
+		BannerEventBatchSize = batchSize
+	}
+	if flushSeconds := viper.GetInt("BannerEvents.FlushIntervalSeconds"); flushSeconds > 0 {
+		BannerEventFlushInterval = time.Duration(flushSeconds) * time.Second
+	}
+
+	if sectionTimeoutMillis := viper.GetInt("Home.SectionTimeoutMillis"); sectionTimeoutMillis > 0 {
+		HomeSectionTimeout = time.Duration(sectionTimeoutMillis) * time.Millisecond
+	}
+
+	if flagTypes := viper.GetStringMapString("AccountFlags.Types"); len(flagTypes) > 0 {
+		AccountFlagTypes = flagTypes
+	}
+	for flagType, schema := range AccountFlagTypes {
+		if err := checkValidatorTag(schema); err != nil {
+			logger.Logger.Errorf("AccountFlags.Types %s schema %q is not valid because: %s", flagType, schema, err.Error())
+			os.Exit(1)
+		}
+	}
+}
+
+// checkValidatorTag runs the tag once so a typo in config fails at startup instead of on the first request
+func checkValidatorTag(tag string) (err error) {
+	if tag == "" {
+		return errors.New("schema is empty")
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	validator.New().Var("", tag)
+	return nil
 }
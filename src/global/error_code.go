@@ -1,6 +1,9 @@
 package global
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 const errorCodeBase = 0
 
@@ -10,13 +13,181 @@ const (
 
 	DatabaseError int64 = errorCodeBase + 8
 	IncorrectPin  int64 = errorCodeBase + 9
+
+	InvalidTransferAmount int64 = errorCodeBase + 10
+	AccountNotFound       int64 = errorCodeBase + 11
+	InsufficientFunds     int64 = errorCodeBase + 12
+	CurrencyMismatch      int64 = errorCodeBase + 13
+	SameAccountTransfer   int64 = errorCodeBase + 14
+
+	InvalidIdempotencyKey    int64 = errorCodeBase + 15
+	IdempotencyKeyConflict   int64 = errorCodeBase + 16
+	IdempotencyKeyInProgress int64 = errorCodeBase + 17
+
+	PinLocked        int64 = errorCodeBase + 18
+	WeakPin          int64 = errorCodeBase + 19
+	PinReused        int64 = errorCodeBase + 20
+	InvalidResetCode int64 = errorCodeBase + 21
+
+	NotificationError int64 = errorCodeBase + 22
+
+	SessionNotFound     int64 = errorCodeBase + 23
+	InvalidRefreshToken int64 = errorCodeBase + 24
+	RefreshTokenReused  int64 = errorCodeBase + 25
+
+	UserNotFound int64 = errorCodeBase + 26
+	ApiRemoved   int64 = errorCodeBase + 27
+
+	InvalidDisplayName  int64 = errorCodeBase + 28
+	UnsupportedLanguage int64 = errorCodeBase + 29
+
+	CardNotFound          int64 = errorCodeBase + 30
+	InvalidCardTransition int64 = errorCodeBase + 31
+	CardStatusChanged     int64 = errorCodeBase + 32
+	CardNotRevealable     int64 = errorCodeBase + 33
+	CardRevealRateLimited int64 = errorCodeBase + 34
+	InvalidRevealToken    int64 = errorCodeBase + 35
+	InvalidCardLimits     int64 = errorCodeBase + 36
+	CardNotUsable         int64 = errorCodeBase + 37
+	CardChannelDisabled   int64 = errorCodeBase + 38
+	CardLimitExceeded     int64 = errorCodeBase + 39
+
+	ColorNotAllowed int64 = errorCodeBase + 40
+
+	SavedAccountNotFound    int64 = errorCodeBase + 41
+	SavedAccountExists      int64 = errorCodeBase + 42
+	InvalidSavedAccountName int64 = errorCodeBase + 43
+	InvalidAccountNumber    int64 = errorCodeBase + 44
+	OwnAccountAsPayee       int64 = errorCodeBase + 45
+
+	InvalidAccountOrder int64 = errorCodeBase + 46
+
+	InvalidSavingsGoal  int64 = errorCodeBase + 47
+	SavingsGoalNotFound int64 = errorCodeBase + 48
+	SavingsGoalExists   int64 = errorCodeBase + 49
+	SavingsGoalClosed   int64 = errorCodeBase + 50
+
+	InvalidAccountFlag  int64 = errorCodeBase + 51
+	AccountFlagNotFound int64 = errorCodeBase + 52
+	AccountFlagExists   int64 = errorCodeBase + 53
+
+	InvalidBannerCampaign  int64 = errorCodeBase + 54
+	BannerCampaignNotFound int64 = errorCodeBase + 55
+	InvalidBannerReport    int64 = errorCodeBase + 56
+
+	HomeSectionTimedOut int64 = errorCodeBase + 57
+	HomeSectionFailed   int64 = errorCodeBase + 58
+
+	BannerNotFound int64 = errorCodeBase + 59
+
+	PayeeLookupRateLimited int64 = errorCodeBase + 60
+
+	IdempotencyKeyNotSupported int64 = errorCodeBase + 61
 )
 
 var ErrorMessage = map[int64]string{
 	InvalidUserToken: "cannot get user_id from token",
 	IncorrectPin:     "Incorrect Pin",
+
+	InvalidTransferAmount: "transfer amount must be positive with at most 2 decimal places",
+	AccountNotFound:       "account not found",
+	InsufficientFunds:     "insufficient funds",
+	CurrencyMismatch:      "source and destination account currency mismatch",
+	SameAccountTransfer:   "cannot transfer to the source account",
+
+	InvalidIdempotencyKey:    "Idempotency-Key must not be longer than %d characters",
+	IdempotencyKeyConflict:   "Idempotency-Key was already used with a different request",
+	IdempotencyKeyInProgress: "a request with the same Idempotency-Key is still being processed",
+
+	PinLocked:        "Pin is locked until %s",
+	WeakPin:          "Pin must be 6 digits without 3 repeated digits in a row or a running sequence",
+	PinReused:        "New pin must be different from the current pin",
+	InvalidResetCode: "Reset code is invalid or expired",
+
+	NotificationError: "unable to deliver notification",
+
+	SessionNotFound:     "session not found",
+	InvalidRefreshToken: "refresh token is invalid or expired",
+	RefreshTokenReused:  "refresh token was already used, please log in again",
+
+	UserNotFound: "user not found",
+	ApiRemoved:   "this api is no longer available, use %s instead",
+
+	InvalidDisplayName:  "display name must not be blank",
+	UnsupportedLanguage: "language must be one of %s",
+
+	CardNotFound:          "card not found",
+	InvalidCardTransition: "card cannot be changed from %s to %s",
+	CardStatusChanged:     "card status was changed by another request, please retry",
+	CardNotRevealable:     "card cannot be revealed while it is %s",
+	CardRevealRateLimited: "too many card reveals, please try again later",
+	InvalidRevealToken:    "reveal token is invalid or expired",
+	InvalidCardLimits:     "%s",
+	CardNotUsable:         "card cannot be used while it is %s",
+	CardChannelDisabled:   "%s use is turned off for this card",
+	CardLimitExceeded:     "%s limit of %s is exceeded",
+
+	ColorNotAllowed: "%s must be one of %s",
+
+	SavedAccountNotFound:    "saved account not found",
+	SavedAccountExists:      "account number %s is already saved",
+	InvalidSavedAccountName: "saved account name must not be blank",
+	InvalidAccountNumber:    "%s",
+	OwnAccountAsPayee:       "your own account cannot be used as a payee",
+
+	InvalidAccountOrder: "account_ids must list every one of your accounts exactly once",
+
+	InvalidSavingsGoal:  "%s",
+	SavingsGoalNotFound: "savings goal not found",
+	SavingsGoalExists:   "account already has an active savings goal",
+	SavingsGoalClosed:   "savings goal is already closed",
+
+	InvalidAccountFlag:  "%s",
+	AccountFlagNotFound: "account flag not found",
+	AccountFlagExists:   "account already has an active flag with this type and value",
+
+	InvalidBannerCampaign:  "%s",
+	BannerCampaignNotFound: "banner campaign not found",
+	InvalidBannerReport:    "%s",
+
+	HomeSectionTimedOut: "%s did not load in time",
+	HomeSectionFailed:   "%s could not be loaded",
+
+	BannerNotFound: "banner not found",
+
+	PayeeLookupRateLimited: "too many payee lookups, please try again later",
+
+	IdempotencyKeyNotSupported: "Idempotency-Key is not supported by this api",
+}
+
+// ErrorMessages is the message catalogue by language, ErrorMessage is the English one
+var ErrorMessages = map[string]map[int64]string{
+	"en": ErrorMessage,
+	"th": errorMessageTh,
 }
 
 func GetErrorMessage(code int64, args ...interface{}) string {
 	return fmt.Sprintf(ErrorMessage[code], args...)
 }
+
+// GetLocalizedErrorMessage uses the first language of the fallback chain that has the message, English otherwise.
+// Reason args are localized the same way.
+func GetLocalizedErrorMessage(language string, code int64, args ...interface{}) string {
+	args = localizeReasons(language, args)
+	for _, candidate := range LanguageChain(language) {
+		if message, ok := ErrorMessages[candidate][code]; ok {
+			return fmt.Sprintf(message, args...)
+		}
+	}
+	return GetErrorMessage(code, args...)
+}
+
+// LocalizeError translates a SystemError by its code and args, raw SystemErrors and any other error keep their own
+// message
+func LocalizeError(err error, language string) string {
+	var systemError SystemError
+	if !errors.As(err, &systemError) || systemError.Raw {
+		return err.Error()
+	}
+	return GetLocalizedErrorMessage(language, systemError.Code, systemError.Args...)
+}
@@ -0,0 +1,99 @@
+package global
+
+// errorMessageTh is the Thai message catalogue. Every message has to take the same verbs in the same order as the
+// English one, codes that only wrap a reason ("%s") are left out, their reason is translated by reasonMessageTh.
+var errorMessageTh = map[int64]string{
+	InvalidUserToken: "ไม่พบ user_id ในโทเคน",
+	IncorrectPin:     "รหัส PIN ไม่ถูกต้อง",
+
+	InvalidTransferAmount: "จำนวนเงินที่โอนต้องมากกว่าศูนย์และมีทศนิยมไม่เกิน 2 ตำแหน่ง",
+	AccountNotFound:       "ไม่พบบัญชี",
+	InsufficientFunds:     "ยอดเงินในบัญชีไม่เพียงพอ",
+	CurrencyMismatch:      "สกุลเงินของบัญชีต้นทางและปลายทางไม่ตรงกัน",
+	SameAccountTransfer:   "ไม่สามารถโอนเข้าบัญชีต้นทางได้",
+
+	InvalidIdempotencyKey:    "Idempotency-Key ต้องยาวไม่เกิน %d ตัวอักษร",
+	IdempotencyKeyConflict:   "Idempotency-Key นี้ถูกใช้กับคำขออื่นแล้ว",
+	IdempotencyKeyInProgress: "คำขอที่ใช้ Idempotency-Key เดียวกันยังดำเนินการอยู่",
+
+	PinLocked:        "รหัส PIN ถูกล็อกจนถึง %s",
+	WeakPin:          "รหัส PIN ต้องเป็นตัวเลข 6 หลัก ห้ามมีเลขซ้ำกัน 3 ตัวติดกันหรือเลขเรียงกัน",
+	PinReused:        "รหัส PIN ใหม่ต้องไม่ซ้ำกับรหัสเดิม",
+	InvalidResetCode: "รหัสรีเซ็ตไม่ถูกต้องหรือหมดอายุแล้ว",
+
+	NotificationError: "ไม่สามารถส่งการแจ้งเตือนได้",
+
+	SessionNotFound:     "ไม่พบเซสชัน",
+	InvalidRefreshToken: "refresh token ไม่ถูกต้องหรือหมดอายุแล้ว",
+	RefreshTokenReused:  "refresh token นี้ถูกใช้ไปแล้ว กรุณาเข้าสู่ระบบใหม่",
+
+	UserNotFound: "ไม่พบผู้ใช้",
+	ApiRemoved:   "API นี้ไม่สามารถใช้งานได้แล้ว กรุณาใช้ %s แทน",
+
+	InvalidDisplayName:  "ชื่อที่แสดงต้องไม่เว้นว่าง",
+	UnsupportedLanguage: "ภาษาต้องเป็นหนึ่งใน %s",
+
+	CardNotFound:          "ไม่พบบัตร",
+	InvalidCardTransition: "ไม่สามารถเปลี่ยนสถานะบัตรจาก %s เป็น %s ได้",
+	CardStatusChanged:     "สถานะบัตรถูกเปลี่ยนโดยคำขออื่น กรุณาลองใหม่",
+	CardNotRevealable:     "ไม่สามารถแสดงข้อมูลบัตรขณะที่บัตรมีสถานะ %s",
+	CardRevealRateLimited: "แสดงข้อมูลบัตรบ่อยเกินไป กรุณาลองใหม่ภายหลัง",
+	InvalidRevealToken:    "reveal token ไม่ถูกต้องหรือหมดอายุแล้ว",
+	CardNotUsable:         "ไม่สามารถใช้บัตรขณะที่บัตรมีสถานะ %s",
+	CardChannelDisabled:   "การใช้งานแบบ %s ถูกปิดสำหรับบัตรนี้",
+	CardLimitExceeded:     "เกินวงเงิน %s ที่ %s",
+
+	ColorNotAllowed: "%s ต้องเป็นหนึ่งใน %s",
+
+	SavedAccountNotFound:    "ไม่พบบัญชีที่บันทึกไว้",
+	SavedAccountExists:      "บัญชีเลขที่ %s ถูกบันทึกไว้แล้ว",
+	InvalidSavedAccountName: "ชื่อบัญชีที่บันทึกต้องไม่เว้นว่าง",
+	OwnAccountAsPayee:       "ไม่สามารถใช้บัญชีของคุณเองเป็นผู้รับเงินได้",
+
+	InvalidAccountOrder: "account_ids ต้องระบุทุกบัญชีของคุณเพียงครั้งเดียว",
+
+	SavingsGoalNotFound: "ไม่พบเป้าหมายการออม",
+	SavingsGoalExists:   "บัญชีนี้มีเป้าหมายการออมที่ใช้งานอยู่แล้ว",
+	SavingsGoalClosed:   "เป้าหมายการออมนี้ถูกปิดไปแล้ว",
+
+	AccountFlagNotFound: "ไม่พบแฟล็กของบัญชี",
+	AccountFlagExists:   "บัญชีนี้มีแฟล็กประเภทและค่านี้ที่ใช้งานอยู่แล้ว",
+
+	BannerCampaignNotFound: "ไม่พบแคมเปญแบนเนอร์",
+
+	HomeSectionTimedOut: "โหลด %s ไม่ทันเวลา",
+	HomeSectionFailed:   "ไม่สามารถโหลด %s ได้",
+
+	BannerNotFound: "ไม่พบแบนเนอร์",
+
+	PayeeLookupRateLimited: "ค้นหาผู้รับเงินบ่อยเกินไป กรุณาลองใหม่ภายหลัง",
+
+	IdempotencyKeyNotSupported: "API นี้ไม่รองรับ Idempotency-Key",
+}
+
+// reasonMessageTh is the Thai reason catalogue, see ReasonMessage
+var reasonMessageTh = map[string]string{
+	ReasonInvalidDate:   "%s ต้องเป็นวันที่ในรูปแบบ 2006-01-02",
+	ReasonNotInFuture:   "%s ต้องเป็นเวลาในอนาคต",
+	ReasonBlankName:     "name ต้องไม่เว้นว่าง",
+	ReasonInvalidTarget: "target_amount ต้องเป็นจำนวนเงินที่มากกว่าศูนย์และมีทศนิยมไม่เกิน 2 ตำแหน่ง",
+
+	ReasonInvalidCardLimit:      "%s ต้องอยู่ระหว่าง 0 ถึง %s และมีทศนิยมไม่เกิน 2 ตำแหน่ง",
+	ReasonOnlineAboveDailyLimit: "online_limit ต้องไม่สูงกว่า daily_limit",
+
+	ReasonMalformedAccountNumber: "เลขบัญชีต้องประกอบด้วยตัวเลข ช่องว่าง หรือขีดเท่านั้น",
+	ReasonUnknownAccountFormat:   "เลขบัญชีไม่ตรงกับธนาคารที่รองรับ",
+	ReasonInvalidCheckDigit:      "เลขตรวจสอบของเลขบัญชีไม่ถูกต้อง",
+
+	ReasonUnknownFlagType:      "flag_type ต้องเป็นหนึ่งใน %s",
+	ReasonFlagValueMismatch:    "flag_value ของแฟล็ก %s ต้องตรงกับ %s",
+	ReasonExpiredFlagUnchanged: "ไม่สามารถแก้ไขแฟล็กที่หมดอายุแล้ว กรุณาเพิ่มแฟล็กใหม่แทน",
+
+	ReasonEndsBeforeStart:        "ends_at ต้องอยู่หลัง starts_at",
+	ReasonUserIdsNotAllowed:      "ระบุ user_ids ได้เฉพาะกลุ่มเป้าหมายแบบ users เท่านั้น",
+	ReasonSegmentNotAllowed:      "ระบุ account_type หรือ currency ได้เฉพาะกลุ่มเป้าหมายแบบ segment เท่านั้น",
+	ReasonUnsupportedTranslation: "translations ต้องเป็นภาษาที่รองรับซึ่งไม่ใช่ %s",
+
+	ReasonFromAfterTo:   "from ต้องไม่อยู่หลัง to",
+	ReasonReportTooLong: "รายงานครอบคลุมได้ไม่เกิน %d วัน",
+}
@@ -0,0 +1,98 @@
+package global
+
+import "fmt"
+
+// Reasons of the codes whose message only wraps a reason ("%s"), e.g. InvalidSavingsGoal
+const (
+	ReasonInvalidDate   = "invalid_date"
+	ReasonNotInFuture   = "not_in_future"
+	ReasonBlankName     = "blank_name"
+	ReasonInvalidTarget = "invalid_target"
+
+	ReasonInvalidCardLimit      = "invalid_card_limit"
+	ReasonOnlineAboveDailyLimit = "online_above_daily_limit"
+
+	ReasonMalformedAccountNumber = "malformed_account_number"
+	ReasonUnknownAccountFormat   = "unknown_account_format"
+	ReasonInvalidCheckDigit      = "invalid_check_digit"
+
+	ReasonUnknownFlagType      = "unknown_flag_type"
+	ReasonFlagValueMismatch    = "flag_value_mismatch"
+	ReasonExpiredFlagUnchanged = "expired_flag_unchanged"
+
+	ReasonEndsBeforeStart        = "ends_before_start"
+	ReasonUserIdsNotAllowed      = "user_ids_not_allowed"
+	ReasonSegmentNotAllowed      = "segment_not_allowed"
+	ReasonUnsupportedTranslation = "unsupported_translation"
+
+	ReasonFromAfterTo   = "from_after_to"
+	ReasonReportTooLong = "report_too_long"
+)
+
+var ReasonMessage = map[string]string{
+	ReasonInvalidDate:   "%s must be a date like 2006-01-02",
+	ReasonNotInFuture:   "%s must be in the future",
+	ReasonBlankName:     "name must not be blank",
+	ReasonInvalidTarget: "target_amount must be a positive amount with at most 2 decimals",
+
+	ReasonInvalidCardLimit:      "%s must be between 0 and %s with at most 2 decimal places",
+	ReasonOnlineAboveDailyLimit: "online_limit must not be higher than daily_limit",
+
+	ReasonMalformedAccountNumber: "account number must only contain digits, spaces and dashes",
+	ReasonUnknownAccountFormat:   "account number does not match any supported bank",
+	ReasonInvalidCheckDigit:      "account number check digit is invalid",
+
+	ReasonUnknownFlagType:      "flag_type must be one of %s",
+	ReasonFlagValueMismatch:    "flag_value of a %s flag must match %s",
+	ReasonExpiredFlagUnchanged: "an expired flag cannot be changed, add a new flag instead",
+
+	ReasonEndsBeforeStart:        "ends_at must be after starts_at",
+	ReasonUserIdsNotAllowed:      "user_ids must be given for the users audience only",
+	ReasonSegmentNotAllowed:      "account_type or currency must be given for the segment audience only",
+	ReasonUnsupportedTranslation: "translations must be in a supported language other than %s",
+
+	ReasonFromAfterTo:   "from must not be after to",
+	ReasonReportTooLong: "the report covers at most %d days",
+}
+
+// ReasonMessages is the reason catalogue by language, ReasonMessage is the English one
+var ReasonMessages = map[string]map[string]string{
+	"en": ReasonMessage,
+	"th": reasonMessageTh,
+}
+
+// Reason is passed as arg of a code that wraps a reason, it is looked up in ReasonMessages when the error is
+// localized and reads as the English text anywhere else
+type Reason struct {
+	Key  string
+	Args []interface{}
+}
+
+func NewReason(key string, args ...interface{}) Reason {
+	return Reason{Key: key, Args: args}
+}
+
+func (reason Reason) String() string {
+	return fmt.Sprintf(ReasonMessage[reason.Key], reason.Args...)
+}
+
+// localize uses the first language of the fallback chain that has the reason, English otherwise
+func (reason Reason) localize(language string) string {
+	for _, candidate := range LanguageChain(language) {
+		if message, ok := ReasonMessages[candidate][reason.Key]; ok {
+			return fmt.Sprintf(message, reason.Args...)
+		}
+	}
+	return reason.String()
+}
+
+func localizeReasons(language string, args []interface{}) []interface{} {
+	localized := make([]interface{}, len(args))
+	for i, arg := range args {
+		if reason, ok := arg.(Reason); ok {
+			arg = reason.localize(language)
+		}
+		localized[i] = arg
+	}
+	return localized
+}
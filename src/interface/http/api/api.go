@@ -5,12 +5,17 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func AddPublicRoute(router *fiber.Router) {
+func AddPublicRoute(router *fiber.Router, postMiddlewares ...fiber.Handler) {
 	v1Route := (*router).Group("/v1")
-	v1.AddPublicRoutes(&v1Route)
+	v1.AddPublicRoutes(&v1Route, postMiddlewares...)
 }
 
-func AddProtectedRoute(router *fiber.Router) {
+func AddProtectedRoute(router *fiber.Router, postMiddlewares ...fiber.Handler) {
 	v1Route := (*router).Group("/v1")
-	v1.AddProtectedRoutes(&v1Route)
+	v1.AddProtectedRoutes(&v1Route, postMiddlewares...)
+}
+
+func AddAdminRoute(router *fiber.Router, postMiddlewares ...fiber.Handler) {
+	v1Route := (*router).Group("/v1")
+	v1.AddAdminRoutes(&v1Route, postMiddlewares...)
 }
@@ -3,8 +3,10 @@ package v1
 import (
 	"assignment/controller"
 	"assignment/global"
+	"assignment/interface/http/middleware/language"
 	"assignment/interface/http/response"
 	model_mysql "assignment/model/mysql"
+	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
@@ -23,7 +25,7 @@ func GetAccounts(context *fiber.Ctx) error {
 	if userId == "" {
 		apiLogger.Errorf("validate user failed on get accounts because user_id is empty")
 		output.Code = global.InvalidJSONString
-		output.Message = global.GetErrorMessage(global.InvalidUserToken)
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
 		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
 	}
 
@@ -38,7 +40,7 @@ func GetAccounts(context *fiber.Ctx) error {
 	result, err := controllerObj.GetUserAccounts(reqCtx)
 	if err != nil {
 		output.Code = err.(global.SystemError).Code
-		output.Message = err.Error()
+		output.Message = global.LocalizeError(err, language.FromContext(context))
 		return context.Status(fiber.ErrInternalServerError.Code).JSON(output)
 	}
 
@@ -62,7 +64,7 @@ func GetDebitCards(context *fiber.Ctx) error {
 	if userId == "" {
 		apiLogger.Errorf("validate user failed on get debit cards because user_id is empty")
 		output.Code = global.InvalidJSONString
-		output.Message = global.GetErrorMessage(global.InvalidUserToken)
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
 		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
 	}
 
@@ -77,7 +79,7 @@ func GetDebitCards(context *fiber.Ctx) error {
 	result, err := controllerObj.GetUserDebitCards(reqCtx)
 	if err != nil {
 		output.Code = err.(global.SystemError).Code
-		output.Message = err.Error()
+		output.Message = global.LocalizeError(err, language.FromContext(context))
 		return context.Status(fiber.ErrInternalServerError.Code).JSON(output)
 	}
 
@@ -92,6 +94,7 @@ func GetSavedAccounts(context *fiber.Ctx) error {
 	apiLogger := contextLogger.(*zap.SugaredLogger)
 	apiLogger.Info("GetSavedAccounts")
 
+	input := controller.GetSavedAccountsInput{}
 	output := response.ResponseOutput{}
 
 	// Get user_id from token
@@ -101,7 +104,23 @@ func GetSavedAccounts(context *fiber.Ctx) error {
 	if userId == "" {
 		apiLogger.Errorf("validate user failed on get saved accounts because user_id is empty")
 		output.Code = global.InvalidJSONString
-		output.Message = global.GetErrorMessage(global.InvalidUserToken)
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Parse Query
+	if err := context.QueryParser(&input); err != nil {
+		apiLogger.Errorf("could not bind query to get saved accounts because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	if err := validator.New().Struct(input); err != nil {
+		apiLogger.Errorf("validate query failed on get saved accounts because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
 		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
 	}
 
@@ -113,10 +132,10 @@ func GetSavedAccounts(context *fiber.Ctx) error {
 	// Get request-scoped context from Fiber and pass it down
 	reqCtx := context.UserContext()
 
-	result, err := controllerObj.GetUserSavedAccounts(reqCtx)
+	result, err := controllerObj.GetUserSavedAccounts(reqCtx, input)
 	if err != nil {
 		output.Code = err.(global.SystemError).Code
-		output.Message = err.Error()
+		output.Message = global.LocalizeError(err, language.FromContext(context))
 		return context.Status(fiber.ErrInternalServerError.Code).JSON(output)
 	}
 
@@ -126,8 +145,188 @@ func GetSavedAccounts(context *fiber.Ctx) error {
 	return context.JSON(output)
 }
 
+func UpdateAccountAppearance(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("UpdateAccountAppearance")
+
+	input := controller.UpdateAccountAppearanceInput{}
+	output := response.ResponseOutput{}
+
+	// Get user_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+
+	// Validate User
+	if userId == "" {
+		apiLogger.Errorf("validate user failed on update account appearance because user_id is empty")
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Parse Json
+	if err := context.BodyParser(&input); err != nil {
+		apiLogger.Errorf("could not bind json body to update account appearance because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	validate := validator.New()
+
+	accountId := context.Params("account_id")
+	err := validate.Var(accountId, "required,max=50")
+	if err == nil {
+		err = validate.Struct(input)
+	}
+	if err != nil {
+		apiLogger.Errorf("validate request failed on update account appearance because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := controllerObj.UpdateAccountAppearance(reqCtx, accountId, input)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(accountErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.JSON(output)
+}
+
+func SetMainAccount(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("SetMainAccount")
+
+	output := response.ResponseOutput{}
+
+	// Get user_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+
+	// Validate User
+	if userId == "" {
+		apiLogger.Errorf("validate user failed on set main account because user_id is empty")
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	accountId := context.Params("account_id")
+	if err := validator.New().Var(accountId, "required,max=50"); err != nil {
+		apiLogger.Errorf("validate account_id failed on set main account because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := controllerObj.SetMainAccount(reqCtx, accountId)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(accountErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.JSON(output)
+}
+
+func ReorderAccounts(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("ReorderAccounts")
+
+	input := controller.ReorderAccountsInput{}
+	output := response.ResponseOutput{}
+
+	// Get user_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+
+	// Validate User
+	if userId == "" {
+		apiLogger.Errorf("validate user failed on reorder accounts because user_id is empty")
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Parse Json
+	if err := context.BodyParser(&input); err != nil {
+		apiLogger.Errorf("could not bind json body to reorder accounts because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	if err := validator.New().Struct(input); err != nil {
+		apiLogger.Errorf("validate request failed on reorder accounts because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := controllerObj.ReorderAccounts(reqCtx, input)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(accountErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.JSON(output)
+}
+
+func accountErrorStatus(code int64) int {
+	switch code {
+	case global.AccountNotFound:
+		return fiber.StatusNotFound
+	case global.ColorNotAllowed, global.InvalidAccountOrder:
+		return fiber.StatusUnprocessableEntity
+	}
+	return fiber.StatusInternalServerError
+}
+
 func init() {
 	RegisterProtectedGET("/get-user-accounts", GetAccounts)
 	RegisterProtectedGET("/get-user-debit-cards", GetDebitCards)
 	RegisterProtectedGET("/get-user-saved-accounts", GetSavedAccounts)
+	RegisterProtectedPUT("/accounts/:account_id/appearance", UpdateAccountAppearance)
+	RegisterProtectedPUT("/accounts/:account_id/main", SetMainAccount)
+	RegisterProtectedPUT("/accounts/order", ReorderAccounts)
 }
@@ -0,0 +1,211 @@
+package v1
+
+import (
+	"assignment/controller"
+	"assignment/entity"
+	"assignment/global"
+	"assignment/interface/http/middleware/language"
+	"assignment/interface/http/response"
+	model_mysql "assignment/model/mysql"
+	gocontext "context"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"strconv"
+)
+
+func GetAccountFlags(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("GetAccountFlags")
+
+	input := controller.GetAccountFlagsInput{}
+	output := response.ResponseOutput{}
+
+	// Parse Query
+	if err := context.QueryParser(&input); err != nil {
+		apiLogger.Errorf("could not bind query to get account flags because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	if err := validator.New().Struct(input); err != nil {
+		apiLogger.Errorf("validate query failed on get account flags because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	// Admin requests act on any account, there is no user behind them
+	userId := ""
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := controllerObj.GetAccountFlags(reqCtx, input)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(accountFlagErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.JSON(output)
+}
+
+func AddAccountFlag(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("AddAccountFlag")
+
+	input := controller.AddAccountFlagInput{}
+	output := response.ResponseOutput{}
+
+	// Parse Json
+	if err := context.BodyParser(&input); err != nil {
+		apiLogger.Errorf("could not bind json body to add account flag because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	if err := validator.New().Struct(input); err != nil {
+		apiLogger.Errorf("validate json body failed on add account flag because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	userId := ""
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := controllerObj.AddAccountFlag(reqCtx, input)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(accountFlagErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.Status(fiber.StatusCreated).JSON(output)
+}
+
+func UpdateAccountFlag(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("UpdateAccountFlag")
+
+	input := controller.UpdateAccountFlagInput{}
+	return changeAccountFlag(context, apiLogger, "update account flag", &input,
+		func(ctx gocontext.Context, controllerObj controller.Controller, flagId int) (entity.AccountFlags, error) {
+			return controllerObj.UpdateAccountFlag(ctx, flagId, input)
+		})
+}
+
+func ExpireAccountFlag(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("ExpireAccountFlag")
+
+	return changeAccountFlag(context, apiLogger, "expire account flag", nil,
+		func(ctx gocontext.Context, controllerObj controller.Controller, flagId int) (entity.AccountFlags, error) {
+			return controllerObj.ExpireAccountFlag(ctx, flagId)
+		})
+}
+
+type changeAccountFlagFunc func(ctx gocontext.Context, controllerObj controller.Controller, flagId int) (entity.AccountFlags, error)
+
+// changeAccountFlag validates the flag_id path param and, when input is not nil, binds and validates the json body
+// into it before calling change
+func changeAccountFlag(context *fiber.Ctx, apiLogger *zap.SugaredLogger, action string, input interface{}, change changeAccountFlagFunc) error {
+	output := response.ResponseOutput{}
+
+	// Parse Json
+	if input != nil {
+		if err := context.BodyParser(input); err != nil {
+			apiLogger.Errorf("could not bind json body to %s because: %s", action, err.Error())
+			output.Code = global.InvalidJSONString
+			output.Message = err.Error()
+			return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+		}
+	}
+
+	// Validate
+	validate := validator.New()
+
+	flagIdStr := context.Params("flag_id")
+	err := validate.Var(flagIdStr, "required,number,max=10")
+	if err == nil && input != nil {
+		err = validate.Struct(input)
+	}
+	if err != nil {
+		apiLogger.Errorf("validate request failed on %s because: %s", action, err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+	flagId, err := strconv.Atoi(flagIdStr)
+	if err != nil {
+		apiLogger.Errorf("validate request failed on %s because: %s", action, err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	userId := ""
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := change(reqCtx, controllerObj, flagId)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(accountFlagErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.JSON(output)
+}
+
+func accountFlagErrorStatus(code int64) int {
+	switch code {
+	case global.AccountNotFound, global.AccountFlagNotFound:
+		return fiber.StatusNotFound
+	case global.AccountFlagExists:
+		return fiber.StatusConflict
+	case global.InvalidAccountFlag:
+		return fiber.StatusUnprocessableEntity
+	}
+	return fiber.StatusInternalServerError
+}
+
+func init() {
+	RegisterAdminGET("/account-flags", GetAccountFlags)
+	RegisterAdminPOST("/account-flags", AddAccountFlag)
+	RegisterAdminPUT("/account-flags/:flag_id", UpdateAccountFlag)
+	RegisterAdminPOST("/account-flags/:flag_id/expire", ExpireAccountFlag)
+}
@@ -3,6 +3,7 @@ package v1
 import (
 	"assignment/controller"
 	"assignment/global"
+	"assignment/interface/http/middleware/language"
 	"assignment/interface/http/response"
 	model_mysql "assignment/model/mysql"
 	"github.com/go-playground/validator/v10"
@@ -39,6 +40,12 @@ func Login(context *fiber.Ctx) error {
 		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
 	}
 
+	input.UserAgent = context.Get(fiber.HeaderUserAgent)
+	if len(input.UserAgent) > 255 {
+		input.UserAgent = input.UserAgent[:255]
+	}
+	input.IpAddress = context.IP()
+
 	requestId := context.Locals(global.KEY_REQUEST_ID)
 	requestIdStr := requestId.(string)
 
@@ -50,19 +57,72 @@ func Login(context *fiber.Ctx) error {
 	result, err := controllerObj.Login(reqCtx, input)
 	if err != nil {
 		output.Code = err.(global.SystemError).Code
-		output.Message = err.Error()
+		output.Message = global.LocalizeError(err, language.FromContext(context))
 		if output.Code == global.IncorrectPin {
 			return context.Status(fiber.ErrUnauthorized.Code).JSON(output)
 		}
+		if output.Code == global.PinLocked {
+			return context.Status(fiber.StatusLocked).JSON(output)
+		}
 		return context.Status(fiber.ErrInternalServerError.Code).JSON(output)
 	}
 
 	output.Message = global.RESULT_SUCCESS
 	output.Data = result
 
+	// Issued tokens must not be kept by any cache on the way
+	context.Set(fiber.HeaderCacheControl, "no-store")
+	return context.JSON(output)
+}
+
+func UnlockPin(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("UnlockPin")
+
+	input := controller.UnlockPinInput{}
+	output := response.ResponseOutput{}
+
+	// Parse Json
+	if err := context.BodyParser(&input); err != nil {
+		apiLogger.Errorf("could not bind json body to unlock pin because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	validate := validator.New()
+
+	err := validate.Struct(input)
+	if err != nil {
+		errors := err.(validator.ValidationErrors)
+		apiLogger.Errorf("validate json body failed on unlock pin because: %s", errors)
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &input.UserId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	if err := controllerObj.UnlockPin(reqCtx, input); err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(pinErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+
 	return context.JSON(output)
 }
 
 func init() {
 	RegisterPublicPOST("/login", Login)
+	RegisterAdminPOST("/unlock-pin", UnlockPin)
 }
@@ -1,10 +1,13 @@
 package v1
 
 import (
+	"assignment/bannerevent"
 	"assignment/controller"
 	"assignment/global"
+	"assignment/interface/http/middleware/language"
 	"assignment/interface/http/response"
 	model_mysql "assignment/model/mysql"
+	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
@@ -23,7 +26,7 @@ func GetBanners(context *fiber.Ctx) error {
 	if userId == "" {
 		apiLogger.Errorf("validate user failed on get banners because user_id is empty")
 		output.Code = global.InvalidJSONString
-		output.Message = global.GetErrorMessage(global.InvalidUserToken)
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
 		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
 	}
 
@@ -38,7 +41,7 @@ func GetBanners(context *fiber.Ctx) error {
 	result, err := controllerObj.GetUserBanners(reqCtx)
 	if err != nil {
 		output.Code = err.(global.SystemError).Code
-		output.Message = err.Error()
+		output.Message = global.LocalizeError(err, language.FromContext(context))
 		return context.Status(fiber.ErrInternalServerError.Code).JSON(output)
 	}
 
@@ -48,6 +51,134 @@ func GetBanners(context *fiber.Ctx) error {
 	return context.JSON(output)
 }
 
+func TrackBannerImpression(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("TrackBannerImpression")
+
+	return trackBannerEvent(context, apiLogger, bannerevent.TypeImpression)
+}
+
+func TrackBannerClick(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("TrackBannerClick")
+
+	return trackBannerEvent(context, apiLogger, bannerevent.TypeClick)
+}
+
+func DismissBanner(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("DismissBanner")
+
+	return trackBannerEvent(context, apiLogger, bannerevent.TypeDismiss)
+}
+
+// trackBannerEvent validates the banner_id path param and records the event for the user
+func trackBannerEvent(context *fiber.Ctx, apiLogger *zap.SugaredLogger, eventType string) error {
+	output := response.ResponseOutput{}
+
+	// Get user_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+
+	// Validate User
+	if userId == "" {
+		apiLogger.Errorf("validate user failed on track banner %s because user_id is empty", eventType)
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	bannerId := context.Params("banner_id")
+	if err := validator.New().Var(bannerId, "required,max=50"); err != nil {
+		apiLogger.Errorf("validate request failed on track banner %s because: %s", eventType, err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	if err := controllerObj.TrackBannerEvent(reqCtx, bannerId, eventType); err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(bannerErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+
+	return context.JSON(output)
+}
+
+func GetBannerReport(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("GetBannerReport")
+
+	input := controller.BannerReportInput{}
+	output := response.ResponseOutput{}
+
+	// Parse Query
+	if err := context.QueryParser(&input); err != nil {
+		apiLogger.Errorf("could not bind query to get banner report because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	if err := validator.New().Struct(input); err != nil {
+		apiLogger.Errorf("validate query failed on get banner report because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	// Admin requests report on every user, there is no user behind them
+	userId := ""
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := controllerObj.GetBannerReport(reqCtx, input)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(bannerErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.JSON(output)
+}
+
+func bannerErrorStatus(code int64) int {
+	switch code {
+	case global.BannerNotFound:
+		return fiber.StatusNotFound
+	case global.InvalidBannerReport:
+		return fiber.StatusUnprocessableEntity
+	}
+	return fiber.StatusInternalServerError
+}
+
 func init() {
 	RegisterProtectedGET("/get-user-banners", GetBanners)
+	RegisterProtectedPOST("/banners/:banner_id/impression", TrackBannerImpression)
+	RegisterProtectedPOST("/banners/:banner_id/click", TrackBannerClick)
+	RegisterProtectedPOST("/banners/:banner_id/dismiss", DismissBanner)
+	RegisterAdminGET("/banner-report", GetBannerReport)
 }
@@ -0,0 +1,183 @@
+package v1
+
+import (
+	"assignment/controller"
+	"assignment/global"
+	"assignment/interface/http/middleware/language"
+	"assignment/interface/http/response"
+	model_mysql "assignment/model/mysql"
+	gocontext "context"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+func GetBannerCampaigns(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("GetBannerCampaigns")
+
+	output := response.ResponseOutput{}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	// Admin requests act on every user, there is no user behind them
+	userId := ""
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := controllerObj.GetBannerCampaigns(reqCtx)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(bannerCampaignErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.JSON(output)
+}
+
+func CreateBannerCampaign(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("CreateBannerCampaign")
+
+	input := controller.BannerCampaignInput{}
+	output := response.ResponseOutput{}
+
+	// Parse Json
+	if err := context.BodyParser(&input); err != nil {
+		apiLogger.Errorf("could not bind json body to create banner campaign because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	if err := validator.New().Struct(input); err != nil {
+		apiLogger.Errorf("validate json body failed on create banner campaign because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	userId := ""
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := controllerObj.CreateBannerCampaign(reqCtx, input)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(bannerCampaignErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.Status(fiber.StatusCreated).JSON(output)
+}
+
+func UpdateBannerCampaign(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("UpdateBannerCampaign")
+
+	input := controller.BannerCampaignInput{}
+	return changeBannerCampaign(context, apiLogger, "update banner campaign", &input,
+		func(ctx gocontext.Context, controllerObj controller.Controller, campaignId string) (interface{}, error) {
+			return controllerObj.UpdateBannerCampaign(ctx, campaignId, input)
+		})
+}
+
+func DeleteBannerCampaign(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("DeleteBannerCampaign")
+
+	return changeBannerCampaign(context, apiLogger, "delete banner campaign", nil,
+		func(ctx gocontext.Context, controllerObj controller.Controller, campaignId string) (interface{}, error) {
+			return nil, controllerObj.DeleteBannerCampaign(ctx, campaignId)
+		})
+}
+
+type changeBannerCampaignFunc func(ctx gocontext.Context, controllerObj controller.Controller, campaignId string) (interface{}, error)
+
+// changeBannerCampaign validates the campaign_id path param and, when input is not nil, binds and validates the json
+// body into it before calling change
+func changeBannerCampaign(context *fiber.Ctx, apiLogger *zap.SugaredLogger, action string, input interface{}, change changeBannerCampaignFunc) error {
+	output := response.ResponseOutput{}
+
+	// Parse Json
+	if input != nil {
+		if err := context.BodyParser(input); err != nil {
+			apiLogger.Errorf("could not bind json body to %s because: %s", action, err.Error())
+			output.Code = global.InvalidJSONString
+			output.Message = err.Error()
+			return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+		}
+	}
+
+	// Validate
+	validate := validator.New()
+
+	campaignId := context.Params("campaign_id")
+	err := validate.Var(campaignId, "required,max=50")
+	if err == nil && input != nil {
+		err = validate.Struct(input)
+	}
+	if err != nil {
+		apiLogger.Errorf("validate request failed on %s because: %s", action, err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	userId := ""
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := change(reqCtx, controllerObj, campaignId)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(bannerCampaignErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.JSON(output)
+}
+
+func bannerCampaignErrorStatus(code int64) int {
+	switch code {
+	case global.BannerCampaignNotFound:
+		return fiber.StatusNotFound
+	case global.InvalidBannerCampaign:
+		return fiber.StatusUnprocessableEntity
+	}
+	return fiber.StatusInternalServerError
+}
+
+func init() {
+	RegisterAdminGET("/banner-campaigns", GetBannerCampaigns)
+	RegisterAdminPOST("/banner-campaigns", CreateBannerCampaign)
+	RegisterAdminPUT("/banner-campaigns/:campaign_id", UpdateBannerCampaign)
+	RegisterAdminDELETE("/banner-campaigns/:campaign_id", DeleteBannerCampaign)
+}
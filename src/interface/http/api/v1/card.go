@@ -0,0 +1,425 @@
+package v1
+
+import (
+	"assignment/controller"
+	"assignment/global"
+	"assignment/interface/http/middleware/language"
+	"assignment/interface/http/response"
+	model_mysql "assignment/model/mysql"
+	gocontext "context"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+func FreezeCard(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("FreezeCard")
+
+	return changeCardStatus(context, apiLogger, "freeze card", func(ctx gocontext.Context, controllerObj controller.Controller, cardId string) (controller.CardStatusOutput, error) {
+		return controllerObj.FreezeCard(ctx, cardId)
+	})
+}
+
+func UnfreezeCard(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("UnfreezeCard")
+
+	return changeCardStatus(context, apiLogger, "unfreeze card", func(ctx gocontext.Context, controllerObj controller.Controller, cardId string) (controller.CardStatusOutput, error) {
+		return controllerObj.UnfreezeCard(ctx, cardId)
+	})
+}
+
+func ReportLostCard(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("ReportLostCard")
+
+	return changeCardStatus(context, apiLogger, "report lost card", func(ctx gocontext.Context, controllerObj controller.Controller, cardId string) (controller.CardStatusOutput, error) {
+		return controllerObj.ReportLostCard(ctx, cardId)
+	})
+}
+
+type changeCardStatusFunc func(ctx gocontext.Context, controllerObj controller.Controller, cardId string) (controller.CardStatusOutput, error)
+
+// changeCardStatus holds the request handling shared by the card status apis, action names the api in logs
+func changeCardStatus(context *fiber.Ctx, apiLogger *zap.SugaredLogger, action string, change changeCardStatusFunc) error {
+	output := response.ResponseOutput{}
+
+	// Get user_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+
+	// Validate User
+	if userId == "" {
+		apiLogger.Errorf("validate user failed on %s because user_id is empty", action)
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	cardId := context.Params("card_id")
+	if err := validator.New().Var(cardId, "required,max=50"); err != nil {
+		apiLogger.Errorf("validate card_id failed on %s because: %s", action, err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := change(reqCtx, controllerObj, cardId)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(cardErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.JSON(output)
+}
+
+func RequestCardReveal(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("RequestCardReveal")
+
+	input := controller.RequestCardRevealInput{}
+	output := response.ResponseOutput{}
+
+	// Get user_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+
+	// Validate User
+	if userId == "" {
+		apiLogger.Errorf("validate user failed on request card reveal because user_id is empty")
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Parse Json
+	if err := context.BodyParser(&input); err != nil {
+		apiLogger.Errorf("could not bind json body to request card reveal because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+	input.IpAddress = context.IP()
+
+	// Validate
+	validate := validator.New()
+
+	cardId := context.Params("card_id")
+	err := validate.Var(cardId, "required,max=50")
+	if err == nil {
+		err = validate.Struct(input)
+	}
+	if err != nil {
+		apiLogger.Errorf("validate request failed on request card reveal because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := controllerObj.RequestCardReveal(reqCtx, cardId, input)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(cardErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	// Revealed card data must not be kept by any cache on the way
+	context.Set(fiber.HeaderCacheControl, "no-store")
+	return context.JSON(output)
+}
+
+func RevealCard(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("RevealCard")
+
+	input := controller.RevealCardInput{}
+	output := response.ResponseOutput{}
+
+	// Get user_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+
+	// Validate User
+	if userId == "" {
+		apiLogger.Errorf("validate user failed on reveal card because user_id is empty")
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Parse Json
+	if err := context.BodyParser(&input); err != nil {
+		apiLogger.Errorf("could not bind json body to reveal card because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+	input.IpAddress = context.IP()
+
+	// Validate
+	validate := validator.New()
+
+	cardId := context.Params("card_id")
+	err := validate.Var(cardId, "required,max=50")
+	if err == nil {
+		err = validate.Struct(input)
+	}
+	if err != nil {
+		apiLogger.Errorf("validate request failed on reveal card because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := controllerObj.RevealCard(reqCtx, cardId, input)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(cardErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	// Revealed card data must not be kept by any cache on the way
+	context.Set(fiber.HeaderCacheControl, "no-store")
+	return context.JSON(output)
+}
+
+func GetCardControls(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("GetCardControls")
+
+	output := response.ResponseOutput{}
+
+	// Get user_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+
+	// Validate User
+	if userId == "" {
+		apiLogger.Errorf("validate user failed on get card controls because user_id is empty")
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	cardId := context.Params("card_id")
+	if err := validator.New().Var(cardId, "required,max=50"); err != nil {
+		apiLogger.Errorf("validate card_id failed on get card controls because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := controllerObj.GetCardControls(reqCtx, cardId)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(cardErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.JSON(output)
+}
+
+func UpdateCardControls(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("UpdateCardControls")
+
+	input := controller.UpdateCardControlsInput{}
+	output := response.ResponseOutput{}
+
+	// Get user_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+
+	// Validate User
+	if userId == "" {
+		apiLogger.Errorf("validate user failed on update card controls because user_id is empty")
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Parse Json
+	if err := context.BodyParser(&input); err != nil {
+		apiLogger.Errorf("could not bind json body to update card controls because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	validate := validator.New()
+
+	cardId := context.Params("card_id")
+	err := validate.Var(cardId, "required,max=50")
+	if err == nil {
+		err = validate.Struct(input)
+	}
+	if err != nil {
+		apiLogger.Errorf("validate request failed on update card controls because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := controllerObj.UpdateCardControls(reqCtx, cardId, input)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(cardErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.JSON(output)
+}
+
+func UpdateCardDesign(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("UpdateCardDesign")
+
+	input := controller.UpdateCardDesignInput{}
+	output := response.ResponseOutput{}
+
+	// Get user_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+
+	// Validate User
+	if userId == "" {
+		apiLogger.Errorf("validate user failed on update card design because user_id is empty")
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Parse Json
+	if err := context.BodyParser(&input); err != nil {
+		apiLogger.Errorf("could not bind json body to update card design because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	validate := validator.New()
+
+	cardId := context.Params("card_id")
+	err := validate.Var(cardId, "required,max=50")
+	if err == nil {
+		err = validate.Struct(input)
+	}
+	if err != nil {
+		apiLogger.Errorf("validate request failed on update card design because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := controllerObj.UpdateCardDesign(reqCtx, cardId, input)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(cardErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.JSON(output)
+}
+
+func cardErrorStatus(code int64) int {
+	switch code {
+	case global.CardNotFound:
+		return fiber.StatusNotFound
+	case global.InvalidCardTransition, global.CardStatusChanged, global.CardNotRevealable:
+		return fiber.StatusConflict
+	case global.IncorrectPin, global.InvalidRevealToken:
+		return fiber.StatusUnauthorized
+	case global.PinLocked:
+		return fiber.StatusLocked
+	case global.CardRevealRateLimited:
+		return fiber.StatusTooManyRequests
+	case global.InvalidCardLimits, global.ColorNotAllowed:
+		return fiber.StatusUnprocessableEntity
+	}
+	return fiber.StatusInternalServerError
+}
+
+func init() {
+	RegisterProtectedPOST("/debit-cards/:card_id/freeze", FreezeCard)
+	RegisterProtectedPOST("/debit-cards/:card_id/unfreeze", UnfreezeCard)
+	RegisterProtectedPOST("/debit-cards/:card_id/report-lost", ReportLostCard)
+	RegisterProtectedPOST("/debit-cards/:card_id/reveal-token", RequestCardReveal)
+	RegisterProtectedPOST("/debit-cards/:card_id/reveal", RevealCard)
+	RegisterProtectedGET("/debit-cards/:card_id/controls", GetCardControls)
+	RegisterProtectedPUT("/debit-cards/:card_id/controls", UpdateCardControls)
+	RegisterProtectedPUT("/debit-cards/:card_id/design", UpdateCardDesign)
+}
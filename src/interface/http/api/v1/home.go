@@ -0,0 +1,49 @@
+package v1
+
+import (
+	"assignment/controller"
+	"assignment/global"
+	"assignment/interface/http/middleware/language"
+	"assignment/interface/http/response"
+	model_mysql "assignment/model/mysql"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// GetHome answers with every home screen section in one call, sections fail on their own so the response is a
+// success even when some of them carry an error code
+func GetHome(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("GetHome")
+
+	output := response.ResponseOutput{}
+
+	// Get user_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+
+	// Validate User
+	if userId == "" {
+		apiLogger.Errorf("validate user failed on get home because user_id is empty")
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = controllerObj.GetHome(reqCtx)
+
+	return context.JSON(output)
+}
+
+func init() {
+	RegisterProtectedGET("/home", GetHome)
+}
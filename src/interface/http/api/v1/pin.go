@@ -0,0 +1,184 @@
+package v1
+
+import (
+	"assignment/controller"
+	"assignment/global"
+	"assignment/interface/http/middleware/language"
+	"assignment/interface/http/response"
+	model_mysql "assignment/model/mysql"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+func ChangePin(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("ChangePin")
+
+	input := controller.ChangePinInput{}
+	output := response.ResponseOutput{}
+
+	// Get user_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+
+	// Validate User
+	if userId == "" {
+		apiLogger.Errorf("validate user failed on change pin because user_id is empty")
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Parse Json
+	if err := context.BodyParser(&input); err != nil {
+		apiLogger.Errorf("could not bind json body to change pin because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	validate := validator.New()
+
+	err := validate.Struct(input)
+	if err != nil {
+		errors := err.(validator.ValidationErrors)
+		apiLogger.Errorf("validate json body failed on change pin because: %s", errors)
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	if err := controllerObj.ChangePin(reqCtx, input); err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(pinErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+
+	return context.JSON(output)
+}
+
+func ForgotPin(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("ForgotPin")
+
+	input := controller.ForgotPinInput{}
+	output := response.ResponseOutput{}
+
+	// Parse Json
+	if err := context.BodyParser(&input); err != nil {
+		apiLogger.Errorf("could not bind json body to forgot pin because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	validate := validator.New()
+
+	err := validate.Struct(input)
+	if err != nil {
+		errors := err.(validator.ValidationErrors)
+		apiLogger.Errorf("validate json body failed on forgot pin because: %s", errors)
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &input.UserId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	if err := controllerObj.ForgotPin(reqCtx, input); err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(fiber.ErrInternalServerError.Code).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+
+	return context.JSON(output)
+}
+
+func ResetPin(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("ResetPin")
+
+	input := controller.ResetPinInput{}
+	output := response.ResponseOutput{}
+
+	// Parse Json
+	if err := context.BodyParser(&input); err != nil {
+		apiLogger.Errorf("could not bind json body to reset pin because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	validate := validator.New()
+
+	err := validate.Struct(input)
+	if err != nil {
+		errors := err.(validator.ValidationErrors)
+		apiLogger.Errorf("validate json body failed on reset pin because: %s", errors)
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &input.UserId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	if err := controllerObj.ResetPin(reqCtx, input); err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(pinErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+
+	return context.JSON(output)
+}
+
+func pinErrorStatus(code int64) int {
+	switch code {
+	case global.IncorrectPin, global.InvalidResetCode:
+		return fiber.StatusUnauthorized
+	case global.PinLocked:
+		return fiber.StatusLocked
+	case global.WeakPin, global.PinReused:
+		return fiber.StatusUnprocessableEntity
+	case global.UserNotFound:
+		return fiber.StatusNotFound
+	}
+	return fiber.StatusInternalServerError
+}
+
+func init() {
+	RegisterProtectedPOST("/change-pin", ChangePin)
+	RegisterPublicPOST("/forgot-pin", ForgotPin)
+	RegisterPublicPOST("/reset-pin", ResetPin)
+}
@@ -0,0 +1,248 @@
+package v1
+
+import (
+	"assignment/controller"
+	"assignment/global"
+	"assignment/interface/http/middleware/language"
+	"assignment/interface/http/response"
+	model_mysql "assignment/model/mysql"
+	gocontext "context"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+func CreateSavedAccount(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("CreateSavedAccount")
+
+	input := controller.CreateSavedAccountInput{}
+	output := response.ResponseOutput{}
+
+	// Get user_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+
+	// Validate User
+	if userId == "" {
+		apiLogger.Errorf("validate user failed on create saved account because user_id is empty")
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Parse Json
+	if err := context.BodyParser(&input); err != nil {
+		apiLogger.Errorf("could not bind json body to create saved account because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	if err := validator.New().Struct(input); err != nil {
+		apiLogger.Errorf("validate request failed on create saved account because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := controllerObj.CreateSavedAccount(reqCtx, input)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(savedAccountErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.Status(fiber.StatusCreated).JSON(output)
+}
+
+func LookupPayee(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("LookupPayee")
+
+	input := controller.LookupPayeeInput{}
+	output := response.ResponseOutput{}
+
+	// Get user_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+
+	// Validate User
+	if userId == "" {
+		apiLogger.Errorf("validate user failed on lookup payee because user_id is empty")
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Parse Query
+	if err := context.QueryParser(&input); err != nil {
+		apiLogger.Errorf("could not bind query to lookup payee because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	if err := validator.New().Struct(input); err != nil {
+		apiLogger.Errorf("validate query failed on lookup payee because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := controllerObj.LookupPayee(reqCtx, input)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(savedAccountErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.JSON(output)
+}
+
+func RenameSavedAccount(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("RenameSavedAccount")
+
+	input := controller.RenameSavedAccountInput{}
+	return changeSavedAccount(context, apiLogger, "rename saved account", &input,
+		func(ctx gocontext.Context, controllerObj controller.Controller, savedAccountId string) (interface{}, error) {
+			return controllerObj.RenameSavedAccount(ctx, savedAccountId, input)
+		})
+}
+
+func FavoriteSavedAccount(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("FavoriteSavedAccount")
+
+	input := controller.FavoriteSavedAccountInput{}
+	return changeSavedAccount(context, apiLogger, "favorite saved account", &input,
+		func(ctx gocontext.Context, controllerObj controller.Controller, savedAccountId string) (interface{}, error) {
+			return controllerObj.FavoriteSavedAccount(ctx, savedAccountId, input)
+		})
+}
+
+func DeleteSavedAccount(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("DeleteSavedAccount")
+
+	return changeSavedAccount(context, apiLogger, "delete saved account", nil,
+		func(ctx gocontext.Context, controllerObj controller.Controller, savedAccountId string) (interface{}, error) {
+			return nil, controllerObj.DeleteSavedAccount(ctx, savedAccountId)
+		})
+}
+
+type changeSavedAccountFunc func(ctx gocontext.Context, controllerObj controller.Controller, savedAccountId string) (interface{}, error)
+
+// changeSavedAccount validates the saved_account_id path param and, when input is not nil, binds and validates the
+// json body into it before calling change
+func changeSavedAccount(context *fiber.Ctx, apiLogger *zap.SugaredLogger, action string, input interface{}, change changeSavedAccountFunc) error {
+	output := response.ResponseOutput{}
+
+	// Get user_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+
+	// Validate User
+	if userId == "" {
+		apiLogger.Errorf("validate user failed on %s because user_id is empty", action)
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Parse Json
+	if input != nil {
+		if err := context.BodyParser(input); err != nil {
+			apiLogger.Errorf("could not bind json body to %s because: %s", action, err.Error())
+			output.Code = global.InvalidJSONString
+			output.Message = err.Error()
+			return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+		}
+	}
+
+	// Validate
+	validate := validator.New()
+
+	savedAccountId := context.Params("saved_account_id")
+	err := validate.Var(savedAccountId, "required,max=50")
+	if err == nil && input != nil {
+		err = validate.Struct(input)
+	}
+	if err != nil {
+		apiLogger.Errorf("validate request failed on %s because: %s", action, err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := change(reqCtx, controllerObj, savedAccountId)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(savedAccountErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.JSON(output)
+}
+
+func savedAccountErrorStatus(code int64) int {
+	switch code {
+	case global.SavedAccountNotFound:
+		return fiber.StatusNotFound
+	case global.SavedAccountExists:
+		return fiber.StatusConflict
+	case global.InvalidSavedAccountName:
+		return fiber.StatusBadRequest
+	case global.InvalidAccountNumber, global.OwnAccountAsPayee:
+		return fiber.StatusUnprocessableEntity
+	case global.PayeeLookupRateLimited:
+		return fiber.StatusTooManyRequests
+	}
+	return fiber.StatusInternalServerError
+}
+
+func init() {
+	RegisterProtectedGET("/payees/lookup", LookupPayee)
+	RegisterProtectedPOST("/saved-accounts", CreateSavedAccount)
+	RegisterProtectedPUT("/saved-accounts/:saved_account_id", RenameSavedAccount)
+	RegisterProtectedPUT("/saved-accounts/:saved_account_id/favorite", FavoriteSavedAccount)
+	RegisterProtectedDELETE("/saved-accounts/:saved_account_id", DeleteSavedAccount)
+}
@@ -0,0 +1,174 @@
+package v1
+
+import (
+	"assignment/controller"
+	"assignment/global"
+	"assignment/interface/http/middleware/language"
+	"assignment/interface/http/response"
+	model_mysql "assignment/model/mysql"
+	gocontext "context"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+func CreateSavingsGoal(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("CreateSavingsGoal")
+
+	input := controller.CreateSavingsGoalInput{}
+	output := response.ResponseOutput{}
+
+	// Get user_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+
+	// Validate User
+	if userId == "" {
+		apiLogger.Errorf("validate user failed on create savings goal because user_id is empty")
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Parse Json
+	if err := context.BodyParser(&input); err != nil {
+		apiLogger.Errorf("could not bind json body to create savings goal because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	if err := validator.New().Struct(input); err != nil {
+		apiLogger.Errorf("validate request failed on create savings goal because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := controllerObj.CreateSavingsGoal(reqCtx, input)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(savingsGoalErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.Status(fiber.StatusCreated).JSON(output)
+}
+
+func UpdateSavingsGoal(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("UpdateSavingsGoal")
+
+	input := controller.UpdateSavingsGoalInput{}
+	return changeSavingsGoal(context, apiLogger, "update savings goal", &input,
+		func(ctx gocontext.Context, controllerObj controller.Controller, goalId string) (model_mysql.SavingsGoal, error) {
+			return controllerObj.UpdateSavingsGoal(ctx, goalId, input)
+		})
+}
+
+func CloseSavingsGoal(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("CloseSavingsGoal")
+
+	return changeSavingsGoal(context, apiLogger, "close savings goal", nil,
+		func(ctx gocontext.Context, controllerObj controller.Controller, goalId string) (model_mysql.SavingsGoal, error) {
+			return controllerObj.CloseSavingsGoal(ctx, goalId)
+		})
+}
+
+type changeSavingsGoalFunc func(ctx gocontext.Context, controllerObj controller.Controller, goalId string) (model_mysql.SavingsGoal, error)
+
+// changeSavingsGoal validates the goal_id path param and, when input is not nil, binds and validates the json body
+// into it before calling change
+func changeSavingsGoal(context *fiber.Ctx, apiLogger *zap.SugaredLogger, action string, input interface{}, change changeSavingsGoalFunc) error {
+	output := response.ResponseOutput{}
+
+	// Get user_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+
+	// Validate User
+	if userId == "" {
+		apiLogger.Errorf("validate user failed on %s because user_id is empty", action)
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Parse Json
+	if input != nil {
+		if err := context.BodyParser(input); err != nil {
+			apiLogger.Errorf("could not bind json body to %s because: %s", action, err.Error())
+			output.Code = global.InvalidJSONString
+			output.Message = err.Error()
+			return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+		}
+	}
+
+	// Validate
+	validate := validator.New()
+
+	goalId := context.Params("goal_id")
+	err := validate.Var(goalId, "required,max=50")
+	if err == nil && input != nil {
+		err = validate.Struct(input)
+	}
+	if err != nil {
+		apiLogger.Errorf("validate request failed on %s because: %s", action, err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := change(reqCtx, controllerObj, goalId)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(savingsGoalErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.JSON(output)
+}
+
+func savingsGoalErrorStatus(code int64) int {
+	switch code {
+	case global.AccountNotFound, global.SavingsGoalNotFound:
+		return fiber.StatusNotFound
+	case global.SavingsGoalExists, global.SavingsGoalClosed:
+		return fiber.StatusConflict
+	case global.InvalidSavingsGoal:
+		return fiber.StatusUnprocessableEntity
+	}
+	return fiber.StatusInternalServerError
+}
+
+func init() {
+	RegisterProtectedPOST("/savings-goals", CreateSavingsGoal)
+	RegisterProtectedPUT("/savings-goals/:goal_id", UpdateSavingsGoal)
+	RegisterProtectedPOST("/savings-goals/:goal_id/close", CloseSavingsGoal)
+}
@@ -0,0 +1,259 @@
+package v1
+
+import (
+	"assignment/controller"
+	"assignment/global"
+	"assignment/interface/http/middleware/language"
+	"assignment/interface/http/response"
+	model_mysql "assignment/model/mysql"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+func RefreshToken(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("RefreshToken")
+
+	input := controller.RefreshTokenInput{}
+	output := response.ResponseOutput{}
+
+	// Parse Json
+	if err := context.BodyParser(&input); err != nil {
+		apiLogger.Errorf("could not bind json body to refresh token because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	validate := validator.New()
+
+	err := validate.Struct(input)
+	if err != nil {
+		errors := err.(validator.ValidationErrors)
+		apiLogger.Errorf("validate json body failed on refresh token because: %s", errors)
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	// The user is only known once the refresh token is looked up
+	userId := ""
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := controllerObj.RefreshToken(reqCtx, input)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		if output.Code == global.InvalidRefreshToken || output.Code == global.RefreshTokenReused {
+			return context.Status(fiber.ErrUnauthorized.Code).JSON(output)
+		}
+		return context.Status(fiber.ErrInternalServerError.Code).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	// Issued tokens must not be kept by any cache on the way
+	context.Set(fiber.HeaderCacheControl, "no-store")
+	return context.JSON(output)
+}
+
+func GetSessions(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("GetSessions")
+
+	output := response.ResponseOutput{}
+
+	// Get user_id and session_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+	sessionId, _ := context.Locals(global.KEY_SESSION_ID).(string)
+
+	// Validate User
+	if userId == "" {
+		apiLogger.Errorf("validate user failed on get sessions because user_id is empty")
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := controllerObj.GetSessions(reqCtx, sessionId)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(fiber.ErrInternalServerError.Code).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.JSON(output)
+}
+
+func Logout(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("Logout")
+
+	output := response.ResponseOutput{}
+
+	// Get user_id and session_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+	sessionId, _ := context.Locals(global.KEY_SESSION_ID).(string)
+
+	// Validate User
+	if userId == "" || sessionId == "" {
+		apiLogger.Errorf("validate user failed on logout because user_id or session_id is empty")
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	if err := controllerObj.Logout(reqCtx, sessionId); err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(sessionErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+
+	return context.JSON(output)
+}
+
+func RevokeSession(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("RevokeSession")
+
+	input := controller.RevokeSessionInput{}
+	output := response.ResponseOutput{}
+
+	// Get user_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+
+	// Validate User
+	if userId == "" {
+		apiLogger.Errorf("validate user failed on revoke session because user_id is empty")
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Parse Json
+	if err := context.BodyParser(&input); err != nil {
+		apiLogger.Errorf("could not bind json body to revoke session because: %s", err.Error())
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	// Validate
+	validate := validator.New()
+
+	err := validate.Struct(input)
+	if err != nil {
+		errors := err.(validator.ValidationErrors)
+		apiLogger.Errorf("validate json body failed on revoke session because: %s", errors)
+		output.Code = global.InvalidJSONString
+		output.Message = err.Error()
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	if err := controllerObj.RevokeSession(reqCtx, input); err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(sessionErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+
+	return context.JSON(output)
+}
+
+func RevokeOtherSessions(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("RevokeOtherSessions")
+
+	output := response.ResponseOutput{}
+
+	// Get user_id and session_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+	sessionId, _ := context.Locals(global.KEY_SESSION_ID).(string)
+
+	// Validate User
+	if userId == "" || sessionId == "" {
+		apiLogger.Errorf("validate user failed on revoke other sessions because user_id or session_id is empty")
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := controllerObj.RevokeOtherSessions(reqCtx, sessionId)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(fiber.ErrInternalServerError.Code).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.JSON(output)
+}
+
+func sessionErrorStatus(code int64) int {
+	if code == global.SessionNotFound {
+		return fiber.StatusNotFound
+	}
+	return fiber.StatusInternalServerError
+}
+
+func init() {
+	RegisterPublicPOST("/token/refresh", RefreshToken)
+	RegisterProtectedGET("/get-sessions", GetSessions)
+	RegisterProtectedPOST("/logout", Logout)
+	RegisterProtectedPOST("/revoke-session", RevokeSession)
+	RegisterProtectedPOST("/revoke-other-sessions", RevokeOtherSessions)
+}
@@ -3,6 +3,7 @@ package v1
 import (
 	"assignment/controller"
 	"assignment/global"
+	"assignment/interface/http/middleware/language"
 	"assignment/interface/http/response"
 	model_mysql "assignment/model/mysql"
 	"github.com/go-playground/validator/v10"
@@ -10,6 +11,8 @@ import (
 	"go.uber.org/zap"
 )
 
+// GetUserById is deprecated in favour of GetMe, it lets anyone look up any user and is only served
+// while Deprecation.EnableGetUserById is on
 func GetUserById(context *fiber.Ctx) error {
 	contextLogger := context.Locals(global.KEY_LOGGER)
 	apiLogger := contextLogger.(*zap.SugaredLogger)
@@ -18,6 +21,15 @@ func GetUserById(context *fiber.Ctx) error {
 	input := controller.GetUserInput{}
 	output := response.ResponseOutput{}
 
+	apiLogger.Warnf("deprecated api get-user-by-id is called from %s (%s)", context.IP(), context.Get(fiber.HeaderUserAgent))
+	if !global.EnableGetUserById {
+		output.Code = global.ApiRemoved
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.ApiRemoved, "/api/v1/me")
+		return context.Status(fiber.StatusGone).JSON(output)
+	}
+	context.Set("Deprecation", "true")
+	context.Set("Link", `</api/v1/me>; rel="successor-version"`)
+
 	// Parse Json
 	if err := context.BodyParser(&input); err != nil {
 		apiLogger.Errorf("could not bind json body to get user because: %s", err.Error())
@@ -50,7 +62,7 @@ func GetUserById(context *fiber.Ctx) error {
 	result, err := controllerObj.GetUser(reqCtx, input)
 	if err != nil {
 		output.Code = err.(global.SystemError).Code
-		output.Message = err.Error()
+		output.Message = global.LocalizeError(err, language.FromContext(context))
 		if output.Code == global.IncorrectPin {
 			return context.Status(fiber.ErrUnauthorized.Code).JSON(output)
 		}
@@ -63,6 +75,46 @@ func GetUserById(context *fiber.Ctx) error {
 	return context.JSON(output)
 }
 
+func GetMe(context *fiber.Ctx) error {
+	contextLogger := context.Locals(global.KEY_LOGGER)
+	apiLogger := contextLogger.(*zap.SugaredLogger)
+	apiLogger.Info("GetMe")
+
+	output := response.ResponseOutput{}
+
+	// Get user_id from token
+	userId, _ := context.Locals(global.KEY_USER_ID).(string)
+
+	// Validate User
+	if userId == "" {
+		apiLogger.Errorf("validate user failed on get me because user_id is empty")
+		output.Code = global.InvalidJSONString
+		output.Message = global.GetLocalizedErrorMessage(language.FromContext(context), global.InvalidUserToken)
+		return context.Status(fiber.ErrBadRequest.Code).JSON(output)
+	}
+
+	requestId := context.Locals(global.KEY_REQUEST_ID)
+	requestIdStr := requestId.(string)
+
+	controllerObj := controller.New(&requestIdStr, &userId, model_mysql.NewModelRepository())
+
+	// Get request-scoped context from Fiber and pass it down
+	reqCtx := context.UserContext()
+
+	result, err := controllerObj.GetMe(reqCtx)
+	if err != nil {
+		output.Code = err.(global.SystemError).Code
+		output.Message = global.LocalizeError(err, language.FromContext(context))
+		return context.Status(profileErrorStatus(output.Code)).JSON(output)
+	}
+
+	output.Message = global.RESULT_SUCCESS
+	output.Data = result
+
+	return context.JSON(output)
+}
+
 func init() {
 	RegisterPublicPOST("/get-user-by-id", GetUserById)
+	RegisterProtectedGET("/me", GetMe)
 }
@@ -6,13 +6,24 @@ import (
 )
 
 var methodRoutesPublic = map[string]map[string]global.HandlerFunc{
-	global.METHOD_GET:  make(map[string]global.HandlerFunc),
-	global.METHOD_POST: make(map[string]global.HandlerFunc),
+	global.METHOD_GET:    make(map[string]global.HandlerFunc),
+	global.METHOD_POST:   make(map[string]global.HandlerFunc),
+	global.METHOD_PUT:    make(map[string]global.HandlerFunc),
+	global.METHOD_DELETE: make(map[string]global.HandlerFunc),
 }
 
 var methodRoutesProtected = map[string]map[string]global.HandlerFunc{
-	global.METHOD_GET:  make(map[string]global.HandlerFunc),
-	global.METHOD_POST: make(map[string]global.HandlerFunc),
+	global.METHOD_GET:    make(map[string]global.HandlerFunc),
+	global.METHOD_POST:   make(map[string]global.HandlerFunc),
+	global.METHOD_PUT:    make(map[string]global.HandlerFunc),
+	global.METHOD_DELETE: make(map[string]global.HandlerFunc),
+}
+
+var methodRoutesAdmin = map[string]map[string]global.HandlerFunc{
+	global.METHOD_GET:    make(map[string]global.HandlerFunc),
+	global.METHOD_POST:   make(map[string]global.HandlerFunc),
+	global.METHOD_PUT:    make(map[string]global.HandlerFunc),
+	global.METHOD_DELETE: make(map[string]global.HandlerFunc),
 }
 
 func RegisterPublicGET(path string, h global.HandlerFunc) {
@@ -21,27 +32,81 @@ func RegisterPublicGET(path string, h global.HandlerFunc) {
 func RegisterPublicPOST(path string, h global.HandlerFunc) {
 	methodRoutesPublic[global.METHOD_POST][path] = h
 }
+func RegisterPublicPUT(path string, h global.HandlerFunc) {
+	methodRoutesPublic[global.METHOD_PUT][path] = h
+}
+func RegisterPublicDELETE(path string, h global.HandlerFunc) {
+	methodRoutesPublic[global.METHOD_DELETE][path] = h
+}
 func RegisterProtectedGET(path string, h global.HandlerFunc) {
 	methodRoutesProtected[global.METHOD_GET][path] = h
 }
 func RegisterProtectedPOST(path string, h global.HandlerFunc) {
 	methodRoutesProtected[global.METHOD_POST][path] = h
 }
+func RegisterProtectedPUT(path string, h global.HandlerFunc) {
+	methodRoutesProtected[global.METHOD_PUT][path] = h
+}
+func RegisterProtectedDELETE(path string, h global.HandlerFunc) {
+	methodRoutesProtected[global.METHOD_DELETE][path] = h
+}
+func RegisterAdminGET(path string, h global.HandlerFunc) {
+	methodRoutesAdmin[global.METHOD_GET][path] = h
+}
+func RegisterAdminPOST(path string, h global.HandlerFunc) {
+	methodRoutesAdmin[global.METHOD_POST][path] = h
+}
+
+func RegisterAdminPUT(path string, h global.HandlerFunc) {
+	methodRoutesAdmin[global.METHOD_PUT][path] = h
+}
+func RegisterAdminDELETE(path string, h global.HandlerFunc) {
+	methodRoutesAdmin[global.METHOD_DELETE][path] = h
+}
 
-func AddPublicRoutes(router *fiber.Router) {
+// postMiddlewares are run in front of every POST handler, e.g. idempotency. PUT and DELETE handlers replace or
+// remove a resource as a whole so they are idempotent by themselves.
+func AddPublicRoutes(router *fiber.Router, postMiddlewares ...fiber.Handler) {
 	for route, h := range methodRoutesPublic[global.METHOD_GET] {
 		(*router).Get(route, h)
 	}
 	for route, h := range methodRoutesPublic[global.METHOD_POST] {
-		(*router).Post(route, h)
+		(*router).Post(route, append(postMiddlewares, h)...)
+	}
+	for route, h := range methodRoutesPublic[global.METHOD_PUT] {
+		(*router).Put(route, h)
+	}
+	for route, h := range methodRoutesPublic[global.METHOD_DELETE] {
+		(*router).Delete(route, h)
 	}
 }
 
-func AddProtectedRoutes(router *fiber.Router) {
+func AddProtectedRoutes(router *fiber.Router, postMiddlewares ...fiber.Handler) {
 	for route, h := range methodRoutesProtected[global.METHOD_GET] {
 		(*router).Get(route, h)
 	}
 	for route, h := range methodRoutesProtected[global.METHOD_POST] {
-		(*router).Post(route, h)
+		(*router).Post(route, append(postMiddlewares, h)...)
+	}
+	for route, h := range methodRoutesProtected[global.METHOD_PUT] {
+		(*router).Put(route, h)
+	}
+	for route, h := range methodRoutesProtected[global.METHOD_DELETE] {
+		(*router).Delete(route, h)
+	}
+}
+
+func AddAdminRoutes(router *fiber.Router, postMiddlewares ...fiber.Handler) {
+	for route, h := range methodRoutesAdmin[global.METHOD_GET] {
+		(*router).Get(route, h)
+	}
+	for route, h := range methodRoutesAdmin[global.METHOD_POST] {
+		(*router).Post(route, append(postMiddlewares, h)...)
+	}
+	for route, h := range methodRoutesAdmin[global.METHOD_PUT] {
+		(*router).Put(route, h)
+	}
+	for route, h := range methodRoutesAdmin[global.METHOD_DELETE] {
+		(*router).Delete(route, h)
 	}
 }
@@ -2,8 +2,14 @@ package http
 
 import (
 	"assignment/datastore/mysql"
+	"assignment/interface/http/middleware/admin"
 	"assignment/interface/http/middleware/auth"
+	"assignment/interface/http/middleware/idempotency"
+	"assignment/interface/http/middleware/language"
+	"assignment/jwt"
+	"context"
 	"os"
+	"time"
 
 	"assignment/global"
 	"assignment/interface/http/api"
@@ -17,7 +23,13 @@ import (
 	"github.com/spf13/viper"
 )
 
+const defaultRevocationRefreshInterval = 10 * time.Second
+
+// Admin requests have no user, every holder of the admin key shares one idempotency scope
+const adminIdempotencyScope = "admin"
+
 var AppServer *fiber.App
+var stopRevocations context.CancelFunc
 var methodRoutes map[string]map[string]global.HandlerFunc
 
 func InitHttpServer() {
@@ -38,17 +50,23 @@ func InitHttpServer() {
 	}))
 	AppServer.Use(log.New())
 	AppServer.Use(recover.New())
+	AppServer.Use(language.New())
 
 	// Config Default Path
 	AddRoute()
 
-	// Add Api Path
+	// Add Api Path, retried POST requests carrying the same Idempotency-Key are replayed except on public routes
+	// whose responses hold credentials and have no user to scope the key by, those reject the header
 	apiGroupPublic := AppServer.Group("/api")
-	api.AddPublicRoute(&apiGroupPublic)
+	api.AddPublicRoute(&apiGroupPublic, idempotency.New(mysql.DB, ""))
+
+	apiGroupAdmin := AppServer.Group("/api/admin")
+	apiGroupAdmin.Use("", admin.ApiKeyAuth(viper.GetString("Admin.ApiKey")))
+	api.AddAdminRoute(&apiGroupAdmin, idempotency.New(mysql.DB, adminIdempotencyScope))
 
 	apiGroupProtected := AppServer.Group("/api")
-	apiGroupProtected.Use("", auth.DBTokenAuth(mysql.DB))
-	api.AddProtectedRoute(&apiGroupProtected)
+	apiGroupProtected.Use("", newAuthMiddleware())
+	api.AddProtectedRoute(&apiGroupProtected, idempotency.New(mysql.DB, ""))
 
 	// Start Server
 	logger.Logger.Infof("serving http at http://127.0.0.1:%s", httpPort)
@@ -61,9 +79,29 @@ func InitHttpServer() {
 
 }
 
+func newAuthMiddleware() fiber.Handler {
+	if global.AuthMode != global.AUTH_MODE_JWT {
+		return auth.DBTokenAuth(mysql.DB)
+	}
+
+	refreshInterval := time.Duration(viper.GetInt("Auth.Jwt.RevocationRefreshSeconds")) * time.Second
+	if refreshInterval <= 0 {
+		refreshInterval = defaultRevocationRefreshInterval
+	}
+	revocations := auth.NewRevocationList(mysql.DB, global.AccessTokenLifetime+refreshInterval)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopRevocations = cancel
+	revocations.Start(ctx, refreshInterval)
+	return auth.JWTAuth(jwt.DefaultSigner, revocations)
+}
+
 func ShutdownHttpServer() {
 	logger.Logger.Infof("http server is shutting down")
-	if err := AppServer.Shutdown(); err != nil {
+	err := AppServer.Shutdown()
+	if stopRevocations != nil {
+		stopRevocations()
+	}
+	if err != nil {
 		logger.Logger.Infof("http server shut down failed: %s", err)
 		return
 	}
@@ -0,0 +1,25 @@
+package admin
+
+import (
+	"assignment/global"
+	"crypto/subtle"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ApiKeyAuth guards internal routes used by support staff and back office tools.
+// The routes are disabled altogether when no key is configured.
+func ApiKeyAuth(apiKey string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if apiKey == "" {
+			return c.SendStatus(fiber.StatusNotFound)
+		}
+
+		key := c.Get(global.HEADER_ADMIN_KEY)
+		if subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) != 1 {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"message": "invalid admin key",
+			})
+		}
+		return c.Next()
+	}
+}
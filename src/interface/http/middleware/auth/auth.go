@@ -3,6 +3,7 @@ package auth
 import (
 	"assignment/entity"
 	"assignment/global"
+	"assignment/util"
 	"errors"
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -23,7 +24,7 @@ func DBTokenAuth(db *gorm.DB) fiber.Handler {
 		now := time.Now()
 
 		err := db.WithContext(c.Context()).
-			Where("session_id = ?", tokenStr).
+			Where("session_id = ?", util.HashSessionToken(tokenStr)).
 			Where("expired_at > ?", now).
 			Take(&token).Error
 
@@ -38,13 +39,14 @@ func DBTokenAuth(db *gorm.DB) fiber.Handler {
 			return c.SendStatus(fiber.StatusInternalServerError)
 		}
 
+		// Access tokens are short-lived and only renewed through the refresh token
 		_ = db.Model(&entity.Tokens{}).
 			Where("session_id = ?", token.SessionId).
-			Update("expired_at", now.Add(720*time.Minute)).Error
+			UpdateColumn("last_seen_at", now).Error
 
 		// Put user info into request context
 		c.Locals(global.KEY_USER_ID, token.UserId)
-		c.Locals("session_id", token.SessionId)
+		c.Locals(global.KEY_SESSION_ID, token.SessionId)
 		return c.Next()
 	}
 }
@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"assignment/global"
+	"assignment/jwt"
+	"assignment/util"
+	"github.com/gofiber/fiber/v2"
+	"strings"
+	"time"
+)
+
+// JWTAuth verifies signed access tokens in-process. The database is only involved through the revocation
+// list, so last_seen_at of a session is not updated in this mode. Until the list has loaded no token is
+// accepted, a revoked one could not be told apart.
+func JWTAuth(signer *jwt.Signer, revocations *RevocationList) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		auth := c.Get("Authorization")
+		if !strings.HasPrefix(auth, "Bearer ") {
+			c.Set("WWW-Authenticate", `Bearer realm="api", error="invalid_request"`)
+			return c.SendStatus(fiber.StatusUnauthorized)
+		}
+		tokenStr := strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+
+		if !revocations.Ready() {
+			return c.SendStatus(fiber.StatusServiceUnavailable)
+		}
+
+		claims, err := signer.Verify(tokenStr, time.Now())
+		if err != nil || revocations.IsRevoked(claims.SessionId) {
+			c.Set("WWW-Authenticate", `Bearer realm="api", error="invalid_token"`)
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"message": "invalid or expired token",
+			})
+		}
+
+		// Put user info into request context
+		c.Locals(global.KEY_USER_ID, claims.UserId)
+		c.Locals(global.KEY_SESSION_ID, util.HashSessionToken(tokenStr))
+		return c.Next()
+	}
+}
@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"assignment/global"
+	"assignment/jwt"
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+)
+
+func newTestSigner(t *testing.T) *jwt.Signer {
+	t.Helper()
+
+	signer, err := jwt.NewSigner("2025-01", []jwt.Key{{Kid: "2025-01", Secret: "fedcba9876543210fedcba9876543210"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return signer
+}
+
+// newJWTTestApp serves GET /me behind JWTAuth and answers with the user id it put into the context
+func newJWTTestApp(signer *jwt.Signer, revocations *RevocationList) *fiber.App {
+	app := fiber.New()
+	app.Get("/me", JWTAuth(signer, revocations), func(c *fiber.Ctx) error {
+		return c.SendString(c.Locals(global.KEY_USER_ID).(string))
+	})
+	return app
+}
+
+func requestWithToken(t *testing.T, app *fiber.App, token string) int {
+	t.Helper()
+
+	req := httptest.NewRequest(fiber.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	return resp.StatusCode
+}
+
+func TestJWTAuth(t *testing.T) {
+	db, mock := setupMockDB(t)
+	revocations := NewRevocationList(db, time.Minute)
+	mock.ExpectQuery(revokedFamiliesQuery).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(revokedFamilies("family-revoked"))
+	if err := revocations.Refresh(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	signer := newTestSigner(t)
+	app := newJWTTestApp(signer, revocations)
+
+	valid, _ := signer.Issue("user-1", "family-1", time.Now().Add(time.Minute))
+	revoked, _ := signer.Issue("user-1", "family-revoked", time.Now().Add(time.Minute))
+	expired, _ := signer.Issue("user-1", "family-1", time.Now().Add(-time.Second))
+	parts := strings.Split(valid, ".")
+	tampered := parts[0] + "." + parts[1] + "." + strings.Repeat("A", len(parts[2]))
+	otherSigner, _ := jwt.NewSigner("2025-01", []jwt.Key{{Kid: "2025-01", Secret: "0123456789abcdef0123456789abcdef"}})
+	foreign, _ := otherSigner.Issue("user-1", "family-1", time.Now().Add(time.Minute))
+
+	cases := map[string]struct {
+		token  string
+		status int
+	}{
+		"valid":       {valid, fiber.StatusOK},
+		"revoked":     {revoked, fiber.StatusUnauthorized},
+		"expired":     {expired, fiber.StatusUnauthorized},
+		"tampered":    {tampered, fiber.StatusUnauthorized},
+		"foreign key": {foreign, fiber.StatusUnauthorized},
+		"malformed":   {"not-a-token", fiber.StatusUnauthorized},
+	}
+	for name, tc := range cases {
+		if status := requestWithToken(t, app, tc.token); status != tc.status {
+			t.Errorf("%s: expected status %d, got %d", name, tc.status, status)
+		}
+	}
+}
+
+func TestJWTAuth_RejectsTokensUntilRevocationsLoaded(t *testing.T) {
+	db, _ := setupMockDB(t)
+	signer := newTestSigner(t)
+	app := newJWTTestApp(signer, NewRevocationList(db, time.Minute))
+
+	token, _ := signer.Issue("user-1", "family-1", time.Now().Add(time.Minute))
+	if status := requestWithToken(t, app, token); status != fiber.StatusServiceUnavailable {
+		t.Fatalf("expected status %d, got %d", fiber.StatusServiceUnavailable, status)
+	}
+}
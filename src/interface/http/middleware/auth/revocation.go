@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"assignment/entity"
+	"assignment/logger"
+	"context"
+	"gorm.io/gorm"
+	"sync"
+	"time"
+)
+
+// RevocationList keeps the sessions ended within the last window in memory so signed access tokens can be
+// checked without a query per request. A session ended after the last refresh is only noticed on the
+// next one, so the refresh interval bounds how long a revoked token keeps working. Until the list has loaded
+// once no session can be trusted, so it is not Ready and signed access tokens are rejected.
+type RevocationList struct {
+	db     *gorm.DB
+	window time.Duration
+
+	mu      sync.RWMutex
+	loaded  bool
+	revoked map[string]struct{}
+}
+
+// NewRevocationList should get a window of at least the access token lifetime, an access token cannot
+// outlive its session by more than that
+func NewRevocationList(db *gorm.DB, window time.Duration) *RevocationList {
+	return &RevocationList{
+		db:      db,
+		window:  window,
+		revoked: map[string]struct{}{},
+	}
+}
+
+func (list *RevocationList) Refresh(ctx context.Context) error {
+	now := time.Now()
+
+	var familyIds []string
+	if err := list.db.WithContext(ctx).Model(&entity.Tokens{}).
+		Where("family_id <> ''").
+		Where("session_expired_at <= ? AND session_expired_at > ?", now, now.Add(-list.window)).
+		Pluck("family_id", &familyIds).Error; err != nil {
+		return err
+	}
+
+	revoked := make(map[string]struct{}, len(familyIds))
+	for _, familyId := range familyIds {
+		revoked[familyId] = struct{}{}
+	}
+
+	list.mu.Lock()
+	list.revoked = revoked
+	list.loaded = true
+	list.mu.Unlock()
+	return nil
+}
+
+// Start loads the list once and then keeps refreshing it every interval until ctx is done. A failed first load is
+// retried on the next tick, the list is not Ready until then.
+func (list *RevocationList) Start(ctx context.Context, interval time.Duration) {
+	if err := list.Refresh(ctx); err != nil {
+		logger.Logger.Errorf("load session revocation list failed because: %s", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := list.Refresh(ctx); err != nil {
+					logger.Logger.Errorf("refresh session revocation list failed because: %s", err)
+				}
+			}
+		}
+	}()
+}
+
+// Ready reports whether the list has loaded at least once
+func (list *RevocationList) Ready() bool {
+	list.mu.RLock()
+	defer list.mu.RUnlock()
+	return list.loaded
+}
+
+func (list *RevocationList) IsRevoked(sessionId string) bool {
+	list.mu.RLock()
+	defer list.mu.RUnlock()
+	_, ok := list.revoked[sessionId]
+	return ok
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"assignment/logger"
+	fake_logger "assignment/mocks/logger"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	gorm_mysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+const revokedFamiliesQuery = "SELECT `family_id` FROM `tokens` WHERE family_id <> '' AND (session_expired_at <= ? AND session_expired_at > ?)"
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db, err := gorm.Open(gorm_mysql.New(gorm_mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm with sqlmock: %v", err)
+	}
+	return db, mock
+}
+
+func revokedFamilies(familyIds ...string) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"family_id"})
+	for _, familyId := range familyIds {
+		rows.AddRow(familyId)
+	}
+	return rows
+}
+
+func TestRevocationList_Refresh(t *testing.T) {
+	db, mock := setupMockDB(t)
+	list := NewRevocationList(db, time.Minute)
+
+	if list.Ready() {
+		t.Fatal("list must not be ready before it loaded")
+	}
+
+	mock.ExpectQuery(revokedFamiliesQuery).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(revokedFamilies("family-1"))
+	if err := list.Refresh(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !list.Ready() || !list.IsRevoked("family-1") || list.IsRevoked("family-2") {
+		t.Fatalf("unexpected list after the first load: %+v", list.revoked)
+	}
+
+	// a failed refresh keeps the last loaded list
+	mock.ExpectQuery(revokedFamiliesQuery).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnError(errors.New("connection refused"))
+	if err := list.Refresh(context.Background()); err == nil {
+		t.Fatal("expected the refresh to fail")
+	}
+	if !list.Ready() || !list.IsRevoked("family-1") {
+		t.Fatalf("unexpected list after a failed refresh: %+v", list.revoked)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestRevocationList_StartRetriesFailedFirstLoad(t *testing.T) {
+	origLogger := logger.Logger
+	logger.Logger = fake_logger.NewLogger()
+	defer func() { logger.Logger = origLogger }()
+
+	db, mock := setupMockDB(t)
+	list := NewRevocationList(db, time.Minute)
+
+	mock.ExpectQuery(revokedFamiliesQuery).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnError(errors.New("connection refused"))
+	mock.ExpectQuery(revokedFamiliesQuery).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(revokedFamilies())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	list.Start(ctx, 10*time.Millisecond)
+	if list.Ready() {
+		t.Fatal("list must not be ready after a failed first load")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for !list.Ready() {
+		if time.Now().After(deadline) {
+			t.Fatal("list was not loaded on the next tick")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+package idempotency
+
+import (
+	"assignment/entity"
+	"assignment/global"
+	"assignment/interface/http/middleware/language"
+	"assignment/interface/http/response"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"time"
+)
+
+const (
+	defaultExpireMinutes = 1440
+	maxKeyLength         = 255
+)
+
+// New records the Idempotency-Key header of a request together with a fingerprint of its body and the
+// response produced by the handler. A retry with the same key and body replays the stored response
+// instead of running the handler again, while reusing the key with another body is rejected.
+// Requests without the header are passed through untouched. A replay is the stored response as it was, in the
+// language it was produced in, so its Content-Language is the stored one whatever the retry asks for.
+//
+// Keys are scoped by the logged-in user. Requests without a user use defaultScope, e.g. the admin routes, and are
+// rejected with IdempotencyKeyNotSupported when it is empty: public routes must never share their stored responses
+// with whoever guesses the key, and a client must not believe its retry is safe when it is not.
+func New(db *gorm.DB, defaultScope string) fiber.Handler {
+	expireMinutes := viper.GetInt("Idempotency.ExpireMinutes")
+	if expireMinutes <= 0 {
+		expireMinutes = defaultExpireMinutes
+	}
+
+	return func(c *fiber.Ctx) error {
+		scope, _ := c.Locals(global.KEY_USER_ID).(string)
+		if scope == "" {
+			scope = defaultScope
+		}
+		key := strings.TrimSpace(c.Get(global.HEADER_IDEMPOTENCY_KEY))
+		if key == "" {
+			return c.Next()
+		}
+		if scope == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(response.ResponseOutput{
+				Code:    global.IdempotencyKeyNotSupported,
+				Message: global.GetLocalizedErrorMessage(language.FromContext(c), global.IdempotencyKeyNotSupported),
+			})
+		}
+		if len(key) > maxKeyLength {
+			return c.Status(fiber.StatusBadRequest).JSON(response.ResponseOutput{
+				Code:    global.InvalidIdempotencyKey,
+				Message: global.GetLocalizedErrorMessage(language.FromContext(c), global.InvalidIdempotencyKey, maxKeyLength),
+			})
+		}
+
+		requestHash := fingerprint(c)
+		now := time.Now()
+
+		var record entity.IdempotencyKeys
+		err := db.WithContext(c.Context()).
+			Where("scope = ? AND idempotency_key = ?", scope, key).
+			Where("expired_at > ?", now).
+			Take(&record).Error
+		if err == nil {
+			return replay(c, record, requestHash)
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+
+		// Free the key if it is only held by an expired record
+		if err := db.WithContext(c.Context()).
+			Where("scope = ? AND idempotency_key = ?", scope, key).
+			Where("expired_at <= ?", now).
+			Delete(&entity.IdempotencyKeys{}).Error; err != nil {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+
+		// Reserve the key before running the handler so a concurrent retry cannot run it twice
+		record = entity.IdempotencyKeys{
+			Scope:          scope,
+			IdempotencyKey: key,
+			RequestHash:    requestHash,
+			Language:       language.FromContext(c),
+			ExpiredAt:      now.Add(time.Duration(expireMinutes) * time.Minute),
+		}
+		result := db.WithContext(c.Context()).Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+		if result.Error != nil {
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+		if result.RowsAffected == 0 {
+			return c.Status(fiber.StatusConflict).JSON(response.ResponseOutput{
+				Code:    global.IdempotencyKeyInProgress,
+				Message: global.GetLocalizedErrorMessage(language.FromContext(c), global.IdempotencyKeyInProgress),
+			})
+		}
+
+		err = c.Next()
+
+		// Server errors are not remembered so the client can retry them, neither are responses that must not be
+		// stored anywhere such as revealed card data or issued tokens
+		statusCode := c.Response().StatusCode()
+		noStore := strings.Contains(c.GetRespHeader(fiber.HeaderCacheControl), "no-store")
+		if err != nil || statusCode >= fiber.StatusInternalServerError || noStore {
+			_ = db.Delete(&record).Error
+			return err
+		}
+
+		// A reservation that cannot be filled is released, a retry would be answered as in progress until it expires
+		if err := db.Model(&record).Updates(map[string]interface{}{
+			"status_code":   statusCode,
+			"response_body": string(c.Response().Body()),
+		}).Error; err != nil {
+			_ = db.Delete(&record).Error
+		}
+		return nil
+	}
+}
+
+func replay(c *fiber.Ctx, record entity.IdempotencyKeys, requestHash string) error {
+	if record.RequestHash != requestHash {
+		return c.Status(fiber.StatusConflict).JSON(response.ResponseOutput{
+			Code:    global.IdempotencyKeyConflict,
+			Message: global.GetLocalizedErrorMessage(language.FromContext(c), global.IdempotencyKeyConflict),
+		})
+	}
+	if record.StatusCode == 0 {
+		return c.Status(fiber.StatusConflict).JSON(response.ResponseOutput{
+			Code:    global.IdempotencyKeyInProgress,
+			Message: global.GetLocalizedErrorMessage(language.FromContext(c), global.IdempotencyKeyInProgress),
+		})
+	}
+
+	c.Set(global.HEADER_IDEMPOTENT_REPLAYED, "true")
+	if record.Language != "" {
+		c.Set(global.HEADER_CONTENT_LANGUAGE, record.Language)
+	}
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Status(record.StatusCode).SendString(record.ResponseBody)
+}
+
+func fingerprint(c *fiber.Ctx) string {
+	hash := sha256.New()
+	hash.Write([]byte(c.Method()))
+	hash.Write([]byte("\n"))
+	hash.Write([]byte(c.Path()))
+	hash.Write([]byte("\n"))
+	hash.Write(c.Body())
+	return hex.EncodeToString(hash.Sum(nil))
+}
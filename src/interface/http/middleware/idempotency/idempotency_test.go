@@ -0,0 +1,242 @@
+package idempotency
+
+import (
+	"assignment/global"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	gorm_mysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db, err := gorm.Open(gorm_mysql.New(gorm_mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm with sqlmock: %v", err)
+	}
+	return db, mock
+}
+
+// newTestApp serves POST /transfer behind the middleware in English, userId empty means a request without a user
+func newTestApp(db *gorm.DB, userId, defaultScope string, handler fiber.Handler) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(global.KEY_USER_ID, userId)
+		c.Locals(global.KEY_LANGUAGE, "en")
+		c.Set(global.HEADER_CONTENT_LANGUAGE, "en")
+		return c.Next()
+	})
+	app.Post("/transfer", New(db, defaultScope), handler)
+	return app
+}
+
+func send(t *testing.T, app *fiber.App, body string) (int, string, http.Header) {
+	t.Helper()
+
+	req := httptest.NewRequest(fiber.MethodPost, "/transfer", strings.NewReader(body))
+	req.Header.Set(global.HEADER_IDEMPOTENCY_KEY, "key-1")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	respBody, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(respBody), resp.Header
+}
+
+func fingerprintOf(body string) string {
+	app := fiber.New()
+	var hash string
+	app.Post("/transfer", func(c *fiber.Ctx) error {
+		hash = fingerprint(c)
+		return nil
+	})
+	_, _ = app.Test(httptest.NewRequest(fiber.MethodPost, "/transfer", strings.NewReader(body)))
+	return hash
+}
+
+const (
+	selectKeyQuery        = "SELECT * FROM `idempotency_keys` WHERE (scope = ? AND idempotency_key = ?) AND expired_at > ? LIMIT ?"
+	deleteExpiredKeyQuery = "DELETE FROM `idempotency_keys` WHERE (scope = ? AND idempotency_key = ?) AND expired_at <= ?"
+	insertKeyQuery        = "INSERT INTO `idempotency_keys` (`scope`,`idempotency_key`,`request_hash`,`language`,`status_code`,`response_body`,`created_at`,`expired_at`) VALUES (?,?,?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `scope`=`scope`"
+)
+
+// storedRecord is a response the middleware stored for a request in Thai
+func storedRecord(requestHash string, statusCode int, body string) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"scope", "idempotency_key", "request_hash", "language", "status_code", "response_body", "expired_at"}).
+		AddRow("user-1", "key-1", requestHash, "th", statusCode, body, time.Now().Add(time.Hour))
+}
+
+func unexpectedHandler(t *testing.T) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		t.Fatalf("handler must not run")
+		return nil
+	}
+}
+
+func TestNew_ReplaysStoredResponse(t *testing.T) {
+	db, mock := setupMockDB(t)
+	app := newTestApp(db, "user-1", "", unexpectedHandler(t))
+
+	mock.ExpectQuery(selectKeyQuery).
+		WithArgs("user-1", "key-1", sqlmock.AnyArg(), 1).
+		WillReturnRows(storedRecord(fingerprintOf(`{"amount":"10"}`), fiber.StatusOK, `{"code":0,"message":"success"}`))
+
+	status, body, headers := send(t, app, `{"amount":"10"}`)
+	if status != fiber.StatusOK || body != `{"code":0,"message":"success"}` {
+		t.Fatalf("unexpected replay: %d %s", status, body)
+	}
+	if headers.Get(global.HEADER_IDEMPOTENT_REPLAYED) != "true" {
+		t.Fatalf("expected %s header", global.HEADER_IDEMPOTENT_REPLAYED)
+	}
+	if language := headers.Get(global.HEADER_CONTENT_LANGUAGE); language != "th" {
+		t.Fatalf("expected the language of the stored response, got %q", language)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestNew_RejectsKeyReusedWithAnotherBody(t *testing.T) {
+	db, mock := setupMockDB(t)
+	app := newTestApp(db, "user-1", "", unexpectedHandler(t))
+
+	mock.ExpectQuery(selectKeyQuery).
+		WithArgs("user-1", "key-1", sqlmock.AnyArg(), 1).
+		WillReturnRows(storedRecord(fingerprintOf(`{"amount":"10"}`), fiber.StatusOK, `{"code":0,"message":"success"}`))
+
+	status, body, _ := send(t, app, `{"amount":"99"}`)
+	if status != fiber.StatusConflict || !strings.Contains(body, `"code":16`) {
+		t.Fatalf("expected IdempotencyKeyConflict, got %d %s", status, body)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestNew_RejectsKeyInProgress(t *testing.T) {
+	db, mock := setupMockDB(t)
+	app := newTestApp(db, "user-1", "", unexpectedHandler(t))
+
+	mock.ExpectQuery(selectKeyQuery).
+		WithArgs("user-1", "key-1", sqlmock.AnyArg(), 1).
+		WillReturnRows(storedRecord(fingerprintOf(`{"amount":"10"}`), 0, ""))
+
+	status, body, _ := send(t, app, `{"amount":"10"}`)
+	if status != fiber.StatusConflict || !strings.Contains(body, `"code":17`) {
+		t.Fatalf("expected IdempotencyKeyInProgress, got %d %s", status, body)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestNew_RejectsKeyReservedConcurrently(t *testing.T) {
+	db, mock := setupMockDB(t)
+	app := newTestApp(db, "user-1", "", unexpectedHandler(t))
+
+	mock.ExpectQuery(selectKeyQuery).WithArgs("user-1", "key-1", sqlmock.AnyArg(), 1).WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectBegin()
+	mock.ExpectExec(deleteExpiredKeyQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(insertKeyQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	status, body, _ := send(t, app, `{"amount":"10"}`)
+	if status != fiber.StatusConflict || !strings.Contains(body, `"code":17`) {
+		t.Fatalf("expected IdempotencyKeyInProgress, got %d %s", status, body)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestNew_DoesNotStoreNoStoreResponse(t *testing.T) {
+	db, mock := setupMockDB(t)
+	app := newTestApp(db, "user-1", "", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderCacheControl, "no-store")
+		return c.SendString(`{"token":"secret"}`)
+	})
+
+	mock.ExpectQuery(selectKeyQuery).WithArgs("user-1", "key-1", sqlmock.AnyArg(), 1).WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectBegin()
+	mock.ExpectExec(deleteExpiredKeyQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(insertKeyQuery).
+		WithArgs("user-1", "key-1", sqlmock.AnyArg(), "en", 0, "", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM `idempotency_keys` WHERE (`idempotency_keys`.`scope`,`idempotency_keys`.`idempotency_key`) IN ((?,?))").WithArgs("user-1", "key-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// the reserved key is released instead of being filled with the response
+	status, body, _ := send(t, app, `{"amount":"10"}`)
+	if status != fiber.StatusOK || body != `{"token":"secret"}` {
+		t.Fatalf("unexpected response: %d %s", status, body)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestNew_RejectsKeyWithoutScope(t *testing.T) {
+	db, mock := setupMockDB(t)
+	app := newTestApp(db, "", "", unexpectedHandler(t))
+
+	status, body, _ := send(t, app, `{"user_id":"user-1"}`)
+	if status != fiber.StatusBadRequest || !strings.Contains(body, `"code":61`) {
+		t.Fatalf("expected IdempotencyKeyNotSupported, got %d %s", status, body)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expected no queries: %v", err)
+	}
+}
+
+func TestNew_ReleasesKeyWhenResponseCannotBeStored(t *testing.T) {
+	db, mock := setupMockDB(t)
+	app := newTestApp(db, "user-1", "", func(c *fiber.Ctx) error {
+		return c.SendString(`{"code":0,"message":"success"}`)
+	})
+
+	mock.ExpectQuery(selectKeyQuery).WithArgs("user-1", "key-1", sqlmock.AnyArg(), 1).WillReturnRows(sqlmock.NewRows(nil))
+	mock.ExpectBegin()
+	mock.ExpectExec(deleteExpiredKeyQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(insertKeyQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `idempotency_keys` SET `response_body`=?,`status_code`=? WHERE `scope` = ? AND `idempotency_key` = ?").WillReturnError(errors.New("connection lost"))
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM `idempotency_keys` WHERE (`idempotency_keys`.`scope`,`idempotency_keys`.`idempotency_key`) IN ((?,?))").WithArgs("user-1", "key-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	status, body, _ := send(t, app, `{"amount":"10"}`)
+	if status != fiber.StatusOK || body != `{"code":0,"message":"success"}` {
+		t.Fatalf("unexpected response: %d %s", status, body)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
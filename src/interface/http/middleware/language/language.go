@@ -0,0 +1,28 @@
+package language
+
+import (
+	"assignment/global"
+	"assignment/locale"
+	"github.com/gofiber/fiber/v2"
+)
+
+// New negotiates the response language from the Accept-Language header. The language is kept in Locals for the
+// handlers and in the user context for the controllers, Content-Language tells the client which one was picked.
+func New() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		language := locale.Negotiate(c.Get(global.HEADER_ACCEPT_LANGUAGE), global.SupportedLanguages, global.DefaultLanguage)
+
+		c.Locals(global.KEY_LANGUAGE, language)
+		c.SetUserContext(locale.WithLanguage(c.UserContext(), language))
+		c.Set(global.HEADER_CONTENT_LANGUAGE, language)
+		c.Vary(global.HEADER_ACCEPT_LANGUAGE)
+
+		return c.Next()
+	}
+}
+
+// FromContext is the language negotiated by New for the request
+func FromContext(c *fiber.Ctx) string {
+	language, _ := c.Locals(global.KEY_LANGUAGE).(string)
+	return language
+}
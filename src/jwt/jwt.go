@@ -0,0 +1,161 @@
+package jwt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	algorithm     = "HS256"
+	tokenType     = "JWT"
+	minSecretSize = 32
+)
+
+var (
+	ErrMalformedToken   = errors.New("malformed token")
+	ErrUnknownKey       = errors.New("token is signed with an unknown key")
+	ErrInvalidSignature = errors.New("token signature is invalid")
+	ErrTokenExpired     = errors.New("token is expired")
+)
+
+// DefaultSigner is set at start up when the service runs in jwt auth mode
+var DefaultSigner *Signer
+
+// Key is one signing key, Kid is written to the token header so the key can be found again on verify
+type Key struct {
+	Kid    string `mapstructure:"Kid"`
+	Secret string `mapstructure:"Secret"`
+}
+
+type Claims struct {
+	UserId    string `json:"sub"`
+	SessionId string `json:"sid"`
+	TokenId   string `json:"jti"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiredAt int64  `json:"exp"`
+}
+
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	Kid       string `json:"kid"`
+}
+
+// Signer signs with the active key and verifies with any configured key, so a key can be rotated by adding
+// the new key, making it active and dropping the old one once the tokens signed with it have expired
+type Signer struct {
+	activeKid string
+	keys      map[string][]byte
+}
+
+func NewSigner(activeKid string, keys []Key) (*Signer, error) {
+	signer := &Signer{activeKid: activeKid, keys: make(map[string][]byte, len(keys))}
+	for _, key := range keys {
+		if key.Kid == "" {
+			return nil, errors.New("signing key without kid")
+		}
+		if len(key.Secret) < minSecretSize {
+			return nil, fmt.Errorf("secret of signing key %s must be at least %d bytes", key.Kid, minSecretSize)
+		}
+		if _, ok := signer.keys[key.Kid]; ok {
+			return nil, fmt.Errorf("duplicate signing key %s", key.Kid)
+		}
+		signer.keys[key.Kid] = []byte(key.Secret)
+	}
+	if _, ok := signer.keys[activeKid]; !ok {
+		return nil, fmt.Errorf("active signing key %q is not configured", activeKid)
+	}
+	return signer, nil
+}
+
+func (signer *Signer) Sign(claims Claims) (string, error) {
+	headerJson, err := json.Marshal(header{Algorithm: algorithm, Type: tokenType, Kid: signer.activeKid})
+	if err != nil {
+		return "", err
+	}
+	claimsJson, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encode(headerJson) + "." + encode(claimsJson)
+	return signingInput + "." + encode(sign(signer.keys[signer.activeKid], signingInput)), nil
+}
+
+// Issue signs an access token for a session, it lets the signer be used as the session's token issuer
+func (signer *Signer) Issue(userId, sessionId string, expiredAt time.Time) (string, error) {
+	now := time.Now()
+	return signer.Sign(Claims{
+		UserId:    userId,
+		SessionId: sessionId,
+		TokenId:   uuid.NewString(),
+		IssuedAt:  now.Unix(),
+		ExpiredAt: expiredAt.Unix(),
+	})
+}
+
+func (signer *Signer) Verify(token string, now time.Time) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, ErrMalformedToken
+	}
+
+	var tokenHeader header
+	if err := decodeJson(parts[0], &tokenHeader); err != nil {
+		return Claims{}, ErrMalformedToken
+	}
+	// Never let the token pick its own algorithm
+	if tokenHeader.Algorithm != algorithm {
+		return Claims{}, ErrMalformedToken
+	}
+	secret, ok := signer.keys[tokenHeader.Kid]
+	if !ok {
+		return Claims{}, ErrUnknownKey
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Claims{}, ErrMalformedToken
+	}
+	if subtle.ConstantTimeCompare(signature, sign(secret, parts[0]+"."+parts[1])) != 1 {
+		return Claims{}, ErrInvalidSignature
+	}
+
+	var claims Claims
+	if err := decodeJson(parts[1], &claims); err != nil {
+		return Claims{}, ErrMalformedToken
+	}
+	if claims.UserId == "" || claims.SessionId == "" {
+		return Claims{}, ErrMalformedToken
+	}
+	if now.Unix() >= claims.ExpiredAt {
+		return Claims{}, ErrTokenExpired
+	}
+	return claims, nil
+}
+
+func sign(secret []byte, signingInput string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+	return mac.Sum(nil)
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeJson(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
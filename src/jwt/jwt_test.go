@@ -0,0 +1,92 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	oldKey = Key{Kid: "2024-12", Secret: "0123456789abcdef0123456789abcdef"}
+	newKey = Key{Kid: "2025-01", Secret: "fedcba9876543210fedcba9876543210"}
+)
+
+func TestSigner_IssueAndVerify(t *testing.T) {
+	signer, err := NewSigner(newKey.Kid, []Key{oldKey, newKey})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expiredAt := time.Now().Add(15 * time.Minute)
+	token, err := signer.Issue("user-1", "family-1", expiredAt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	claims, err := signer.Verify(token, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claims.UserId != "user-1" || claims.SessionId != "family-1" || claims.ExpiredAt != expiredAt.Unix() || claims.TokenId == "" {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+
+	if _, err := signer.Verify(token, expiredAt); !errors.Is(err, ErrTokenExpired) {
+		t.Fatalf("expected ErrTokenExpired, got %v", err)
+	}
+}
+
+func TestSigner_KeyRotation(t *testing.T) {
+	before, _ := NewSigner(oldKey.Kid, []Key{oldKey})
+	during, _ := NewSigner(newKey.Kid, []Key{oldKey, newKey})
+	after, _ := NewSigner(newKey.Kid, []Key{newKey})
+
+	token, _ := before.Issue("user-1", "family-1", time.Now().Add(time.Minute))
+
+	if _, err := during.Verify(token, time.Now()); err != nil {
+		t.Fatalf("token of the previous key must verify while both keys are configured: %v", err)
+	}
+	if _, err := after.Verify(token, time.Now()); !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("expected ErrUnknownKey once the old key is removed, got %v", err)
+	}
+}
+
+func TestSigner_RejectsTampering(t *testing.T) {
+	signer, _ := NewSigner(newKey.Kid, []Key{newKey})
+	token, _ := signer.Issue("user-1", "family-1", time.Now().Add(time.Minute))
+	parts := strings.Split(token, ".")
+
+	forgedClaims := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"user-2","sid":"family-1","exp":9999999999}`))
+	if _, err := signer.Verify(parts[0]+"."+forgedClaims+"."+parts[2], time.Now()); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+
+	noneHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT","kid":"2025-01"}`))
+	if _, err := signer.Verify(noneHeader+"."+parts[1]+".", time.Now()); !errors.Is(err, ErrMalformedToken) {
+		t.Fatalf("expected ErrMalformedToken for alg none, got %v", err)
+	}
+
+	if _, err := signer.Verify("not-a-token", time.Now()); !errors.Is(err, ErrMalformedToken) {
+		t.Fatalf("expected ErrMalformedToken, got %v", err)
+	}
+}
+
+func TestNewSigner_Validation(t *testing.T) {
+	cases := map[string]struct {
+		activeKid string
+		keys      []Key
+	}{
+		"missing active key": {activeKid: "other", keys: []Key{newKey}},
+		"short secret":       {activeKid: "short", keys: []Key{{Kid: "short", Secret: "too-short"}}},
+		"duplicate kid":      {activeKid: newKey.Kid, keys: []Key{newKey, newKey}},
+		"empty kid":          {activeKid: "", keys: []Key{{Secret: newKey.Secret}}},
+	}
+
+	for name, tc := range cases {
+		if _, err := NewSigner(tc.activeKid, tc.keys); err == nil {
+			t.Fatalf("%s: expected error, got nil", name)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package locale
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type contextKey struct{}
+
+// Base drops the region and lowercases the tag, e.g. th-TH becomes th
+func Base(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
+
+// Negotiate picks the supported language the Accept-Language header prefers most. Tags are matched on their base
+// language, ties keep the order of the header and fallback is returned when nothing matches.
+func Negotiate(acceptLanguage string, supported []string, fallback string) string {
+	best, bestQuality := fallback, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		language := Base(tag)
+		if language == "*" {
+			language = fallback
+		}
+		if quality > bestQuality && slices.Contains(supported, language) {
+			best, bestQuality = language, quality
+		}
+	}
+	return best
+}
+
+// Chain lists the languages to try for a text in order: the language itself, its configured fallbacks and finally
+// the default language
+func Chain(language string, fallbacks map[string][]string, defaultLanguage string) []string {
+	chain := []string{language}
+	for _, fallback := range fallbacks[language] {
+		if !slices.Contains(chain, fallback) {
+			chain = append(chain, fallback)
+		}
+	}
+	if !slices.Contains(chain, defaultLanguage) {
+		chain = append(chain, defaultLanguage)
+	}
+	return chain
+}
+
+func WithLanguage(ctx context.Context, language string) context.Context {
+	return context.WithValue(ctx, contextKey{}, language)
+}
+
+// FromContext returns the negotiated language of the request, empty when there is none
+func FromContext(ctx context.Context) string {
+	language, _ := ctx.Value(contextKey{}).(string)
+	return language
+}
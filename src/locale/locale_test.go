@@ -0,0 +1,57 @@
+package locale
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	supported := []string{"en", "th"}
+	for _, tc := range []struct {
+		header string
+		want   string
+	}{
+		{"", "en"},
+		{"th", "th"},
+		{"th-TH,th;q=0.9,en;q=0.8", "th"},
+		{"en-US,en;q=0.9,th;q=0.8", "en"},
+		{"fr-FR,th;q=0.5", "th"},
+		{"fr,de;q=0.9", "en"},
+		{"en;q=0.2, TH;q=0.7", "th"},
+		{"th;q=0", "en"},
+		{"th;q=abc,en;q=0.1", "en"},
+		{"*", "en"},
+	} {
+		if got := Negotiate(tc.header, supported, "en"); got != tc.want {
+			t.Errorf("Negotiate(%q) = %q, want %q", tc.header, got, tc.want)
+		}
+	}
+}
+
+func TestChain(t *testing.T) {
+	fallbacks := map[string][]string{"lo": {"th"}, "th": {"en"}}
+
+	for _, tc := range []struct {
+		language string
+		want     []string
+	}{
+		{"th", []string{"th", "en"}},
+		{"lo", []string{"lo", "th", "en"}},
+		{"en", []string{"en"}},
+		{"ja", []string{"ja", "en"}},
+	} {
+		if got := Chain(tc.language, fallbacks, "en"); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Chain(%q) = %v, want %v", tc.language, got, tc.want)
+		}
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if got := FromContext(context.Background()); got != "" {
+		t.Fatalf("expected no language, got %q", got)
+	}
+	if got := FromContext(WithLanguage(context.Background(), "th")); got != "th" {
+		t.Fatalf("expected th, got %q", got)
+	}
+}
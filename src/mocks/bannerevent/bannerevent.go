@@ -0,0 +1,20 @@
+package fake_bannerevent
+
+import "assignment/entity"
+
+type FakeRecorder struct {
+	Err    error
+	Events []entity.BannerEvents
+}
+
+func NewRecorder() *FakeRecorder {
+	return &FakeRecorder{}
+}
+
+func (r *FakeRecorder) Record(event entity.BannerEvents) error {
+	if r.Err != nil {
+		return r.Err
+	}
+	r.Events = append(r.Events, event)
+	return nil
+}
@@ -9,8 +9,10 @@ import (
 	mysql "assignment/model/mysql"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	decimal "github.com/shopspring/decimal"
 )
 
 // MockModelRepository is a mock of ModelRepository interface.
@@ -36,6 +38,49 @@ func (m *MockModelRepository) EXPECT() *MockModelRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AuditCardReveal mocks base method.
+func (m *MockModelRepository) AuditCardReveal(ctx context.Context, userId, cardId, event, ipAddress string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuditCardReveal", ctx, userId, cardId, event, ipAddress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuditCardReveal indicates an expected call of AuditCardReveal.
+func (mr *MockModelRepositoryMockRecorder) AuditCardReveal(ctx, userId, cardId, event, ipAddress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditCardReveal", reflect.TypeOf((*MockModelRepository)(nil).AuditCardReveal), ctx, userId, cardId, event, ipAddress)
+}
+
+// ChangeUserPin mocks base method.
+func (m *MockModelRepository) ChangeUserPin(ctx context.Context, userId, hashedPin string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeUserPin", ctx, userId, hashedPin)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeUserPin indicates an expected call of ChangeUserPin.
+func (mr *MockModelRepositoryMockRecorder) ChangeUserPin(ctx, userId, hashedPin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeUserPin", reflect.TypeOf((*MockModelRepository)(nil).ChangeUserPin), ctx, userId, hashedPin)
+}
+
+// CloseSavingsGoal mocks base method.
+func (m *MockModelRepository) CloseSavingsGoal(ctx context.Context, userId, goalId string) (mysql.SavingsGoal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSavingsGoal", ctx, userId, goalId)
+	ret0, _ := ret[0].(mysql.SavingsGoal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseSavingsGoal indicates an expected call of CloseSavingsGoal.
+func (mr *MockModelRepositoryMockRecorder) CloseSavingsGoal(ctx, userId, goalId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSavingsGoal", reflect.TypeOf((*MockModelRepository)(nil).CloseSavingsGoal), ctx, userId, goalId)
+}
+
 // ConfigureRequestId mocks base method.
 func (m *MockModelRepository) ConfigureRequestId(requestId *string) {
 	m.ctrl.T.Helper()
@@ -60,6 +105,349 @@ func (mr *MockModelRepositoryMockRecorder) ConfigureUserId(userId interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureUserId", reflect.TypeOf((*MockModelRepository)(nil).ConfigureUserId), userId)
 }
 
+// CountCardRevealEvents mocks base method.
+func (m *MockModelRepository) CountCardRevealEvents(ctx context.Context, userId, event string, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCardRevealEvents", ctx, userId, event, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCardRevealEvents indicates an expected call of CountCardRevealEvents.
+func (mr *MockModelRepositoryMockRecorder) CountCardRevealEvents(ctx, userId, event, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCardRevealEvents", reflect.TypeOf((*MockModelRepository)(nil).CountCardRevealEvents), ctx, userId, event, since)
+}
+
+// CountPayeeLookups mocks base method.
+func (m *MockModelRepository) CountPayeeLookups(ctx context.Context, userId string, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPayeeLookups", ctx, userId, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPayeeLookups indicates an expected call of CountPayeeLookups.
+func (mr *MockModelRepositoryMockRecorder) CountPayeeLookups(ctx, userId, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPayeeLookups", reflect.TypeOf((*MockModelRepository)(nil).CountPayeeLookups), ctx, userId, since)
+}
+
+// CreateAccountFlag mocks base method.
+func (m *MockModelRepository) CreateAccountFlag(ctx context.Context, flag entity.AccountFlags) (entity.AccountFlags, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccountFlag", ctx, flag)
+	ret0, _ := ret[0].(entity.AccountFlags)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccountFlag indicates an expected call of CreateAccountFlag.
+func (mr *MockModelRepositoryMockRecorder) CreateAccountFlag(ctx, flag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccountFlag", reflect.TypeOf((*MockModelRepository)(nil).CreateAccountFlag), ctx, flag)
+}
+
+// CreateBannerCampaign mocks base method.
+func (m *MockModelRepository) CreateBannerCampaign(ctx context.Context, campaign mysql.BannerCampaign) (mysql.BannerCampaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBannerCampaign", ctx, campaign)
+	ret0, _ := ret[0].(mysql.BannerCampaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBannerCampaign indicates an expected call of CreateBannerCampaign.
+func (mr *MockModelRepositoryMockRecorder) CreateBannerCampaign(ctx, campaign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBannerCampaign", reflect.TypeOf((*MockModelRepository)(nil).CreateBannerCampaign), ctx, campaign)
+}
+
+// CreateBannerEvents mocks base method.
+func (m *MockModelRepository) CreateBannerEvents(ctx context.Context, events []entity.BannerEvents) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBannerEvents", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBannerEvents indicates an expected call of CreateBannerEvents.
+func (mr *MockModelRepositoryMockRecorder) CreateBannerEvents(ctx, events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBannerEvents", reflect.TypeOf((*MockModelRepository)(nil).CreateBannerEvents), ctx, events)
+}
+
+// CreateCardRevealToken mocks base method.
+func (m *MockModelRepository) CreateCardRevealToken(ctx context.Context, userId, cardId string, lifetime time.Duration) (string, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCardRevealToken", ctx, userId, cardId, lifetime)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateCardRevealToken indicates an expected call of CreateCardRevealToken.
+func (mr *MockModelRepositoryMockRecorder) CreateCardRevealToken(ctx, userId, cardId, lifetime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCardRevealToken", reflect.TypeOf((*MockModelRepository)(nil).CreateCardRevealToken), ctx, userId, cardId, lifetime)
+}
+
+// CreateSavedAccount mocks base method.
+func (m *MockModelRepository) CreateSavedAccount(ctx context.Context, userId, name, accountNumber, image string) (mysql.SavedAccounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSavedAccount", ctx, userId, name, accountNumber, image)
+	ret0, _ := ret[0].(mysql.SavedAccounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSavedAccount indicates an expected call of CreateSavedAccount.
+func (mr *MockModelRepositoryMockRecorder) CreateSavedAccount(ctx, userId, name, accountNumber, image interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavedAccount", reflect.TypeOf((*MockModelRepository)(nil).CreateSavedAccount), ctx, userId, name, accountNumber, image)
+}
+
+// CreateSavingsGoal mocks base method.
+func (m *MockModelRepository) CreateSavingsGoal(ctx context.Context, userId, accountId, name string, target decimal.Decimal, deadline time.Time) (mysql.SavingsGoal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSavingsGoal", ctx, userId, accountId, name, target, deadline)
+	ret0, _ := ret[0].(mysql.SavingsGoal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSavingsGoal indicates an expected call of CreateSavingsGoal.
+func (mr *MockModelRepositoryMockRecorder) CreateSavingsGoal(ctx, userId, accountId, name, target, deadline interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavingsGoal", reflect.TypeOf((*MockModelRepository)(nil).CreateSavingsGoal), ctx, userId, accountId, name, target, deadline)
+}
+
+// CreateSessionToken mocks base method.
+func (m *MockModelRepository) CreateSessionToken(ctx context.Context, userId string, device mysql.SessionDevice, policy mysql.SessionPolicy) (mysql.SessionTokens, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSessionToken", ctx, userId, device, policy)
+	ret0, _ := ret[0].(mysql.SessionTokens)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateSessionToken indicates an expected call of CreateSessionToken.
+func (mr *MockModelRepositoryMockRecorder) CreateSessionToken(ctx, userId, device, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSessionToken", reflect.TypeOf((*MockModelRepository)(nil).CreateSessionToken), ctx, userId, device, policy)
+}
+
+// DeleteBannerCampaign mocks base method.
+func (m *MockModelRepository) DeleteBannerCampaign(ctx context.Context, campaignId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBannerCampaign", ctx, campaignId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBannerCampaign indicates an expected call of DeleteBannerCampaign.
+func (mr *MockModelRepositoryMockRecorder) DeleteBannerCampaign(ctx, campaignId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBannerCampaign", reflect.TypeOf((*MockModelRepository)(nil).DeleteBannerCampaign), ctx, campaignId)
+}
+
+// DeleteSavedAccount mocks base method.
+func (m *MockModelRepository) DeleteSavedAccount(ctx context.Context, userId, savedAccountId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSavedAccount", ctx, userId, savedAccountId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSavedAccount indicates an expected call of DeleteSavedAccount.
+func (mr *MockModelRepositoryMockRecorder) DeleteSavedAccount(ctx, userId, savedAccountId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSavedAccount", reflect.TypeOf((*MockModelRepository)(nil).DeleteSavedAccount), ctx, userId, savedAccountId)
+}
+
+// DismissBanner mocks base method.
+func (m *MockModelRepository) DismissBanner(ctx context.Context, userId, bannerId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DismissBanner", ctx, userId, bannerId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DismissBanner indicates an expected call of DismissBanner.
+func (mr *MockModelRepositoryMockRecorder) DismissBanner(ctx, userId, bannerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissBanner", reflect.TypeOf((*MockModelRepository)(nil).DismissBanner), ctx, userId, bannerId)
+}
+
+// ExpireAccountFlag mocks base method.
+func (m *MockModelRepository) ExpireAccountFlag(ctx context.Context, flagId int, at time.Time) (entity.AccountFlags, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireAccountFlag", ctx, flagId, at)
+	ret0, _ := ret[0].(entity.AccountFlags)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireAccountFlag indicates an expected call of ExpireAccountFlag.
+func (mr *MockModelRepositoryMockRecorder) ExpireAccountFlag(ctx, flagId, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireAccountFlag", reflect.TypeOf((*MockModelRepository)(nil).ExpireAccountFlag), ctx, flagId, at)
+}
+
+// GetAccountFlag mocks base method.
+func (m *MockModelRepository) GetAccountFlag(ctx context.Context, flagId int) (entity.AccountFlags, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountFlag", ctx, flagId)
+	ret0, _ := ret[0].(entity.AccountFlags)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountFlag indicates an expected call of GetAccountFlag.
+func (mr *MockModelRepositoryMockRecorder) GetAccountFlag(ctx, flagId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountFlag", reflect.TypeOf((*MockModelRepository)(nil).GetAccountFlag), ctx, flagId)
+}
+
+// GetAccountFlags mocks base method.
+func (m *MockModelRepository) GetAccountFlags(ctx context.Context, accountId string) ([]entity.AccountFlags, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountFlags", ctx, accountId)
+	ret0, _ := ret[0].([]entity.AccountFlags)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountFlags indicates an expected call of GetAccountFlags.
+func (mr *MockModelRepositoryMockRecorder) GetAccountFlags(ctx, accountId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountFlags", reflect.TypeOf((*MockModelRepository)(nil).GetAccountFlags), ctx, accountId)
+}
+
+// GetActiveSessions mocks base method.
+func (m *MockModelRepository) GetActiveSessions(ctx context.Context, userId string) ([]entity.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveSessions", ctx, userId)
+	ret0, _ := ret[0].([]entity.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveSessions indicates an expected call of GetActiveSessions.
+func (mr *MockModelRepositoryMockRecorder) GetActiveSessions(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveSessions", reflect.TypeOf((*MockModelRepository)(nil).GetActiveSessions), ctx, userId)
+}
+
+// GetBannerCampaign mocks base method.
+func (m *MockModelRepository) GetBannerCampaign(ctx context.Context, campaignId string) (entity.BannerCampaigns, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBannerCampaign", ctx, campaignId)
+	ret0, _ := ret[0].(entity.BannerCampaigns)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBannerCampaign indicates an expected call of GetBannerCampaign.
+func (mr *MockModelRepositoryMockRecorder) GetBannerCampaign(ctx, campaignId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBannerCampaign", reflect.TypeOf((*MockModelRepository)(nil).GetBannerCampaign), ctx, campaignId)
+}
+
+// GetBannerCampaigns mocks base method.
+func (m *MockModelRepository) GetBannerCampaigns(ctx context.Context) ([]mysql.BannerCampaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBannerCampaigns", ctx)
+	ret0, _ := ret[0].([]mysql.BannerCampaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBannerCampaigns indicates an expected call of GetBannerCampaigns.
+func (mr *MockModelRepositoryMockRecorder) GetBannerCampaigns(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBannerCampaigns", reflect.TypeOf((*MockModelRepository)(nil).GetBannerCampaigns), ctx)
+}
+
+// GetBannerEventReport mocks base method.
+func (m *MockModelRepository) GetBannerEventReport(ctx context.Context, from, to time.Time) ([]mysql.BannerEventStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBannerEventReport", ctx, from, to)
+	ret0, _ := ret[0].([]mysql.BannerEventStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBannerEventReport indicates an expected call of GetBannerEventReport.
+func (mr *MockModelRepositoryMockRecorder) GetBannerEventReport(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBannerEventReport", reflect.TypeOf((*MockModelRepository)(nil).GetBannerEventReport), ctx, from, to)
+}
+
+// GetBannerTranslations mocks base method.
+func (m *MockModelRepository) GetBannerTranslations(ctx context.Context, bannerIds, languages []string) ([]entity.BannerTranslations, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBannerTranslations", ctx, bannerIds, languages)
+	ret0, _ := ret[0].([]entity.BannerTranslations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBannerTranslations indicates an expected call of GetBannerTranslations.
+func (mr *MockModelRepositoryMockRecorder) GetBannerTranslations(ctx, bannerIds, languages interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBannerTranslations", reflect.TypeOf((*MockModelRepository)(nil).GetBannerTranslations), ctx, bannerIds, languages)
+}
+
+// GetCardControls mocks base method.
+func (m *MockModelRepository) GetCardControls(ctx context.Context, userId, cardId string, defaults entity.DebitCardControls) (entity.DebitCardControls, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardControls", ctx, userId, cardId, defaults)
+	ret0, _ := ret[0].(entity.DebitCardControls)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardControls indicates an expected call of GetCardControls.
+func (mr *MockModelRepositoryMockRecorder) GetCardControls(ctx, userId, cardId, defaults interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardControls", reflect.TypeOf((*MockModelRepository)(nil).GetCardControls), ctx, userId, cardId, defaults)
+}
+
+// GetGreetingTranslations mocks base method.
+func (m *MockModelRepository) GetGreetingTranslations(ctx context.Context, userId string, languages []string) ([]entity.UserGreetingTranslations, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGreetingTranslations", ctx, userId, languages)
+	ret0, _ := ret[0].([]entity.UserGreetingTranslations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGreetingTranslations indicates an expected call of GetGreetingTranslations.
+func (mr *MockModelRepositoryMockRecorder) GetGreetingTranslations(ctx, userId, languages interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGreetingTranslations", reflect.TypeOf((*MockModelRepository)(nil).GetGreetingTranslations), ctx, userId, languages)
+}
+
+// GetPinResetCode mocks base method.
+func (m *MockModelRepository) GetPinResetCode(ctx context.Context, userId string) (entity.PinResetCodes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPinResetCode", ctx, userId)
+	ret0, _ := ret[0].(entity.PinResetCodes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPinResetCode indicates an expected call of GetPinResetCode.
+func (mr *MockModelRepositoryMockRecorder) GetPinResetCode(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPinResetCode", reflect.TypeOf((*MockModelRepository)(nil).GetPinResetCode), ctx, userId)
+}
+
 // GetUser mocks base method.
 func (m *MockModelRepository) GetUser(ctx context.Context, userId string) (mysql.User, error) {
 	m.ctrl.T.Helper()
@@ -105,6 +493,36 @@ func (mr *MockModelRepositoryMockRecorder) GetUserBanners(ctx, userId interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserBanners", reflect.TypeOf((*MockModelRepository)(nil).GetUserBanners), ctx, userId)
 }
 
+// GetUserCardNumber mocks base method.
+func (m *MockModelRepository) GetUserCardNumber(ctx context.Context, userId, cardId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCardNumber", ctx, userId, cardId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCardNumber indicates an expected call of GetUserCardNumber.
+func (mr *MockModelRepositoryMockRecorder) GetUserCardNumber(ctx, userId, cardId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCardNumber", reflect.TypeOf((*MockModelRepository)(nil).GetUserCardNumber), ctx, userId, cardId)
+}
+
+// GetUserCardStatus mocks base method.
+func (m *MockModelRepository) GetUserCardStatus(ctx context.Context, userId, cardId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCardStatus", ctx, userId, cardId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCardStatus indicates an expected call of GetUserCardStatus.
+func (mr *MockModelRepositoryMockRecorder) GetUserCardStatus(ctx, userId, cardId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCardStatus", reflect.TypeOf((*MockModelRepository)(nil).GetUserCardStatus), ctx, userId, cardId)
+}
+
 // GetUserCards mocks base method.
 func (m *MockModelRepository) GetUserCards(ctx context.Context, userId string) ([]mysql.CardsWithDetails, error) {
 	m.ctrl.T.Helper()
@@ -135,33 +553,423 @@ func (mr *MockModelRepositoryMockRecorder) GetUserHashedPin(ctx, userId interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserHashedPin", reflect.TypeOf((*MockModelRepository)(nil).GetUserHashedPin), ctx, userId)
 }
 
+// GetUserProfile mocks base method.
+func (m *MockModelRepository) GetUserProfile(ctx context.Context, userId string) (mysql.UserProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProfile", ctx, userId)
+	ret0, _ := ret[0].(mysql.UserProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserProfile indicates an expected call of GetUserProfile.
+func (mr *MockModelRepositoryMockRecorder) GetUserProfile(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfile", reflect.TypeOf((*MockModelRepository)(nil).GetUserProfile), ctx, userId)
+}
+
 // GetUserSavedAccounts mocks base method.
-func (m *MockModelRepository) GetUserSavedAccounts(ctx context.Context, userId string) ([]mysql.SavedAccounts, error) {
+func (m *MockModelRepository) GetUserSavedAccounts(ctx context.Context, userId, search string) ([]mysql.SavedAccounts, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserSavedAccounts", ctx, userId)
+	ret := m.ctrl.Call(m, "GetUserSavedAccounts", ctx, userId, search)
 	ret0, _ := ret[0].([]mysql.SavedAccounts)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserSavedAccounts indicates an expected call of GetUserSavedAccounts.
-func (mr *MockModelRepositoryMockRecorder) GetUserSavedAccounts(ctx, userId interface{}) *gomock.Call {
+func (mr *MockModelRepositoryMockRecorder) GetUserSavedAccounts(ctx, userId, search interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSavedAccounts", reflect.TypeOf((*MockModelRepository)(nil).GetUserSavedAccounts), ctx, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSavedAccounts", reflect.TypeOf((*MockModelRepository)(nil).GetUserSavedAccounts), ctx, userId, search)
 }
 
-// RevokeExistingTokenAndCreateNewToken mocks base method.
-func (m *MockModelRepository) RevokeExistingTokenAndCreateNewToken(ctx context.Context, userId string) (string, string, error) {
+// GetUserTransactions mocks base method.
+func (m *MockModelRepository) GetUserTransactions(ctx context.Context, userId, cursor string, limit int) ([]entity.Transactions, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RevokeExistingTokenAndCreateNewToken", ctx, userId)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(string)
+	ret := m.ctrl.Call(m, "GetUserTransactions", ctx, userId, cursor, limit)
+	ret0, _ := ret[0].([]entity.Transactions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTransactions indicates an expected call of GetUserTransactions.
+func (mr *MockModelRepositoryMockRecorder) GetUserTransactions(ctx, userId, cursor, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTransactions", reflect.TypeOf((*MockModelRepository)(nil).GetUserTransactions), ctx, userId, cursor, limit)
+}
+
+// IsUserBanner mocks base method.
+func (m *MockModelRepository) IsUserBanner(ctx context.Context, userId, bannerId string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUserBanner", ctx, userId, bannerId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUserBanner indicates an expected call of IsUserBanner.
+func (mr *MockModelRepositoryMockRecorder) IsUserBanner(ctx, userId, bannerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserBanner", reflect.TypeOf((*MockModelRepository)(nil).IsUserBanner), ctx, userId, bannerId)
+}
+
+// LookupAccountOwner mocks base method.
+func (m *MockModelRepository) LookupAccountOwner(ctx context.Context, digits string) (mysql.AccountOwner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupAccountOwner", ctx, digits)
+	ret0, _ := ret[0].(mysql.AccountOwner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupAccountOwner indicates an expected call of LookupAccountOwner.
+func (mr *MockModelRepositoryMockRecorder) LookupAccountOwner(ctx, digits interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupAccountOwner", reflect.TypeOf((*MockModelRepository)(nil).LookupAccountOwner), ctx, digits)
+}
+
+// RecordPayeeLookup mocks base method.
+func (m *MockModelRepository) RecordPayeeLookup(ctx context.Context, userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordPayeeLookup", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordPayeeLookup indicates an expected call of RecordPayeeLookup.
+func (mr *MockModelRepositoryMockRecorder) RecordPayeeLookup(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordPayeeLookup", reflect.TypeOf((*MockModelRepository)(nil).RecordPayeeLookup), ctx, userId)
+}
+
+// RefreshSessionToken mocks base method.
+func (m *MockModelRepository) RefreshSessionToken(ctx context.Context, refreshToken string, policy mysql.SessionPolicy) (mysql.SessionTokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshSessionToken", ctx, refreshToken, policy)
+	ret0, _ := ret[0].(mysql.SessionTokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshSessionToken indicates an expected call of RefreshSessionToken.
+func (mr *MockModelRepositoryMockRecorder) RefreshSessionToken(ctx, refreshToken, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSessionToken", reflect.TypeOf((*MockModelRepository)(nil).RefreshSessionToken), ctx, refreshToken, policy)
+}
+
+// ReorderAccounts mocks base method.
+func (m *MockModelRepository) ReorderAccounts(ctx context.Context, userId string, accountIds []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderAccounts", ctx, userId, accountIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderAccounts indicates an expected call of ReorderAccounts.
+func (mr *MockModelRepositoryMockRecorder) ReorderAccounts(ctx, userId, accountIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderAccounts", reflect.TypeOf((*MockModelRepository)(nil).ReorderAccounts), ctx, userId, accountIds)
+}
+
+// ReservePinAttempt mocks base method.
+func (m *MockModelRepository) ReservePinAttempt(ctx context.Context, userId string, policy mysql.PinLockoutPolicy) (entity.UserPin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReservePinAttempt", ctx, userId, policy)
+	ret0, _ := ret[0].(entity.UserPin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReservePinAttempt indicates an expected call of ReservePinAttempt.
+func (mr *MockModelRepositoryMockRecorder) ReservePinAttempt(ctx, userId, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReservePinAttempt", reflect.TypeOf((*MockModelRepository)(nil).ReservePinAttempt), ctx, userId, policy)
+}
+
+// ReservePinResetCodeAttempt mocks base method.
+func (m *MockModelRepository) ReservePinResetCodeAttempt(ctx context.Context, userId string, maxAttempts int) (entity.PinResetCodes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReservePinResetCodeAttempt", ctx, userId, maxAttempts)
+	ret0, _ := ret[0].(entity.PinResetCodes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReservePinResetCodeAttempt indicates an expected call of ReservePinResetCodeAttempt.
+func (mr *MockModelRepositoryMockRecorder) ReservePinResetCodeAttempt(ctx, userId, maxAttempts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReservePinResetCodeAttempt", reflect.TypeOf((*MockModelRepository)(nil).ReservePinResetCodeAttempt), ctx, userId, maxAttempts)
+}
+
+// ResetPinLockout mocks base method.
+func (m *MockModelRepository) ResetPinLockout(ctx context.Context, userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPinLockout", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPinLockout indicates an expected call of ResetPinLockout.
+func (mr *MockModelRepositoryMockRecorder) ResetPinLockout(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPinLockout", reflect.TypeOf((*MockModelRepository)(nil).ResetPinLockout), ctx, userId)
+}
+
+// ResetUserPin mocks base method.
+func (m *MockModelRepository) ResetUserPin(ctx context.Context, userId, codeHash, hashedPin string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetUserPin", ctx, userId, codeHash, hashedPin)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetUserPin indicates an expected call of ResetUserPin.
+func (mr *MockModelRepositoryMockRecorder) ResetUserPin(ctx, userId, codeHash, hashedPin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetUserPin", reflect.TypeOf((*MockModelRepository)(nil).ResetUserPin), ctx, userId, codeHash, hashedPin)
+}
+
+// RevokeOtherSessions mocks base method.
+func (m *MockModelRepository) RevokeOtherSessions(ctx context.Context, userId, keepSessionId string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOtherSessions", ctx, userId, keepSessionId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeOtherSessions indicates an expected call of RevokeOtherSessions.
+func (mr *MockModelRepositoryMockRecorder) RevokeOtherSessions(ctx, userId, keepSessionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOtherSessions", reflect.TypeOf((*MockModelRepository)(nil).RevokeOtherSessions), ctx, userId, keepSessionId)
+}
+
+// RevokeSession mocks base method.
+func (m *MockModelRepository) RevokeSession(ctx context.Context, userId, sessionId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, userId, sessionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockModelRepositoryMockRecorder) RevokeSession(ctx, userId, sessionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockModelRepository)(nil).RevokeSession), ctx, userId, sessionId)
+}
+
+// SaveCardControls mocks base method.
+func (m *MockModelRepository) SaveCardControls(ctx context.Context, userId string, controls entity.DebitCardControls) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCardControls", ctx, userId, controls)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCardControls indicates an expected call of SaveCardControls.
+func (mr *MockModelRepositoryMockRecorder) SaveCardControls(ctx, userId, controls interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCardControls", reflect.TypeOf((*MockModelRepository)(nil).SaveCardControls), ctx, userId, controls)
+}
+
+// SavePinResetCode mocks base method.
+func (m *MockModelRepository) SavePinResetCode(ctx context.Context, resetCode entity.PinResetCodes) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePinResetCode", ctx, resetCode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePinResetCode indicates an expected call of SavePinResetCode.
+func (mr *MockModelRepositoryMockRecorder) SavePinResetCode(ctx, resetCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePinResetCode", reflect.TypeOf((*MockModelRepository)(nil).SavePinResetCode), ctx, resetCode)
+}
+
+// SetMainAccount mocks base method.
+func (m *MockModelRepository) SetMainAccount(ctx context.Context, userId, accountId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMainAccount", ctx, userId, accountId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMainAccount indicates an expected call of SetMainAccount.
+func (mr *MockModelRepositoryMockRecorder) SetMainAccount(ctx, userId, accountId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMainAccount", reflect.TypeOf((*MockModelRepository)(nil).SetMainAccount), ctx, userId, accountId)
+}
+
+// Transfer mocks base method.
+func (m *MockModelRepository) Transfer(ctx context.Context, userId string, request mysql.TransferRequest) (entity.Transfers, decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfer", ctx, userId, request)
+	ret0, _ := ret[0].(entity.Transfers)
+	ret1, _ := ret[1].(decimal.Decimal)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// RevokeExistingTokenAndCreateNewToken indicates an expected call of RevokeExistingTokenAndCreateNewToken.
-func (mr *MockModelRepositoryMockRecorder) RevokeExistingTokenAndCreateNewToken(ctx, userId interface{}) *gomock.Call {
+// Transfer indicates an expected call of Transfer.
+func (mr *MockModelRepositoryMockRecorder) Transfer(ctx, userId, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockModelRepository)(nil).Transfer), ctx, userId, request)
+}
+
+// UpdateAccountColor mocks base method.
+func (m *MockModelRepository) UpdateAccountColor(ctx context.Context, userId, accountId, color string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountColor", ctx, userId, accountId, color)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccountColor indicates an expected call of UpdateAccountColor.
+func (mr *MockModelRepositoryMockRecorder) UpdateAccountColor(ctx, userId, accountId, color interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountColor", reflect.TypeOf((*MockModelRepository)(nil).UpdateAccountColor), ctx, userId, accountId, color)
+}
+
+// UpdateAccountFlag mocks base method.
+func (m *MockModelRepository) UpdateAccountFlag(ctx context.Context, flagId int, value string, expiredAt *time.Time) (entity.AccountFlags, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountFlag", ctx, flagId, value, expiredAt)
+	ret0, _ := ret[0].(entity.AccountFlags)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountFlag indicates an expected call of UpdateAccountFlag.
+func (mr *MockModelRepositoryMockRecorder) UpdateAccountFlag(ctx, flagId, value, expiredAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountFlag", reflect.TypeOf((*MockModelRepository)(nil).UpdateAccountFlag), ctx, flagId, value, expiredAt)
+}
+
+// UpdateBannerCampaign mocks base method.
+func (m *MockModelRepository) UpdateBannerCampaign(ctx context.Context, campaign mysql.BannerCampaign) (mysql.BannerCampaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBannerCampaign", ctx, campaign)
+	ret0, _ := ret[0].(mysql.BannerCampaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBannerCampaign indicates an expected call of UpdateBannerCampaign.
+func (mr *MockModelRepositoryMockRecorder) UpdateBannerCampaign(ctx, campaign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBannerCampaign", reflect.TypeOf((*MockModelRepository)(nil).UpdateBannerCampaign), ctx, campaign)
+}
+
+// UpdateCardDesign mocks base method.
+func (m *MockModelRepository) UpdateCardDesign(ctx context.Context, userId, cardId, color, borderColor string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCardDesign", ctx, userId, cardId, color, borderColor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCardDesign indicates an expected call of UpdateCardDesign.
+func (mr *MockModelRepositoryMockRecorder) UpdateCardDesign(ctx, userId, cardId, color, borderColor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCardDesign", reflect.TypeOf((*MockModelRepository)(nil).UpdateCardDesign), ctx, userId, cardId, color, borderColor)
+}
+
+// UpdateCardStatus mocks base method.
+func (m *MockModelRepository) UpdateCardStatus(ctx context.Context, cardId, fromStatus, toStatus string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCardStatus", ctx, cardId, fromStatus, toStatus)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCardStatus indicates an expected call of UpdateCardStatus.
+func (mr *MockModelRepositoryMockRecorder) UpdateCardStatus(ctx, cardId, fromStatus, toStatus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCardStatus", reflect.TypeOf((*MockModelRepository)(nil).UpdateCardStatus), ctx, cardId, fromStatus, toStatus)
+}
+
+// UpdateSavedAccount mocks base method.
+func (m *MockModelRepository) UpdateSavedAccount(ctx context.Context, userId, savedAccountId string, changes mysql.SavedAccountChanges) (mysql.SavedAccounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSavedAccount", ctx, userId, savedAccountId, changes)
+	ret0, _ := ret[0].(mysql.SavedAccounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSavedAccount indicates an expected call of UpdateSavedAccount.
+func (mr *MockModelRepositoryMockRecorder) UpdateSavedAccount(ctx, userId, savedAccountId, changes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSavedAccount", reflect.TypeOf((*MockModelRepository)(nil).UpdateSavedAccount), ctx, userId, savedAccountId, changes)
+}
+
+// UpdateSavingsGoal mocks base method.
+func (m *MockModelRepository) UpdateSavingsGoal(ctx context.Context, userId, goalId string, changes mysql.SavingsGoalChanges) (mysql.SavingsGoal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSavingsGoal", ctx, userId, goalId, changes)
+	ret0, _ := ret[0].(mysql.SavingsGoal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSavingsGoal indicates an expected call of UpdateSavingsGoal.
+func (mr *MockModelRepositoryMockRecorder) UpdateSavingsGoal(ctx, userId, goalId, changes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSavingsGoal", reflect.TypeOf((*MockModelRepository)(nil).UpdateSavingsGoal), ctx, userId, goalId, changes)
+}
+
+// UpdateUserPinHash mocks base method.
+func (m *MockModelRepository) UpdateUserPinHash(ctx context.Context, userId, oldHashedPin, newHashedPin string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPinHash", ctx, userId, oldHashedPin, newHashedPin)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPinHash indicates an expected call of UpdateUserPinHash.
+func (mr *MockModelRepositoryMockRecorder) UpdateUserPinHash(ctx, userId, oldHashedPin, newHashedPin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPinHash", reflect.TypeOf((*MockModelRepository)(nil).UpdateUserPinHash), ctx, userId, oldHashedPin, newHashedPin)
+}
+
+// UpdateUserPreferences mocks base method.
+func (m *MockModelRepository) UpdateUserPreferences(ctx context.Context, userId, defaultLanguage string, changes mysql.PreferenceChanges) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPreferences", ctx, userId, defaultLanguage, changes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPreferences indicates an expected call of UpdateUserPreferences.
+func (mr *MockModelRepositoryMockRecorder) UpdateUserPreferences(ctx, userId, defaultLanguage, changes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPreferences", reflect.TypeOf((*MockModelRepository)(nil).UpdateUserPreferences), ctx, userId, defaultLanguage, changes)
+}
+
+// UpdateUserProfile mocks base method.
+func (m *MockModelRepository) UpdateUserProfile(ctx context.Context, userId string, changes mysql.ProfileChanges) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserProfile", ctx, userId, changes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserProfile indicates an expected call of UpdateUserProfile.
+func (mr *MockModelRepositoryMockRecorder) UpdateUserProfile(ctx, userId, changes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProfile", reflect.TypeOf((*MockModelRepository)(nil).UpdateUserProfile), ctx, userId, changes)
+}
+
+// UseCardRevealToken mocks base method.
+func (m *MockModelRepository) UseCardRevealToken(ctx context.Context, userId, cardId, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseCardRevealToken", ctx, userId, cardId, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseCardRevealToken indicates an expected call of UseCardRevealToken.
+func (mr *MockModelRepositoryMockRecorder) UseCardRevealToken(ctx, userId, cardId, token interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeExistingTokenAndCreateNewToken", reflect.TypeOf((*MockModelRepository)(nil).RevokeExistingTokenAndCreateNewToken), ctx, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseCardRevealToken", reflect.TypeOf((*MockModelRepository)(nil).UseCardRevealToken), ctx, userId, cardId, token)
 }
@@ -0,0 +1,29 @@
+package fake_notifier
+
+import (
+	"context"
+	"time"
+)
+
+type SentPinResetCode struct {
+	UserId    string
+	Code      string
+	ExpiredAt time.Time
+}
+
+type FakeNotifier struct {
+	Err           error
+	PinResetCodes []SentPinResetCode
+}
+
+func NewNotifier() *FakeNotifier {
+	return &FakeNotifier{}
+}
+
+func (n *FakeNotifier) SendPinResetCode(ctx context.Context, userId, code string, expiredAt time.Time) error {
+	if n.Err != nil {
+		return n.Err
+	}
+	n.PinResetCodes = append(n.PinResetCodes, SentPinResetCode{UserId: userId, Code: code, ExpiredAt: expiredAt})
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"assignment/entity"
 	model_mysql "assignment/model/mysql"
 	"context"
+	"github.com/shopspring/decimal"
+	"time"
 )
 
 type ModelRepository interface {
@@ -11,11 +13,67 @@ type ModelRepository interface {
 	ConfigureUserId(userId *string)
 
 	GetUserHashedPin(ctx context.Context, userId string) (entity.UserPin, error)
-	RevokeExistingTokenAndCreateNewToken(ctx context.Context, userId string) (string, string, error)
+	CreateSessionToken(ctx context.Context, userId string, device model_mysql.SessionDevice, policy model_mysql.SessionPolicy) (model_mysql.SessionTokens, string, error)
+	RefreshSessionToken(ctx context.Context, refreshToken string, policy model_mysql.SessionPolicy) (model_mysql.SessionTokens, error)
+	GetActiveSessions(ctx context.Context, userId string) ([]entity.Tokens, error)
+	RevokeSession(ctx context.Context, userId, sessionId string) error
+	RevokeOtherSessions(ctx context.Context, userId, keepSessionId string) (int64, error)
+	ReservePinAttempt(ctx context.Context, userId string, policy model_mysql.PinLockoutPolicy) (entity.UserPin, error)
+	ResetPinLockout(ctx context.Context, userId string) error
+	UpdateUserPinHash(ctx context.Context, userId, oldHashedPin, newHashedPin string) error
+	ChangeUserPin(ctx context.Context, userId, hashedPin string) error
+	ResetUserPin(ctx context.Context, userId, codeHash, hashedPin string) error
+	SavePinResetCode(ctx context.Context, resetCode entity.PinResetCodes) error
+	GetPinResetCode(ctx context.Context, userId string) (entity.PinResetCodes, error)
+	ReservePinResetCodeAttempt(ctx context.Context, userId string, maxAttempts int) (entity.PinResetCodes, error)
 
 	GetUserBanners(ctx context.Context, userId string) ([]entity.Banners, error)
+	IsUserBanner(ctx context.Context, userId, bannerId string) (bool, error)
+	GetBannerTranslations(ctx context.Context, bannerIds, languages []string) ([]entity.BannerTranslations, error)
+	GetBannerCampaign(ctx context.Context, campaignId string) (entity.BannerCampaigns, error)
+	GetBannerCampaigns(ctx context.Context) ([]model_mysql.BannerCampaign, error)
+	CreateBannerCampaign(ctx context.Context, campaign model_mysql.BannerCampaign) (model_mysql.BannerCampaign, error)
+	UpdateBannerCampaign(ctx context.Context, campaign model_mysql.BannerCampaign) (model_mysql.BannerCampaign, error)
+	DeleteBannerCampaign(ctx context.Context, campaignId string) error
+	CreateBannerEvents(ctx context.Context, events []entity.BannerEvents) error
+	DismissBanner(ctx context.Context, userId, bannerId string) error
+	GetBannerEventReport(ctx context.Context, from, to time.Time) ([]model_mysql.BannerEventStats, error)
 	GetUserAccounts(ctx context.Context, userId string) ([]model_mysql.AccountWithDetails, error)
 	GetUserCards(ctx context.Context, userId string) ([]model_mysql.CardsWithDetails, error)
-	GetUserSavedAccounts(ctx context.Context, userId string) ([]model_mysql.SavedAccounts, error)
+	GetUserCardStatus(ctx context.Context, userId, cardId string) (string, error)
+	UpdateCardStatus(ctx context.Context, cardId, fromStatus, toStatus string) error
+	GetUserCardNumber(ctx context.Context, userId, cardId string) (string, error)
+	CreateCardRevealToken(ctx context.Context, userId, cardId string, lifetime time.Duration) (string, time.Time, error)
+	UseCardRevealToken(ctx context.Context, userId, cardId, token string) error
+	AuditCardReveal(ctx context.Context, userId, cardId, event, ipAddress string) error
+	CountCardRevealEvents(ctx context.Context, userId, event string, since time.Time) (int64, error)
+	GetCardControls(ctx context.Context, userId, cardId string, defaults entity.DebitCardControls) (entity.DebitCardControls, error)
+	SaveCardControls(ctx context.Context, userId string, controls entity.DebitCardControls) error
+	UpdateCardDesign(ctx context.Context, userId, cardId, color, borderColor string) error
+	UpdateAccountColor(ctx context.Context, userId, accountId, color string) error
+	SetMainAccount(ctx context.Context, userId, accountId string) error
+	ReorderAccounts(ctx context.Context, userId string, accountIds []string) error
+	CreateSavingsGoal(ctx context.Context, userId, accountId, name string, target decimal.Decimal, deadline time.Time) (model_mysql.SavingsGoal, error)
+	UpdateSavingsGoal(ctx context.Context, userId, goalId string, changes model_mysql.SavingsGoalChanges) (model_mysql.SavingsGoal, error)
+	CloseSavingsGoal(ctx context.Context, userId, goalId string) (model_mysql.SavingsGoal, error)
+	GetAccountFlags(ctx context.Context, accountId string) ([]entity.AccountFlags, error)
+	GetAccountFlag(ctx context.Context, flagId int) (entity.AccountFlags, error)
+	CreateAccountFlag(ctx context.Context, flag entity.AccountFlags) (entity.AccountFlags, error)
+	UpdateAccountFlag(ctx context.Context, flagId int, value string, expiredAt *time.Time) (entity.AccountFlags, error)
+	ExpireAccountFlag(ctx context.Context, flagId int, at time.Time) (entity.AccountFlags, error)
+	GetUserSavedAccounts(ctx context.Context, userId, search string) ([]model_mysql.SavedAccounts, error)
+	CreateSavedAccount(ctx context.Context, userId, name, accountNumber, image string) (model_mysql.SavedAccounts, error)
+	UpdateSavedAccount(ctx context.Context, userId, savedAccountId string, changes model_mysql.SavedAccountChanges) (model_mysql.SavedAccounts, error)
+	DeleteSavedAccount(ctx context.Context, userId, savedAccountId string) error
+	LookupAccountOwner(ctx context.Context, digits string) (model_mysql.AccountOwner, error)
+	RecordPayeeLookup(ctx context.Context, userId string) error
+	CountPayeeLookups(ctx context.Context, userId string, since time.Time) (int64, error)
 	GetUser(ctx context.Context, userId string) (model_mysql.User, error)
+	GetGreetingTranslations(ctx context.Context, userId string, languages []string) ([]entity.UserGreetingTranslations, error)
+	GetUserProfile(ctx context.Context, userId string) (model_mysql.UserProfile, error)
+	UpdateUserProfile(ctx context.Context, userId string, changes model_mysql.ProfileChanges) error
+	UpdateUserPreferences(ctx context.Context, userId, defaultLanguage string, changes model_mysql.PreferenceChanges) error
+	GetUserTransactions(ctx context.Context, userId, cursor string, limit int) ([]entity.Transactions, error)
+
+	Transfer(ctx context.Context, userId string, request model_mysql.TransferRequest) (entity.Transfers, decimal.Decimal, error)
 }
@@ -4,7 +4,13 @@ import (
 	"assignment/datastore/mysql"
 	"assignment/entity"
 	"context"
+	"errors"
 	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"slices"
+	"strings"
+	"time"
 )
 
 type AccountWithDetails struct {
@@ -16,7 +22,9 @@ type AccountWithDetails struct {
 	Amount        decimal.Decimal `json:"amount"`
 	Color         string          `json:"color"`
 	IsMainAccount bool            `json:"is_main_account"`
-	Progress      int             `json:"progress"`
+	DisplayOrder  int             `json:"display_order"`
+	Progress      int             `json:"progress" gorm:"-"`
+	Goal          *SavingsGoal    `json:"goal" gorm:"-"`
 	Flags         []AccountFlags  `json:"flags" gorm:"-"`
 }
 
@@ -38,12 +46,13 @@ func (repository *ModelMysqlRepository) GetUserAccounts(ctx context.Context, use
 			ab.amount,
 			ad.color,
 			ad.is_main_account,
-			ad.progress
+			ad.display_order
 		`).
 		Joins("JOIN account_balances AS ab ON ab.account_id = a.account_id AND ab.user_id = a.user_id").
 		Joins("JOIN account_details  AS ad ON ad.account_id = a.account_id AND ad.user_id = a.user_id").
 		Where("a.user_id = ?", userId).
-		Order("ad.is_main_account DESC, a.account_id").
+		// Accounts are created outside this service with display order 0, they go after the ordered ones
+		Order("ad.display_order = 0, ad.display_order, a.account_id").
 		Scan(&result).Error; err != nil {
 		return nil, err
 	}
@@ -60,7 +69,7 @@ func (repository *ModelMysqlRepository) GetUserAccounts(ctx context.Context, use
 
 	var flagList []entity.AccountFlags
 	if err := mysql.DB.WithContext(ctx).
-		Where("user_id = ? AND account_id IN ?", userId, accountIds).
+		Where("user_id = ? AND account_id IN ? AND (expired_at IS NULL OR expired_at > ?)", userId, accountIds, time.Now()).
 		Order("account_id, flag_type, flag_value").
 		Find(&flagList).Error; err != nil {
 		return nil, err
@@ -75,6 +84,22 @@ func (repository *ModelMysqlRepository) GetUserAccounts(ctx context.Context, use
 		}
 	}
 
+	// progress is only meaningful towards an active savings goal
+	var goals []entity.SavingsGoals
+	if err := mysql.DB.WithContext(ctx).
+		Where("user_id = ? AND account_id IN ? AND status = ?", userId, accountIds, SavingsGoalStatusActive).
+		Find(&goals).Error; err != nil {
+		return nil, err
+	}
+
+	for _, goal := range goals {
+		if idx, ok := accountIndex[goal.AccountId]; ok {
+			savingsGoal := newSavingsGoal(goal, result[idx].Amount)
+			result[idx].Goal = &savingsGoal
+			result[idx].Progress = savingsGoal.Progress
+		}
+	}
+
 	return result, nil
 }
 
@@ -113,17 +138,153 @@ func (repository *ModelMysqlRepository) GetUserCards(ctx context.Context, userId
 }
 
 type SavedAccounts struct {
-	AccountName   string `json:"name"`
-	AccountNumber string `json:"number"`
-	Image         string `json:"image"`
+	SavedAccountId string `json:"id"`
+	AccountName    string `json:"name"`
+	AccountNumber  string `json:"number"`
+	Image          string `json:"image"`
+	IsFavorite     bool   `json:"is_favorite"`
 }
 
-func (repository *ModelMysqlRepository) GetUserSavedAccounts(ctx context.Context, userId string) ([]SavedAccounts, error) {
+// GetUserSavedAccounts lists the saved accounts of the user with favorites first. A non-empty search only keeps
+// saved accounts whose name contains it.
+func (repository *ModelMysqlRepository) GetUserSavedAccounts(ctx context.Context, userId, search string) ([]SavedAccounts, error) {
 	var result []SavedAccounts
-	if err := mysql.DB.WithContext(ctx).Table(entity.SavedAccounts{}.TableName()).
-		Select(`account_name, account_number, image`).
-		Where("user_id = ?", userId).Scan(&result).Error; err != nil {
+	query := mysql.DB.WithContext(ctx).Table(entity.SavedAccounts{}.TableName()).
+		Select(`saved_account_id, account_name, account_number, image, is_favorite`).
+		Where("user_id = ?", userId)
+	if search != "" {
+		query = query.Where("account_name LIKE ?", "%"+likeEscaper.Replace(search)+"%")
+	}
+	if err := query.Order("is_favorite DESC, account_name, saved_account_id").Scan(&result).Error; err != nil {
 		return nil, err
 	}
 	return result, nil
 }
+
+var ErrAccountOrderMismatch = errors.New("account order does not list every account of the user exactly once")
+
+// lockAccountDetails locks the details of every account of the user so the main account and the display order are
+// changed by one request at a time
+func lockAccountDetails(tx *gorm.DB, userId string) ([]entity.AccountDetails, error) {
+	var details []entity.AccountDetails
+	if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("user_id = ?", userId).
+		Find(&details).Error; err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+// writeAccountOrder numbers the accounts from 1 in the order of accountIds, only accounts whose position changed are
+// written
+func writeAccountOrder(tx *gorm.DB, userId string, current map[string]int, accountIds []string) error {
+	for i, accountId := range accountIds {
+		if current[accountId] == i+1 {
+			continue
+		}
+		if err := tx.Model(&entity.AccountDetails{}).
+			Where("account_id = ? AND user_id = ?", accountId, userId).
+			UpdateColumn("display_order", i+1).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetMainAccount makes the account the only main account of the user and moves it to the top of the display order,
+// the other accounts keep their order below it. The order can be changed afterwards with ReorderAccounts.
+func (repository *ModelMysqlRepository) SetMainAccount(ctx context.Context, userId, accountId string) error {
+	return mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		details, err := lockAccountDetails(tx, userId)
+		if err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(details, func(d entity.AccountDetails) bool { return d.AccountId == accountId }) {
+			return ErrAccountNotFound
+		}
+
+		if err := tx.Model(&entity.AccountDetails{}).
+			Where("user_id = ?", userId).
+			UpdateColumn("is_main_account", gorm.Expr("(account_id = ?)", accountId)).Error; err != nil {
+			return err
+		}
+
+		// Same order as GetUserAccounts, accounts without a position yet come last
+		slices.SortFunc(details, func(a, b entity.AccountDetails) int {
+			if (a.DisplayOrder == 0) != (b.DisplayOrder == 0) {
+				if a.DisplayOrder == 0 {
+					return 1
+				}
+				return -1
+			}
+			if a.DisplayOrder != b.DisplayOrder {
+				return a.DisplayOrder - b.DisplayOrder
+			}
+			return strings.Compare(a.AccountId, b.AccountId)
+		})
+		current := make(map[string]int, len(details))
+		accountIds := []string{accountId}
+		for _, d := range details {
+			current[d.AccountId] = d.DisplayOrder
+			if d.AccountId != accountId {
+				accountIds = append(accountIds, d.AccountId)
+			}
+		}
+		return writeAccountOrder(tx, userId, current, accountIds)
+	})
+}
+
+// ReorderAccounts sets the display order of the user's accounts to the order of accountIds, which has to list every
+// account of the user exactly once
+func (repository *ModelMysqlRepository) ReorderAccounts(ctx context.Context, userId string, accountIds []string) error {
+	return mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		details, err := lockAccountDetails(tx, userId)
+		if err != nil {
+			return err
+		}
+
+		current := make(map[string]int, len(details))
+		for _, d := range details {
+			current[d.AccountId] = d.DisplayOrder
+		}
+		if len(accountIds) != len(current) {
+			return ErrAccountOrderMismatch
+		}
+		seen := make(map[string]bool, len(accountIds))
+		for _, accountId := range accountIds {
+			if _, ok := current[accountId]; !ok || seen[accountId] {
+				return ErrAccountOrderMismatch
+			}
+			seen[accountId] = true
+		}
+
+		return writeAccountOrder(tx, userId, current, accountIds)
+	})
+}
+
+// lockAccountDetail locks the details of one account and makes sure it is owned by the user
+func lockAccountDetail(tx *gorm.DB, userId, accountId string) (entity.AccountDetails, error) {
+	var details entity.AccountDetails
+	if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("account_id = ? AND user_id = ?", accountId, userId).
+		Take(&details).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return details, ErrAccountNotFound
+		}
+		return details, err
+	}
+	return details, nil
+}
+
+// UpdateAccountColor changes the color of an account owned by the user
+func (repository *ModelMysqlRepository) UpdateAccountColor(ctx context.Context, userId, accountId, color string) error {
+	return mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockAccountDetail(tx, userId, accountId); err != nil {
+			return err
+		}
+
+		return tx.Model(&entity.AccountDetails{}).
+			Where("account_id = ? AND user_id = ?", accountId, userId).
+			UpdateColumn("color", color).Error
+	})
+}
@@ -0,0 +1,136 @@
+package model_mysql
+
+import (
+	"assignment/datastore/mysql"
+	"assignment/entity"
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+var (
+	ErrAccountFlagNotFound = errors.New("account flag not found")
+	ErrAccountFlagExists   = errors.New("account already has this flag")
+	ErrAccountFlagExpired  = errors.New("account flag already expired")
+)
+
+const activeAccountFlagCondition = "account_id = ? AND flag_type = ? AND flag_value = ? AND (expired_at IS NULL OR expired_at > ?)"
+
+func lockAccountFlag(tx *gorm.DB, flagId int) (entity.AccountFlags, error) {
+	var flag entity.AccountFlags
+	if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("flag_id = ?", flagId).
+		Take(&flag).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return flag, ErrAccountFlagNotFound
+		}
+		return flag, err
+	}
+	return flag, nil
+}
+
+// GetAccountFlags lists every flag of the account including the expired ones
+func (repository *ModelMysqlRepository) GetAccountFlags(ctx context.Context, accountId string) ([]entity.AccountFlags, error) {
+	var flags []entity.AccountFlags
+	if err := mysql.DB.WithContext(ctx).
+		Where("account_id = ?", accountId).
+		Order("flag_id").
+		Find(&flags).Error; err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
+func (repository *ModelMysqlRepository) GetAccountFlag(ctx context.Context, flagId int) (entity.AccountFlags, error) {
+	var flag entity.AccountFlags
+	if err := mysql.DB.WithContext(ctx).Where("flag_id = ?", flagId).Take(&flag).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return flag, ErrAccountFlagNotFound
+		}
+		return flag, err
+	}
+	return flag, nil
+}
+
+// CreateAccountFlag adds a flag to the account for its owner. The same type and value can only be active once per
+// account.
+func (repository *ModelMysqlRepository) CreateAccountFlag(ctx context.Context, flag entity.AccountFlags) (entity.AccountFlags, error) {
+	err := mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var account entity.Accounts
+		if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("account_id = ?", flag.AccountId).
+			Take(&account).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrAccountNotFound
+			}
+			return err
+		}
+		flag.UserId = account.UserId
+
+		var active int64
+		if err := tx.Model(&entity.AccountFlags{}).
+			Where(activeAccountFlagCondition, flag.AccountId, flag.FlagType, flag.FlagValue, time.Now()).
+			Count(&active).Error; err != nil {
+			return err
+		}
+		if active > 0 {
+			return ErrAccountFlagExists
+		}
+
+		return tx.Create(&flag).Error
+	})
+	return flag, err
+}
+
+// UpdateAccountFlag replaces the value and the expiry of a flag, a nil expiredAt keeps the flag until it is expired.
+// An expired flag stays expired, a new flag has to be added instead, and the new value can only be active once per
+// account like in CreateAccountFlag.
+func (repository *ModelMysqlRepository) UpdateAccountFlag(ctx context.Context, flagId int, value string, expiredAt *time.Time) (entity.AccountFlags, error) {
+	var flag entity.AccountFlags
+	err := mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if flag, err = lockAccountFlag(tx, flagId); err != nil {
+			return err
+		}
+		now := time.Now()
+		if flag.ExpiredAt != nil && !flag.ExpiredAt.After(now) {
+			return ErrAccountFlagExpired
+		}
+
+		var active int64
+		if err := tx.Model(&entity.AccountFlags{}).
+			Where(activeAccountFlagCondition+" AND flag_id <> ?", flag.AccountId, flag.FlagType, value, now, flagId).
+			Count(&active).Error; err != nil {
+			return err
+		}
+		if active > 0 {
+			return ErrAccountFlagExists
+		}
+
+		flag.FlagValue = value
+		flag.ExpiredAt = expiredAt
+		return tx.Model(&entity.AccountFlags{}).Where("flag_id = ?", flagId).
+			UpdateColumns(map[string]interface{}{"flag_value": value, "expired_at": expiredAt}).Error
+	})
+	return flag, err
+}
+
+// ExpireAccountFlag expires the flag at the given time unless it already expired earlier
+func (repository *ModelMysqlRepository) ExpireAccountFlag(ctx context.Context, flagId int, at time.Time) (entity.AccountFlags, error) {
+	var flag entity.AccountFlags
+	err := mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if flag, err = lockAccountFlag(tx, flagId); err != nil {
+			return err
+		}
+		if flag.ExpiredAt != nil && !flag.ExpiredAt.After(at) {
+			return nil
+		}
+
+		flag.ExpiredAt = &at
+		return tx.Model(&entity.AccountFlags{}).Where("flag_id = ?", flagId).UpdateColumn("expired_at", at).Error
+	})
+	return flag, err
+}
@@ -0,0 +1,218 @@
+package model_mysql
+
+import (
+	"assignment/entity"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const (
+	lockAccountQuery           = "SELECT * FROM `accounts` WHERE account_id = ? LIMIT ? FOR UPDATE"
+	countActiveFlagsQuery      = "SELECT count(*) FROM `account_flags` WHERE account_id = ? AND flag_type = ? AND flag_value = ? AND (expired_at IS NULL OR expired_at > ?)"
+	insertAccountFlagSql       = "INSERT INTO `account_flags` (`account_id`,`user_id`,`flag_type`,`flag_value`,`expired_at`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?)"
+	lockAccountFlagQuery       = "SELECT * FROM `account_flags` WHERE flag_id = ? LIMIT ? FOR UPDATE"
+	expireAccountFlagSql       = "UPDATE `account_flags` SET `expired_at`=? WHERE flag_id = ?"
+	countOtherActiveFlagsQuery = "SELECT count(*) FROM `account_flags` WHERE account_id = ? AND flag_type = ? AND flag_value = ? AND (expired_at IS NULL OR expired_at > ?) AND flag_id <> ?"
+	updateAccountFlagSql       = "UPDATE `account_flags` SET `expired_at`=?,`flag_value`=? WHERE flag_id = ?"
+)
+
+var accountFlagColumns = []string{"flag_id", "account_id", "user_id", "flag_type", "flag_value", "expired_at"}
+
+func TestCreateAccountFlag_SetsOwner(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockAccountQuery).WithArgs("acc-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "user_id"}).AddRow("acc-1", "user-1"))
+	mock.ExpectQuery(countActiveFlagsQuery).WithArgs("acc-1", "badge", "new", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+	mock.ExpectExec(insertAccountFlagSql).
+		WithArgs("acc-1", "user-1", "badge", "new", nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectCommit()
+
+	flag, err := repo.CreateAccountFlag(context.Background(), entity.AccountFlags{AccountId: "acc-1", FlagType: "badge", FlagValue: "new"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if flag.FlagId != 7 || flag.UserId != "user-1" {
+		t.Fatalf("unexpected flag: %+v", flag)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestCreateAccountFlag_AlreadyActive(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockAccountQuery).WithArgs("acc-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "user_id"}).AddRow("acc-1", "user-1"))
+	mock.ExpectQuery(countActiveFlagsQuery).WithArgs("acc-1", "badge", "new", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	mock.ExpectRollback()
+
+	_, err := repo.CreateAccountFlag(context.Background(), entity.AccountFlags{AccountId: "acc-1", FlagType: "badge", FlagValue: "new"})
+	if !errors.Is(err, ErrAccountFlagExists) {
+		t.Fatalf("expected ErrAccountFlagExists, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestUpdateAccountFlag_Success(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockAccountFlagQuery).WithArgs(7, 1).
+		WillReturnRows(sqlmock.NewRows(accountFlagColumns).AddRow(7, "acc-1", "user-1", "badge", "new", nil))
+	mock.ExpectQuery(countOtherActiveFlagsQuery).WithArgs("acc-1", "badge", "popular", sqlmock.AnyArg(), 7).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(0))
+	mock.ExpectExec(updateAccountFlagSql).WithArgs(nil, "popular", 7).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	flag, err := repo.UpdateAccountFlag(context.Background(), 7, "popular", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if flag.FlagValue != "popular" || flag.ExpiredAt != nil {
+		t.Fatalf("unexpected flag: %+v", flag)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestUpdateAccountFlag_AlreadyActive(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockAccountFlagQuery).WithArgs(7, 1).
+		WillReturnRows(sqlmock.NewRows(accountFlagColumns).AddRow(7, "acc-1", "user-1", "badge", "new", nil))
+	mock.ExpectQuery(countOtherActiveFlagsQuery).WithArgs("acc-1", "badge", "popular", sqlmock.AnyArg(), 7).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	mock.ExpectRollback()
+
+	_, err := repo.UpdateAccountFlag(context.Background(), 7, "popular", nil)
+	if !errors.Is(err, ErrAccountFlagExists) {
+		t.Fatalf("expected ErrAccountFlagExists, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestUpdateAccountFlag_Expired(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	expiredAt := time.Now().Add(-time.Hour)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockAccountFlagQuery).WithArgs(7, 1).
+		WillReturnRows(sqlmock.NewRows(accountFlagColumns).AddRow(7, "acc-1", "user-1", "badge", "new", expiredAt))
+	mock.ExpectRollback()
+
+	_, err := repo.UpdateAccountFlag(context.Background(), 7, "new", nil)
+	if !errors.Is(err, ErrAccountFlagExpired) {
+		t.Fatalf("expected ErrAccountFlagExpired, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestExpireAccountFlag_KeepsEarlierExpiry(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	expiredAt := time.Now().Add(-time.Hour)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockAccountFlagQuery).WithArgs(7, 1).
+		WillReturnRows(sqlmock.NewRows(accountFlagColumns).AddRow(7, "acc-1", "user-1", "badge", "new", expiredAt))
+	mock.ExpectCommit()
+
+	flag, err := repo.ExpireAccountFlag(context.Background(), 7, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if flag.ExpiredAt == nil || !flag.ExpiredAt.Equal(expiredAt) {
+		t.Fatalf("unexpected expiry: %v", flag.ExpiredAt)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestExpireAccountFlag_Active(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockAccountFlagQuery).WithArgs(7, 1).
+		WillReturnRows(sqlmock.NewRows(accountFlagColumns).AddRow(7, "acc-1", "user-1", "badge", "new", nil))
+	mock.ExpectExec(expireAccountFlagSql).WithArgs(now, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	flag, err := repo.ExpireAccountFlag(context.Background(), 7, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if flag.ExpiredAt == nil || !flag.ExpiredAt.Equal(now) {
+		t.Fatalf("unexpected expiry: %v", flag.ExpiredAt)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestExpireAccountFlag_NotFound(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockAccountFlagQuery).WithArgs(7, 1).WillReturnRows(sqlmock.NewRows(accountFlagColumns))
+	mock.ExpectRollback()
+
+	_, err := repo.ExpireAccountFlag(context.Background(), 7, time.Now())
+	if !errors.Is(err, ErrAccountFlagNotFound) {
+		t.Fatalf("expected ErrAccountFlagNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
@@ -2,7 +2,9 @@ package model_mysql
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/shopspring/decimal"
@@ -27,27 +29,33 @@ func TestGetUserAccounts_Success_WithFlags(t *testing.T) {
 			ab.amount,
 			ad.color,
 			ad.is_main_account,
-			ad.progress
+			ad.display_order
 		FROM accounts AS a
 		JOIN account_balances AS ab ON ab.account_id = a.account_id AND ab.user_id = a.user_id
 		JOIN account_details  AS ad ON ad.account_id = a.account_id AND ad.user_id = a.user_id
 		WHERE a.user_id = ?
-		ORDER BY ad.is_main_account DESC, a.account_id
+		ORDER BY ad.display_order = 0, ad.display_order, a.account_id
 	`
 
-	mainRows := sqlmock.NewRows([]string{"account_id", "type", "currency", "account_number", "issuer", "amount", "color", "is_main_account", "progress"}).
-		AddRow("acc-1", "savings", "USD", "111111", "BANKX", "123.45", "blue", 1, 80).
-		AddRow("acc-2", "checking", "EUR", "222222", "BANKY", "0", "red", 0, 10)
+	mainRows := sqlmock.NewRows([]string{"account_id", "type", "currency", "account_number", "issuer", "amount", "color", "is_main_account", "display_order"}).
+		AddRow("acc-1", "savings", "USD", "111111", "BANKX", "123.45", "blue", 1, 1).
+		AddRow("acc-2", "checking", "EUR", "222222", "BANKY", "0", "red", 0, 2)
 
 	mock.ExpectQuery(mainQuery).WithArgs(userID).WillReturnRows(mainRows)
 
-	flagsQuery := "SELECT * FROM `account_flags` WHERE user_id = ? AND account_id IN (?,?) ORDER BY account_id, flag_type, flag_value"
+	flagsQuery := "SELECT * FROM `account_flags` WHERE user_id = ? AND account_id IN (?,?) AND (expired_at IS NULL OR expired_at > ?) ORDER BY account_id, flag_type, flag_value"
 	flagRows := sqlmock.NewRows([]string{"user_id", "account_id", "flag_type", "flag_value"}).
 		AddRow(userID, "acc-1", "restricted", "true").
 		AddRow(userID, "acc-1", "vip", "gold").
 		AddRow(userID, "acc-2", "promo", "summer-2025")
 
-	mock.ExpectQuery(flagsQuery).WithArgs(userID, "acc-1", "acc-2").WillReturnRows(flagRows)
+	mock.ExpectQuery(flagsQuery).WithArgs(userID, "acc-1", "acc-2", sqlmock.AnyArg()).WillReturnRows(flagRows)
+
+	goalsQuery := "SELECT * FROM `savings_goals` WHERE user_id = ? AND account_id IN (?,?) AND status = ?"
+	goalRows := sqlmock.NewRows([]string{"goal_id", "account_id", "user_id", "name", "target_amount", "deadline", "status"}).
+		AddRow("goal-1", "acc-1", userID, "Trip", "200", time.Date(2030, 1, 31, 0, 0, 0, 0, time.Local), SavingsGoalStatusActive)
+
+	mock.ExpectQuery(goalsQuery).WithArgs(userID, "acc-1", "acc-2", SavingsGoalStatusActive).WillReturnRows(goalRows)
 
 	accounts, err := repo.GetUserAccounts(ctx, userID)
 	if err != nil {
@@ -58,7 +66,7 @@ func TestGetUserAccounts_Success_WithFlags(t *testing.T) {
 		t.Fatalf("expected 2 accounts, got %d", len(accounts))
 	}
 
-	// Order: is_main_account DESC first, then by account_id
+	// Order: unordered accounts last, then display_order and account_id
 	if accounts[0].AccountID != "acc-1" || !accounts[0].IsMainAccount {
 		t.Fatalf("expected first account to be acc-1 (main), got %+v", accounts[0])
 	}
@@ -68,6 +76,9 @@ func TestGetUserAccounts_Success_WithFlags(t *testing.T) {
 	if len(accounts[0].Flags) != 2 {
 		t.Fatalf("expected 2 flags for acc-1, got %d", len(accounts[0].Flags))
 	}
+	if accounts[0].Goal == nil || accounts[0].Goal.Deadline != "2030-01-31" || accounts[0].Progress != 61 {
+		t.Fatalf("expected acc-1 to be 61%% towards its goal, got %+v (goal %+v)", accounts[0], accounts[0].Goal)
+	}
 
 	if accounts[1].AccountID != "acc-2" || accounts[1].IsMainAccount {
 		t.Fatalf("expected second account to be acc-2 (not main), got %+v", accounts[1])
@@ -78,6 +89,9 @@ func TestGetUserAccounts_Success_WithFlags(t *testing.T) {
 	if len(accounts[1].Flags) != 1 {
 		t.Fatalf("expected 1 flag for acc-2, got %d", len(accounts[1].Flags))
 	}
+	if accounts[1].Goal != nil || accounts[1].Progress != 0 {
+		t.Fatalf("expected acc-2 without goal to have no progress, got %+v", accounts[1])
+	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Fatalf("unmet expectations: %v", err)
@@ -102,17 +116,17 @@ func TestGetUserAccounts_Empty_NoFlagsQuery(t *testing.T) {
 			ab.amount,
 			ad.color,
 			ad.is_main_account,
-			ad.progress
+			ad.display_order
 		FROM accounts AS a
 		JOIN account_balances AS ab ON ab.account_id = a.account_id AND ab.user_id = a.user_id
 		JOIN account_details  AS ad ON ad.account_id = a.account_id AND ad.user_id = a.user_id
 		WHERE a.user_id = ?
-		ORDER BY ad.is_main_account DESC, a.account_id
+		ORDER BY ad.display_order = 0, ad.display_order, a.account_id
 	`
 
 	mock.ExpectQuery(mainQuery).WithArgs(userID).WillReturnRows(
 		sqlmock.NewRows([]string{
-			"account_id", "type", "currency", "account_number", "issuer", "amount", "color", "is_main_account", "progress",
+			"account_id", "type", "currency", "account_number", "issuer", "amount", "color", "is_main_account", "display_order",
 		}),
 	)
 
@@ -147,12 +161,12 @@ func TestGetUserAccounts_QueryError(t *testing.T) {
 			ab.amount,
 			ad.color,
 			ad.is_main_account,
-			ad.progress
+			ad.display_order
 		FROM accounts AS a
 		JOIN account_balances AS ab ON ab.account_id = a.account_id AND ab.user_id = a.user_id
 		JOIN account_details  AS ad ON ad.account_id = a.account_id AND ad.user_id = a.user_id
 		WHERE a.user_id = ?
-		ORDER BY ad.is_main_account DESC, a.account_id
+		ORDER BY ad.display_order = 0, ad.display_order, a.account_id
 	`
 
 	mock.ExpectQuery(mainQuery).WithArgs(userID).WillReturnError(gorm.ErrInvalidDB)
@@ -261,23 +275,22 @@ func TestGetUserSavedAccounts_Success(t *testing.T) {
 	ctx := context.Background()
 	userID := "user-saved"
 
-	// We don't rely on the exact table name; match the core of the query.
-	savedQuery := "SELECT account_name, account_number, image FROM `saved_accounts` WHERE user_id = ?"
+	savedQuery := "SELECT saved_account_id, account_name, account_number, image, is_favorite FROM `saved_accounts` WHERE user_id = ? ORDER BY is_favorite DESC, account_name, saved_account_id"
 
-	rows := sqlmock.NewRows([]string{"account_name", "account_number", "image"}).
-		AddRow("Alice", "1234567890", "https://cdn/img1.png").
-		AddRow("Bob", "9876543210", "https://cdn/img2.png")
+	rows := sqlmock.NewRows([]string{"saved_account_id", "account_name", "account_number", "image", "is_favorite"}).
+		AddRow("saved-1", "Alice", "1234567890", "https://cdn/img1.png", true).
+		AddRow("saved-2", "Bob", "9876543210", "https://cdn/img2.png", false)
 
 	mock.ExpectQuery(savedQuery).WithArgs(userID).WillReturnRows(rows)
 
-	accounts, err := repo.GetUserSavedAccounts(ctx, userID)
+	accounts, err := repo.GetUserSavedAccounts(ctx, userID, "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	if len(accounts) != 2 {
 		t.Fatalf("expected 2 saved accounts, got %d", len(accounts))
 	}
-	if accounts[0].AccountName != "Alice" || accounts[0].AccountNumber != "1234567890" {
+	if accounts[0].SavedAccountId != "saved-1" || accounts[0].AccountName != "Alice" || !accounts[0].IsFavorite {
 		t.Fatalf("unexpected first saved account: %+v", accounts[0])
 	}
 
@@ -294,10 +307,10 @@ func TestGetUserSavedAccounts_QueryError(t *testing.T) {
 	ctx := context.Background()
 	userID := "user-saved-err"
 
-	savedQuery := "SELECT account_name, account_number, image FROM `saved_accounts` WHERE user_id = ?"
+	savedQuery := "SELECT saved_account_id, account_name, account_number, image, is_favorite FROM `saved_accounts` WHERE user_id = ? ORDER BY is_favorite DESC, account_name, saved_account_id"
 	mock.ExpectQuery(savedQuery).WithArgs(userID).WillReturnError(gorm.ErrInvalidDB)
 
-	_, err := repo.GetUserSavedAccounts(ctx, userID)
+	_, err := repo.GetUserSavedAccounts(ctx, userID, "")
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -306,3 +319,205 @@ func TestGetUserSavedAccounts_QueryError(t *testing.T) {
 		t.Fatalf("unmet expectations: %v", err)
 	}
 }
+
+func TestGetUserSavedAccounts_SearchEscapesWildcards(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	userID := "user-saved"
+
+	savedQuery := "SELECT saved_account_id, account_name, account_number, image, is_favorite FROM `saved_accounts` WHERE user_id = ? AND account_name LIKE ? ORDER BY is_favorite DESC, account_name, saved_account_id"
+	mock.ExpectQuery(savedQuery).WithArgs(userID, `%50\%\_off%`).
+		WillReturnRows(sqlmock.NewRows([]string{"saved_account_id", "account_name", "account_number", "image", "is_favorite"}))
+
+	if _, err := repo.GetUserSavedAccounts(context.Background(), userID, "50%_off"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+const (
+	lockUserAccountDetailsQuery = "SELECT * FROM `account_details` WHERE user_id = ? FOR UPDATE"
+	setMainAccountSql           = "UPDATE `account_details` SET `is_main_account`=(account_id = ?) WHERE user_id = ?"
+	updateDisplayOrderSql       = "UPDATE `account_details` SET `display_order`=? WHERE account_id = ? AND user_id = ?"
+)
+
+var accountDetailsColumns = []string{"account_id", "user_id", "color", "is_main_account", "display_order", "progress"}
+
+func TestSetMainAccount_Success(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockUserAccountDetailsQuery).WithArgs("user-1").
+		WillReturnRows(sqlmock.NewRows(accountDetailsColumns).
+			AddRow("acc-1", "user-1", "#24c875", 1, 1, 0).
+			AddRow("acc-3", "user-1", "#ffffff", 0, 3, 0).
+			AddRow("acc-2", "user-1", "#00a1e2", 0, 2, 0))
+	mock.ExpectExec(setMainAccountSql).WithArgs("acc-2", "user-1").WillReturnResult(sqlmock.NewResult(0, 3))
+	// the new main account moves to the top, acc-3 keeps its position
+	mock.ExpectExec(updateDisplayOrderSql).WithArgs(1, "acc-2", "user-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(updateDisplayOrderSql).WithArgs(2, "acc-1", "user-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.SetMainAccount(context.Background(), "user-1", "acc-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestSetMainAccount_NewAccountStaysLast(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockUserAccountDetailsQuery).WithArgs("user-1").
+		WillReturnRows(sqlmock.NewRows(accountDetailsColumns).
+			AddRow("acc-0", "user-1", "#ffffff", 0, 0, 0).
+			AddRow("acc-1", "user-1", "#24c875", 1, 1, 0).
+			AddRow("acc-2", "user-1", "#00a1e2", 0, 2, 0))
+	mock.ExpectExec(setMainAccountSql).WithArgs("acc-2", "user-1").WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(updateDisplayOrderSql).WithArgs(1, "acc-2", "user-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(updateDisplayOrderSql).WithArgs(2, "acc-1", "user-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	// acc-0 was created without a position and gets the last one
+	mock.ExpectExec(updateDisplayOrderSql).WithArgs(3, "acc-0", "user-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.SetMainAccount(context.Background(), "user-1", "acc-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestSetMainAccount_NotOwned(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockUserAccountDetailsQuery).WithArgs("user-1").
+		WillReturnRows(sqlmock.NewRows(accountDetailsColumns).AddRow("acc-1", "user-1", "#24c875", 1, 1, 0))
+	mock.ExpectRollback()
+
+	err := repo.SetMainAccount(context.Background(), "user-1", "acc-9")
+	if !errors.Is(err, ErrAccountNotFound) {
+		t.Fatalf("expected ErrAccountNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestReorderAccounts_OnlyUpdatesMovedAccounts(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockUserAccountDetailsQuery).WithArgs("user-1").
+		WillReturnRows(sqlmock.NewRows(accountDetailsColumns).
+			AddRow("acc-1", "user-1", "#24c875", 1, 1, 0).
+			AddRow("acc-2", "user-1", "#00a1e2", 0, 2, 0).
+			AddRow("acc-3", "user-1", "#ffffff", 0, 3, 0))
+	mock.ExpectExec(updateDisplayOrderSql).WithArgs(1, "acc-2", "user-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(updateDisplayOrderSql).WithArgs(2, "acc-1", "user-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.ReorderAccounts(context.Background(), "user-1", []string{"acc-2", "acc-1", "acc-3"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestReorderAccounts_Mismatch(t *testing.T) {
+	for _, accountIds := range [][]string{
+		{"acc-1"},
+		{"acc-1", "acc-1"},
+		{"acc-1", "acc-9"},
+		{"acc-1", "acc-2", "acc-3"},
+	} {
+		mock, teardown := setupMockDB(t)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockUserAccountDetailsQuery).WithArgs("user-1").
+			WillReturnRows(sqlmock.NewRows(accountDetailsColumns).
+				AddRow("acc-1", "user-1", "#24c875", 1, 1, 0).
+				AddRow("acc-2", "user-1", "#00a1e2", 0, 2, 0))
+		mock.ExpectRollback()
+
+		err := (&ModelMysqlRepository{}).ReorderAccounts(context.Background(), "user-1", accountIds)
+		if !errors.Is(err, ErrAccountOrderMismatch) {
+			t.Fatalf("%v: expected ErrAccountOrderMismatch, got %v", accountIds, err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("%v: unmet expectations: %v", accountIds, err)
+		}
+		teardown()
+	}
+}
+
+const (
+	lockAccountDetailsQuery = "SELECT * FROM `account_details` WHERE account_id = ? AND user_id = ? LIMIT ? FOR UPDATE"
+	updateAccountColorQuery = "UPDATE `account_details` SET `color`=? WHERE account_id = ? AND user_id = ?"
+)
+
+func TestUpdateAccountColor_Success(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockAccountDetailsQuery).WithArgs("acc-1", "user-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "user_id", "color"}).AddRow("acc-1", "user-1", "#24c875"))
+	mock.ExpectExec(updateAccountColorQuery).WithArgs("#00a1e2", "acc-1", "user-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.UpdateAccountColor(context.Background(), "user-1", "acc-1", "#00a1e2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestUpdateAccountColor_NotOwned(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockAccountDetailsQuery).WithArgs("acc-1", "user-2", 1).WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectRollback()
+
+	err := repo.UpdateAccountColor(context.Background(), "user-2", "acc-1", "#00a1e2")
+	if !errors.Is(err, ErrAccountNotFound) {
+		t.Fatalf("expected ErrAccountNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
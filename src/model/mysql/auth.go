@@ -3,18 +3,42 @@ package model_mysql
 import (
 	"assignment/datastore/mysql"
 	"assignment/entity"
-	"assignment/util"
 	"context"
 	"errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"time"
 )
 
+var (
+	ErrPinResetCodeNotFound = errors.New("pin reset code not found")
+	ErrPinResetCodeUsedUp   = errors.New("pin reset code is expired or used up")
+	ErrPinLocked            = errors.New("pin is locked")
+)
+
+type PinLockoutPolicy struct {
+	MaxAttempts  int
+	BaseLockTime time.Duration
+	MaxLockTime  time.Duration
+}
+
+// LockDuration doubles the lock time on every consecutive lockout up to MaxLockTime
+func (policy PinLockoutPolicy) LockDuration(lockoutCount int) time.Duration {
+	duration := policy.BaseLockTime
+	for i := 1; i < lockoutCount && duration < policy.MaxLockTime; i++ {
+		duration *= 2
+	}
+	if duration > policy.MaxLockTime {
+		return policy.MaxLockTime
+	}
+	return duration
+}
+
 func (repository *ModelMysqlRepository) GetUserHashedPin(ctx context.Context, userId string) (entity.UserPin, error) {
 	var result entity.UserPin
 	if err := mysql.DB.WithContext(ctx).Where("user_id = ?", userId).First(&result).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return entity.UserPin{}, errors.New("user not found")
+			return entity.UserPin{}, ErrUserNotFound
 		} else {
 			return entity.UserPin{}, err
 		}
@@ -22,37 +46,160 @@ func (repository *ModelMysqlRepository) GetUserHashedPin(ctx context.Context, us
 	return result, nil
 }
 
-func (repository *ModelMysqlRepository) RevokeExistingTokenAndCreateNewToken(ctx context.Context, userId string) (string, string, error) {
-	var token, greeting string
+// ReservePinAttempt counts a pin attempt before the pin is checked and locks the pin once MaxAttempts is reached, so
+// concurrent attempts cannot check more pins than the policy allows. It returns ErrPinLocked with the pin while the
+// pin is locked, the attempt is not counted then. A successful check is expected to clear the attempts again with
+// ResetPinLockout. The attempt counter starts over after each lockout while the lockout count keeps escalating the
+// lock time.
+func (repository *ModelMysqlRepository) ReservePinAttempt(ctx context.Context, userId string, policy PinLockoutPolicy) (entity.UserPin, error) {
+	var userPin entity.UserPin
 
 	err := mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		existingToken := entity.Tokens{
-			ExpiredAt: time.Now().Add(-(time.Second * 1)),
-		}
-		if err := tx.Where("user_id = ?", userId).Where("expired_at > ?", time.Now()).Updates(&existingToken).Error; err != nil {
+		if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("user_id = ?", userId).
+			Take(&userPin).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrUserNotFound
+			}
 			return err
 		}
 
-		token = util.GenerateTokenSessionId(userId)
-		newToken := entity.Tokens{
-			SessionId: token,
-			UserId:    userId,
-			ExpiredAt: time.Now().Add(time.Minute * 720),
+		now := time.Now()
+		if userPin.LockedUntil != nil && now.Before(*userPin.LockedUntil) {
+			return ErrPinLocked
 		}
-		if err := tx.Create(&newToken).Error; err != nil {
-			return err
+
+		userPin.FailedAttempts++
+		if userPin.FailedAttempts >= policy.MaxAttempts {
+			userPin.LockoutCount++
+			lockedUntil := now.Add(policy.LockDuration(userPin.LockoutCount))
+			userPin.LockedUntil = &lockedUntil
+			userPin.FailedAttempts = 0
 		}
 
-		if err := tx.Table(entity.UserGreetings{}.TableName()).
-			Select("greeting").Where("user_id = ?", userId).
-			Row().Scan(&greeting); err != nil {
+		return tx.Model(&entity.UserPin{}).Where("user_id = ?", userId).UpdateColumns(map[string]interface{}{
+			"failed_attempts": userPin.FailedAttempts,
+			"lockout_count":   userPin.LockoutCount,
+			"locked_until":    userPin.LockedUntil,
+		}).Error
+	})
+
+	if errors.Is(err, ErrPinLocked) {
+		return userPin, err
+	}
+	if err != nil {
+		return entity.UserPin{}, err // rollback
+	}
+	return userPin, nil
+}
+
+// ResetPinLockout clears failed attempts and any lock, used on successful login and by support staff
+func (repository *ModelMysqlRepository) ResetPinLockout(ctx context.Context, userId string) error {
+	return mysql.DB.WithContext(ctx).Model(&entity.UserPin{}).Where("user_id = ?", userId).UpdateColumns(map[string]interface{}{
+		"failed_attempts": 0,
+		"lockout_count":   0,
+		"locked_until":    nil,
+	}).Error
+}
+
+// UpdateUserPinHash swaps the stored hash for one of the same pin in a newer format. It only applies while
+// the stored hash is still oldHashedPin so a pin changed in the meantime is never overwritten.
+func (repository *ModelMysqlRepository) UpdateUserPinHash(ctx context.Context, userId, oldHashedPin, newHashedPin string) error {
+	return mysql.DB.WithContext(ctx).Model(&entity.UserPin{}).
+		Where("user_id = ? AND pin = ?", userId, oldHashedPin).
+		UpdateColumn("pin", newHashedPin).Error
+}
+
+// ChangeUserPin stores a new hashed pin, clears any lockout, revokes every session of the user
+// and drops outstanding reset codes so that only the new pin can be used from now on
+func (repository *ModelMysqlRepository) ChangeUserPin(ctx context.Context, userId, hashedPin string) error {
+	return mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := storeUserPin(tx, userId, hashedPin); err != nil {
 			return err
 		}
-		return nil
+		return tx.Where("user_id = ?", userId).Delete(&entity.PinResetCodes{}).Error
+	})
+}
+
+// ResetUserPin consumes the reset code with codeHash and stores the new pin like ChangeUserPin. It returns
+// ErrPinResetCodeNotFound without touching the pin when that code was replaced or used in the meantime, so
+// a code can only ever reset the pin once.
+func (repository *ModelMysqlRepository) ResetUserPin(ctx context.Context, userId, codeHash, hashedPin string) error {
+	return mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ? AND code_hash = ?", userId, codeHash).Delete(&entity.PinResetCodes{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPinResetCodeNotFound
+		}
+		return storeUserPin(tx, userId, hashedPin)
 	})
+}
+
+func storeUserPin(tx *gorm.DB, userId, hashedPin string) error {
+	if err := tx.Model(&entity.UserPin{}).Where("user_id = ?", userId).Updates(map[string]interface{}{
+		"pin":             hashedPin,
+		"failed_attempts": 0,
+		"lockout_count":   0,
+		"locked_until":    nil,
+	}).Error; err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return tx.Model(&entity.Tokens{}).
+		Where("user_id = ?", userId).
+		Where("session_expired_at > ?", now).
+		UpdateColumns(endSessionColumns(now)).Error
+}
+
+// SavePinResetCode replaces any previous reset code of the user
+func (repository *ModelMysqlRepository) SavePinResetCode(ctx context.Context, resetCode entity.PinResetCodes) error {
+	return mysql.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"code_hash", "failed_attempts", "created_at", "expired_at"}),
+	}).Create(&resetCode).Error
+}
+
+func (repository *ModelMysqlRepository) GetPinResetCode(ctx context.Context, userId string) (entity.PinResetCodes, error) {
+	var result entity.PinResetCodes
+	if err := mysql.DB.WithContext(ctx).Where("user_id = ?", userId).Take(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.PinResetCodes{}, ErrPinResetCodeNotFound
+		}
+		return entity.PinResetCodes{}, err
+	}
+	return result, nil
+}
+
+// ReservePinResetCodeAttempt counts an attempt on the reset code of the user before the code is checked, under a
+// lock of the code row so concurrent guesses cannot check more codes than maxAttempts allows. It returns
+// ErrPinResetCodeUsedUp without counting when the code is expired or has no attempts left.
+func (repository *ModelMysqlRepository) ReservePinResetCodeAttempt(ctx context.Context, userId string, maxAttempts int) (entity.PinResetCodes, error) {
+	var resetCode entity.PinResetCodes
+
+	err := mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("user_id = ?", userId).
+			Take(&resetCode).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrPinResetCodeNotFound
+			}
+			return err
+		}
 
+		if !time.Now().Before(resetCode.ExpiredAt) || resetCode.FailedAttempts >= maxAttempts {
+			return ErrPinResetCodeUsedUp
+		}
+
+		resetCode.FailedAttempts++
+		return tx.Model(&entity.PinResetCodes{}).
+			Where("user_id = ?", userId).
+			UpdateColumn("failed_attempts", resetCode.FailedAttempts).Error
+	})
 	if err != nil {
-		return "", "", err // rollback
+		return entity.PinResetCodes{}, err // rollback
 	}
-	return token, greeting, nil
+	return resetCode, nil
 }
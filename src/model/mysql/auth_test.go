@@ -1,11 +1,13 @@
 package model_mysql
 
 import (
+	"assignment/entity"
 	"context"
-	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
 	"testing"
+	"time"
 )
 
 func TestGetUserHashedPin_Success(t *testing.T) {
@@ -91,41 +93,81 @@ func TestGetUserHashedPin_DBError(t *testing.T) {
 	}
 }
 
-func TestRevokeExistingTokenAndCreateNewToken_Success(t *testing.T) {
+func TestPinLockoutPolicy_LockDuration(t *testing.T) {
+	policy := PinLockoutPolicy{MaxAttempts: 5, BaseLockTime: 5 * time.Minute, MaxLockTime: time.Hour}
+
+	cases := map[int]time.Duration{
+		1: 5 * time.Minute,
+		2: 10 * time.Minute,
+		3: 20 * time.Minute,
+		4: 40 * time.Minute,
+		5: time.Hour,
+		9: time.Hour,
+	}
+	for lockoutCount, want := range cases {
+		if got := policy.LockDuration(lockoutCount); got != want {
+			t.Fatalf("lockout %d: expected %s, got %s", lockoutCount, want, got)
+		}
+	}
+}
+
+func TestReservePinAttempt_BelowThreshold(t *testing.T) {
 	mock, cleanup := setupMockDB(t)
 	defer cleanup()
 
 	repo := &ModelMysqlRepository{}
-	ctx := context.Background()
 	userID := "user-1"
+	policy := PinLockoutPolicy{MaxAttempts: 3, BaseLockTime: time.Minute, MaxLockTime: time.Hour}
 
 	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT * FROM `user_pin` WHERE user_id = ? LIMIT ? FOR UPDATE").
+		WithArgs(userID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "failed_attempts", "lockout_count"}).AddRow(userID, 1, 0))
+	mock.ExpectExec("UPDATE `user_pin` SET `failed_attempts`=?,`locked_until`=?,`lockout_count`=? WHERE user_id = ?").
+		WithArgs(2, nil, 0, userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
-	updateQuery := "UPDATE `tokens` SET `expired_at`=? WHERE user_id = ? AND expired_at > ?"
-	mock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+	got, err := repo.ReservePinAttempt(context.Background(), userID, policy)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.FailedAttempts != 2 || got.LockedUntil != nil {
+		t.Fatalf("unexpected user pin: %+v", got)
+	}
 
-	insertQuery := "INSERT INTO `tokens` (`session_id`,`user_id`,`issued_at`,`expired_at`) VALUES (?,?,?,?)"
-	mock.ExpectExec(insertQuery).
-		WithArgs(sqlmock.AnyArg(), userID, sqlmock.AnyArg(), sqlmock.AnyArg()).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
 
-	selectGreetingQuery := "SELECT greeting FROM `user_greetings` WHERE user_id = ?"
-	mockGreeting := "Welcome back!"
-	mock.ExpectQuery(selectGreetingQuery).
-		WithArgs(userID).
-		WillReturnRows(sqlmock.NewRows([]string{"greeting"}).AddRow(mockGreeting))
+func TestReservePinAttempt_ReachThresholdLocks(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
 
+	repo := &ModelMysqlRepository{}
+	userID := "user-1"
+	policy := PinLockoutPolicy{MaxAttempts: 3, BaseLockTime: time.Minute, MaxLockTime: time.Hour}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT * FROM `user_pin` WHERE user_id = ? LIMIT ? FOR UPDATE").
+		WithArgs(userID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "failed_attempts", "lockout_count"}).AddRow(userID, 2, 1))
+	mock.ExpectExec("UPDATE `user_pin` SET `failed_attempts`=?,`locked_until`=?,`lockout_count`=? WHERE user_id = ?").
+		WithArgs(0, sqlmock.AnyArg(), 2, userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
-	token, greeting, err := repo.RevokeExistingTokenAndCreateNewToken(ctx, userID)
+	got, err := repo.ReservePinAttempt(context.Background(), userID, policy)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if token == "" {
-		t.Fatalf("expected non-empty token")
+	if got.LockedUntil == nil || got.LockoutCount != 2 || got.FailedAttempts != 0 {
+		t.Fatalf("expected pin to be locked, got %+v", got)
 	}
-	if greeting != mockGreeting {
-		t.Fatalf("expected greeting %q, got %q", mockGreeting, greeting)
+	// Second lockout doubles the base lock time
+	if remaining := time.Until(*got.LockedUntil); remaining <= time.Minute || remaining > 2*time.Minute {
+		t.Fatalf("unexpected lock duration %s", remaining)
 	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
@@ -133,27 +175,27 @@ func TestRevokeExistingTokenAndCreateNewToken_Success(t *testing.T) {
 	}
 }
 
-func TestRevokeExistingTokenAndCreateNewToken_UpdateError(t *testing.T) {
+func TestReservePinAttempt_Locked(t *testing.T) {
 	mock, cleanup := setupMockDB(t)
 	defer cleanup()
 
 	repo := &ModelMysqlRepository{}
-	ctx := context.Background()
-	userID := "user-2"
+	userID := "user-1"
+	policy := PinLockoutPolicy{MaxAttempts: 3, BaseLockTime: time.Minute, MaxLockTime: time.Hour}
+	lockedUntil := time.Now().Add(time.Minute)
 
 	mock.ExpectBegin()
-
-	updateQuery := "UPDATE `tokens` SET `expired_at`=? WHERE user_id = ? AND expired_at > ?"
-	mock.ExpectExec(updateQuery).WillReturnError(errors.New("update failed"))
-
+	mock.ExpectQuery("SELECT * FROM `user_pin` WHERE user_id = ? LIMIT ? FOR UPDATE").
+		WithArgs(userID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "failed_attempts", "lockout_count", "locked_until"}).AddRow(userID, 0, 1, lockedUntil))
 	mock.ExpectRollback()
 
-	token, greeting, err := repo.RevokeExistingTokenAndCreateNewToken(ctx, userID)
-	if err == nil {
-		t.Fatalf("expected error, got nil")
+	got, err := repo.ReservePinAttempt(context.Background(), userID, policy)
+	if !errors.Is(err, ErrPinLocked) {
+		t.Fatalf("expected ErrPinLocked, got %v", err)
 	}
-	if token != "" || greeting != "" {
-		t.Fatalf("expected empty outputs on error")
+	if got.LockedUntil == nil || !got.LockedUntil.Equal(lockedUntil) {
+		t.Fatalf("expected the lock to be returned, got %+v", got)
 	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
@@ -161,70 +203,248 @@ func TestRevokeExistingTokenAndCreateNewToken_UpdateError(t *testing.T) {
 	}
 }
 
-func TestRevokeExistingTokenAndCreateNewToken_InsertError(t *testing.T) {
+func TestResetPinLockout_Success(t *testing.T) {
 	mock, cleanup := setupMockDB(t)
 	defer cleanup()
 
 	repo := &ModelMysqlRepository{}
-	ctx := context.Background()
-	userID := "user-3"
+	userID := "user-1"
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `user_pin` SET `failed_attempts`=?,`locked_until`=?,`lockout_count`=? WHERE user_id = ?").
+		WithArgs(0, nil, 0, userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.ResetPinLockout(context.Background(), userID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestChangeUserPin_Success(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := &ModelMysqlRepository{}
+	userID := "user-1"
 
 	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `user_pin` SET `failed_attempts`=?,`locked_until`=?,`lockout_count`=?,`pin`=?,`updated_at`=? WHERE user_id = ?").
+		WithArgs(0, nil, 0, "salt:hash", sqlmock.AnyArg(), userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE `tokens` SET `expired_at`=?,`session_expired_at`=? WHERE user_id = ? AND session_expired_at > ?").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("DELETE FROM `pin_reset_codes` WHERE user_id = ?").
+		WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
-	updateQuery := "UPDATE `tokens` SET `expired_at`=? WHERE user_id = ? AND expired_at > ?"
-	mock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := repo.ChangeUserPin(context.Background(), userID, "salt:hash"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
 
-	insertQuery := "INSERT INTO `tokens` (`session_id`,`user_id`,`issued_at`,`expired_at`) VALUES (?,?,?,?)"
-	mock.ExpectExec(insertQuery).
-		WithArgs(sqlmock.AnyArg(), userID, sqlmock.AnyArg(), sqlmock.AnyArg()).
-		WillReturnError(errors.New("insert failed"))
+func TestChangeUserPin_RevokeError(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
 
+	repo := &ModelMysqlRepository{}
+	userID := "user-1"
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `user_pin` SET `failed_attempts`=?,`locked_until`=?,`lockout_count`=?,`pin`=?,`updated_at`=? WHERE user_id = ?").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE `tokens` SET `expired_at`=?,`session_expired_at`=? WHERE user_id = ? AND session_expired_at > ?").
+		WillReturnError(errors.New("update failed"))
 	mock.ExpectRollback()
 
-	token, greeting, err := repo.RevokeExistingTokenAndCreateNewToken(ctx, userID)
-	if err == nil {
+	if err := repo.ChangeUserPin(context.Background(), userID, "salt:hash"); err == nil {
 		t.Fatalf("expected error, got nil")
 	}
-	if token != "" || greeting != "" {
-		t.Fatalf("expected empty outputs on error")
-	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Fatalf("unmet expectations: %v", err)
 	}
 }
 
-func TestRevokeExistingTokenAndCreateNewToken_GreetingQueryError(t *testing.T) {
+func TestResetUserPin_Success(t *testing.T) {
 	mock, cleanup := setupMockDB(t)
 	defer cleanup()
 
 	repo := &ModelMysqlRepository{}
-	ctx := context.Background()
-	userID := "user-4"
+	userID := "user-1"
 
 	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM `pin_reset_codes` WHERE user_id = ? AND code_hash = ?").
+		WithArgs(userID, "salt:code").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE `user_pin` SET `failed_attempts`=?,`locked_until`=?,`lockout_count`=?,`pin`=?,`updated_at`=? WHERE user_id = ?").
+		WithArgs(0, nil, 0, "salt:hash", sqlmock.AnyArg(), userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE `tokens` SET `expired_at`=?,`session_expired_at`=? WHERE user_id = ? AND session_expired_at > ?").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	if err := repo.ResetUserPin(context.Background(), userID, "salt:code", "salt:hash"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 
-	updateQuery := "UPDATE `tokens` SET `expired_at`=? WHERE user_id = ? AND expired_at > ?"
-	mock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
 
-	insertQuery := "INSERT INTO `tokens` (`session_id`,`user_id`,`issued_at`,`expired_at`) VALUES (?,?,?,?)"
-	mock.ExpectExec(insertQuery).
-		WithArgs(sqlmock.AnyArg(), userID, sqlmock.AnyArg(), sqlmock.AnyArg()).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+func TestResetUserPin_CodeGone(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
 
-	selectGreetingQuery := "SELECT greeting FROM `user_greetings` WHERE user_id = ?"
-	mock.ExpectQuery(selectGreetingQuery).
-		WithArgs(userID).
-		WillReturnError(sql.ErrNoRows)
+	repo := &ModelMysqlRepository{}
+	userID := "user-1"
 
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM `pin_reset_codes` WHERE user_id = ? AND code_hash = ?").
+		WithArgs(userID, "salt:code").
+		WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectRollback()
 
-	token, greeting, err := repo.RevokeExistingTokenAndCreateNewToken(ctx, userID)
-	if err == nil {
-		t.Fatalf("expected error, got nil")
+	err := repo.ResetUserPin(context.Background(), userID, "salt:code", "salt:hash")
+	if !errors.Is(err, ErrPinResetCodeNotFound) {
+		t.Fatalf("expected ErrPinResetCodeNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestReservePinResetCodeAttempt_Success(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := &ModelMysqlRepository{}
+	userID := "user-1"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT * FROM `pin_reset_codes` WHERE user_id = ? LIMIT ? FOR UPDATE").
+		WithArgs(userID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "code_hash", "failed_attempts", "expired_at"}).AddRow(userID, "salt:code", 2, time.Now().Add(time.Minute)))
+	mock.ExpectExec("UPDATE `pin_reset_codes` SET `failed_attempts`=? WHERE user_id = ?").
+		WithArgs(3, userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	got, err := repo.ReservePinResetCodeAttempt(context.Background(), userID, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.FailedAttempts != 3 || got.CodeHash != "salt:code" {
+		t.Fatalf("unexpected reset code: %+v", got)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestReservePinResetCodeAttempt_UsedUp(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := &ModelMysqlRepository{}
+	userID := "user-1"
+
+	rows := [][]driver.Value{
+		{userID, "salt:code", 5, time.Now().Add(time.Minute)},
+		{userID, "salt:code", 0, time.Now().Add(-time.Second)},
+	}
+	for _, row := range rows {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT * FROM `pin_reset_codes` WHERE user_id = ? LIMIT ? FOR UPDATE").
+			WithArgs(userID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "code_hash", "failed_attempts", "expired_at"}).AddRow(row...))
+		mock.ExpectRollback()
+
+		_, err := repo.ReservePinResetCodeAttempt(context.Background(), userID, 5)
+		if !errors.Is(err, ErrPinResetCodeUsedUp) {
+			t.Fatalf("expected ErrPinResetCodeUsedUp, got %v", err)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
 	}
-	if token != "" || greeting != "" {
-		t.Fatalf("expected empty outputs on error")
+}
+
+func TestGetPinResetCode_NotFound(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectQuery("SELECT * FROM `pin_reset_codes` WHERE user_id = ? LIMIT ?").
+		WithArgs("user-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "code_hash"}))
+
+	_, err := repo.GetPinResetCode(context.Background(), "user-1")
+	if !errors.Is(err, ErrPinResetCodeNotFound) {
+		t.Fatalf("expected ErrPinResetCodeNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestSavePinResetCode_Upsert(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := &ModelMysqlRepository{}
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `pin_reset_codes` (`user_id`,`code_hash`,`failed_attempts`,`created_at`,`expired_at`) VALUES (?,?,?,?,?) ON DUPLICATE KEY UPDATE `code_hash`=VALUES(`code_hash`),`failed_attempts`=VALUES(`failed_attempts`),`created_at`=VALUES(`created_at`),`expired_at`=VALUES(`expired_at`)").
+		WithArgs("user-1", "salt:hash", 0, now, now.Add(time.Minute)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.SavePinResetCode(context.Background(), entity.PinResetCodes{
+		UserId:    "user-1",
+		CodeHash:  "salt:hash",
+		CreatedAt: now,
+		ExpiredAt: now.Add(time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestUpdateUserPinHash_Success(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `user_pin` SET `pin`=? WHERE user_id = ? AND pin = ?").
+		WithArgs("$argon2id$new", "user-1", "salt:old").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.UpdateUserPinHash(context.Background(), "user-1", "salt:old", "$argon2id$new"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
@@ -5,17 +5,288 @@ import (
 	"assignment/entity"
 	"context"
 	"errors"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
 )
 
+const (
+	BannerAudienceAll     = "all"
+	BannerAudienceUsers   = "users"
+	BannerAudienceSegment = "segment"
+)
+
+var ErrBannerCampaignNotFound = errors.New("banner campaign not found")
+
+// BannerCampaign is a campaign with the users it targets and its texts in other languages, UserIds is only filled
+// for the users audience
+type BannerCampaign struct {
+	entity.BannerCampaigns
+	UserIds      []string                     `json:"user_ids,omitempty"`
+	Translations map[string]BannerTranslation `json:"translations,omitempty"`
+}
+
+type BannerTranslation struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// activeCampaignsCondition matches the campaigns running at the given time whose audience includes the user. A
+// segment campaign matches when any account of the user has its account type and currency, a nil rule matches any.
+const activeCampaignsCondition = `starts_at <= ? AND (ends_at IS NULL OR ends_at > ?) AND (
+	audience = ?
+	OR (audience = ? AND EXISTS (SELECT 1 FROM banner_campaign_users bcu
+		WHERE bcu.campaign_id = banner_campaigns.campaign_id AND bcu.user_id = ?))
+	OR (audience = ? AND EXISTS (SELECT 1 FROM accounts a
+		WHERE a.user_id = ?
+		AND (banner_campaigns.account_type IS NULL OR a.type = banner_campaigns.account_type)
+		AND (banner_campaigns.currency IS NULL OR a.currency = banner_campaigns.currency))))`
+
+// notDismissedCondition leaves out the campaigns the user dismissed
+const notDismissedCondition = ` AND campaign_id NOT IN (SELECT banner_id FROM banner_dismissals WHERE user_id = ?)`
+
+// GetUserBanners returns the active campaigns of the user by priority followed by the legacy per-user banners, the
+// banners the user dismissed are left out
 func (repository *ModelMysqlRepository) GetUserBanners(ctx context.Context, userId string) ([]entity.Banners, error) {
-	var result []entity.Banners
-	if err := mysql.DB.WithContext(ctx).Where("user_id = ?", userId).Find(&result).Error; err != nil {
+	db := mysql.DB.WithContext(ctx)
+
+	var legacy []entity.Banners
+	if err := db.Where("user_id = ? AND banner_id NOT IN (SELECT banner_id FROM banner_dismissals WHERE user_id = ?)", userId, userId).
+		Find(&legacy).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return []entity.Banners{}, errors.New("banner not found")
 		} else {
 			return []entity.Banners{}, err
 		}
 	}
+
+	now := time.Now()
+	var campaigns []entity.BannerCampaigns
+	if err := db.Where(activeCampaignsCondition+notDismissedCondition, now, now,
+		BannerAudienceAll, BannerAudienceUsers, userId, BannerAudienceSegment, userId, userId).
+		Order("priority DESC, starts_at DESC, campaign_id").
+		Find(&campaigns).Error; err != nil {
+		return []entity.Banners{}, err
+	}
+
+	result := make([]entity.Banners, 0, len(campaigns)+len(legacy))
+	for _, campaign := range campaigns {
+		result = append(result, entity.Banners{
+			BannerId:    campaign.CampaignId,
+			UserId:      userId,
+			Title:       campaign.Title,
+			Description: campaign.Description,
+			Image:       campaign.Image,
+		})
+	}
+	return append(result, legacy...), nil
+}
+
+// IsUserBanner tells whether the banner is a legacy banner of the user or a running campaign whose audience includes
+// the user, dismissed or not
+func (repository *ModelMysqlRepository) IsUserBanner(ctx context.Context, userId, bannerId string) (bool, error) {
+	db := mysql.DB.WithContext(ctx)
+
+	var count int64
+	if err := db.Model(&entity.Banners{}).Where("user_id = ? AND banner_id = ?", userId, bannerId).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	now := time.Now()
+	if err := db.Model(&entity.BannerCampaigns{}).Where("campaign_id = ? AND "+activeCampaignsCondition, bannerId, now, now,
+		BannerAudienceAll, BannerAudienceUsers, userId, BannerAudienceSegment, userId).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetBannerTranslations returns the translations of the banners in the given languages
+func (repository *ModelMysqlRepository) GetBannerTranslations(ctx context.Context, bannerIds, languages []string) ([]entity.BannerTranslations, error) {
+	var translations []entity.BannerTranslations
+	if err := mysql.DB.WithContext(ctx).
+		Where("banner_id IN ? AND language IN ?", bannerIds, languages).
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// GetBannerCampaign returns the campaign without its audience and translations
+func (repository *ModelMysqlRepository) GetBannerCampaign(ctx context.Context, campaignId string) (entity.BannerCampaigns, error) {
+	var campaign entity.BannerCampaigns
+	if err := mysql.DB.WithContext(ctx).Where("campaign_id = ?", campaignId).Take(&campaign).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return campaign, ErrBannerCampaignNotFound
+		}
+		return campaign, err
+	}
+	return campaign, nil
+}
+
+// GetBannerCampaigns lists every campaign, including the ended ones, newest first
+func (repository *ModelMysqlRepository) GetBannerCampaigns(ctx context.Context) ([]BannerCampaign, error) {
+	db := mysql.DB.WithContext(ctx)
+
+	var campaigns []entity.BannerCampaigns
+	if err := db.Order("starts_at DESC, campaign_id").Find(&campaigns).Error; err != nil {
+		return nil, err
+	}
+
+	var campaignIds []string
+	for _, campaign := range campaigns {
+		if campaign.Audience == BannerAudienceUsers {
+			campaignIds = append(campaignIds, campaign.CampaignId)
+		}
+	}
+	userIds := map[string][]string{}
+	if len(campaignIds) > 0 {
+		var users []entity.BannerCampaignUsers
+		if err := db.Where("campaign_id IN ?", campaignIds).Order("campaign_id, user_id").Find(&users).Error; err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			userIds[user.CampaignId] = append(userIds[user.CampaignId], user.UserId)
+		}
+	}
+
+	translations := map[string]map[string]BannerTranslation{}
+	if len(campaigns) > 0 {
+		allIds := make([]string, 0, len(campaigns))
+		for _, campaign := range campaigns {
+			allIds = append(allIds, campaign.CampaignId)
+		}
+		var rows []entity.BannerTranslations
+		if err := db.Where("banner_id IN ?", allIds).Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			if translations[row.BannerId] == nil {
+				translations[row.BannerId] = map[string]BannerTranslation{}
+			}
+			translations[row.BannerId][row.Language] = BannerTranslation{Title: row.Title, Description: row.Description}
+		}
+	}
+
+	result := make([]BannerCampaign, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		result = append(result, BannerCampaign{
+			BannerCampaigns: campaign,
+			UserIds:         userIds[campaign.CampaignId],
+			Translations:    translations[campaign.CampaignId],
+		})
+	}
 	return result, nil
 }
+
+func replaceBannerCampaignUsers(tx *gorm.DB, campaignId string, userIds []string) error {
+	if err := tx.Where("campaign_id = ?", campaignId).Delete(&entity.BannerCampaignUsers{}).Error; err != nil {
+		return err
+	}
+	if len(userIds) == 0 {
+		return nil
+	}
+	users := make([]entity.BannerCampaignUsers, 0, len(userIds))
+	for _, userId := range userIds {
+		users = append(users, entity.BannerCampaignUsers{CampaignId: campaignId, UserId: userId})
+	}
+	return tx.CreateInBatches(&users, 500).Error
+}
+
+func replaceBannerTranslations(tx *gorm.DB, bannerId string, translations map[string]BannerTranslation) error {
+	if err := tx.Where("banner_id = ?", bannerId).Delete(&entity.BannerTranslations{}).Error; err != nil {
+		return err
+	}
+	if len(translations) == 0 {
+		return nil
+	}
+	rows := make([]entity.BannerTranslations, 0, len(translations))
+	for language, translation := range translations {
+		rows = append(rows, entity.BannerTranslations{
+			BannerId:    bannerId,
+			Language:    language,
+			Title:       translation.Title,
+			Description: translation.Description,
+		})
+	}
+	return tx.Create(&rows).Error
+}
+
+// CreateBannerCampaign stores a new campaign, UserIds is the audience of a users campaign and ignored otherwise
+func (repository *ModelMysqlRepository) CreateBannerCampaign(ctx context.Context, campaign BannerCampaign) (BannerCampaign, error) {
+	campaign.CampaignId = uuid.NewString()
+	if campaign.Audience != BannerAudienceUsers {
+		campaign.UserIds = nil
+	}
+
+	err := mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&campaign.BannerCampaigns).Error; err != nil {
+			return err
+		}
+		if err := replaceBannerCampaignUsers(tx, campaign.CampaignId, campaign.UserIds); err != nil {
+			return err
+		}
+		return replaceBannerTranslations(tx, campaign.CampaignId, campaign.Translations)
+	})
+	return campaign, err
+}
+
+// UpdateBannerCampaign replaces every field, the audience and the translations of the campaign
+func (repository *ModelMysqlRepository) UpdateBannerCampaign(ctx context.Context, campaign BannerCampaign) (BannerCampaign, error) {
+	if campaign.Audience != BannerAudienceUsers {
+		campaign.UserIds = nil
+	}
+
+	err := mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current entity.BannerCampaigns
+		if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("campaign_id = ?", campaign.CampaignId).
+			Take(&current).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrBannerCampaignNotFound
+			}
+			return err
+		}
+		campaign.CreatedAt = current.CreatedAt
+
+		if err := tx.Model(&entity.BannerCampaigns{}).Where("campaign_id = ?", campaign.CampaignId).
+			UpdateColumns(map[string]interface{}{
+				"title":        campaign.Title,
+				"description":  campaign.Description,
+				"image":        campaign.Image,
+				"priority":     campaign.Priority,
+				"starts_at":    campaign.StartsAt,
+				"ends_at":      campaign.EndsAt,
+				"audience":     campaign.Audience,
+				"account_type": campaign.AccountType,
+				"currency":     campaign.Currency,
+			}).Error; err != nil {
+			return err
+		}
+		if err := replaceBannerCampaignUsers(tx, campaign.CampaignId, campaign.UserIds); err != nil {
+			return err
+		}
+		return replaceBannerTranslations(tx, campaign.CampaignId, campaign.Translations)
+	})
+	return campaign, err
+}
+
+func (repository *ModelMysqlRepository) DeleteBannerCampaign(ctx context.Context, campaignId string) error {
+	return mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("campaign_id = ?", campaignId).Delete(&entity.BannerCampaigns{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrBannerCampaignNotFound
+		}
+		if err := tx.Where("campaign_id = ?", campaignId).Delete(&entity.BannerCampaignUsers{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("banner_id = ?", campaignId).Delete(&entity.BannerTranslations{}).Error
+	})
+}
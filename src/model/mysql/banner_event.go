@@ -0,0 +1,57 @@
+package model_mysql
+
+import (
+	"assignment/bannerevent"
+	"assignment/datastore/mysql"
+	"assignment/entity"
+	"context"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type BannerEventStats struct {
+	BannerId    string          `json:"banner_id"`
+	Impressions int64           `json:"impressions"`
+	Clicks      int64           `json:"clicks"`
+	Dismissals  int64           `json:"dismissals"`
+	Ctr         decimal.Decimal `json:"ctr" gorm:"-"`
+}
+
+// ClickThroughRate is clicks per impression as a percentage with 2 decimals
+func ClickThroughRate(clicks, impressions int64) decimal.Decimal {
+	if impressions <= 0 {
+		return decimal.Zero
+	}
+	return decimal.NewFromInt(clicks).Mul(decimal.NewFromInt(100)).Div(decimal.NewFromInt(impressions)).Round(2)
+}
+
+// CreateBannerEvents writes one batch of the buffered banner event writer
+func (repository *ModelMysqlRepository) CreateBannerEvents(ctx context.Context, events []entity.BannerEvents) error {
+	return mysql.DB.WithContext(ctx).CreateInBatches(&events, 500).Error
+}
+
+// DismissBanner hides the banner from the user, dismissing it again is a no-op
+func (repository *ModelMysqlRepository) DismissBanner(ctx context.Context, userId, bannerId string) error {
+	return mysql.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&entity.BannerDismissals{UserId: userId, BannerId: bannerId}).Error
+}
+
+// GetBannerEventReport counts the events of every banner that has any within [from, to)
+func (repository *ModelMysqlRepository) GetBannerEventReport(ctx context.Context, from, to time.Time) ([]BannerEventStats, error) {
+	var stats []BannerEventStats
+	if err := mysql.DB.WithContext(ctx).Model(&entity.BannerEvents{}).
+		Select("banner_id, SUM(event_type = ?) AS impressions, SUM(event_type = ?) AS clicks, SUM(event_type = ?) AS dismissals",
+			bannerevent.TypeImpression, bannerevent.TypeClick, bannerevent.TypeDismiss).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Group("banner_id").
+		Order("banner_id").
+		Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range stats {
+		stats[i].Ctr = ClickThroughRate(stats[i].Clicks, stats[i].Impressions)
+	}
+	return stats, nil
+}
@@ -0,0 +1,63 @@
+package model_mysql
+
+import (
+	"assignment/bannerevent"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+)
+
+func TestDismissBanner_IgnoresRepeat(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `banner_dismissals` (`user_id`,`banner_id`,`created_at`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `user_id`=`user_id`").
+		WithArgs("user-1", "banner-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	if err := repo.DismissBanner(context.Background(), "user-1", "banner-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestGetBannerEventReport_ComputesCtr(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	from := time.Now().AddDate(0, 0, -7)
+	to := time.Now()
+
+	mock.ExpectQuery("SELECT banner_id, SUM(event_type = ?) AS impressions, SUM(event_type = ?) AS clicks, SUM(event_type = ?) AS dismissals "+
+		"FROM `banner_events` WHERE created_at >= ? AND created_at < ? GROUP BY `banner_id` ORDER BY banner_id").
+		WithArgs(bannerevent.TypeImpression, bannerevent.TypeClick, bannerevent.TypeDismiss, from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"banner_id", "impressions", "clicks", "dismissals"}).
+			AddRow("banner-1", 300, 10, 2).
+			AddRow("banner-2", 0, 0, 1))
+
+	stats, err := repo.GetBannerEventReport(context.Background(), from, to)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(stats))
+	}
+	if !stats[0].Ctr.Equal(decimal.RequireFromString("3.33")) || !stats[1].Ctr.IsZero() {
+		t.Fatalf("unexpected ctr: %s, %s", stats[0].Ctr, stats[1].Ctr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
@@ -1,14 +1,32 @@
 package model_mysql
 
 import (
+	"assignment/entity"
 	"context"
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
 	"gorm.io/gorm"
+	"reflect"
 	"testing"
 	"time"
 )
 
+const legacyBannersQuery = "SELECT * FROM `banners` WHERE user_id = ? AND banner_id NOT IN (SELECT banner_id FROM banner_dismissals WHERE user_id = ?)"
+
+const activeCampaignsQuery = "SELECT * FROM `banner_campaigns` WHERE starts_at <= ? AND (ends_at IS NULL OR ends_at > ?) AND ( audience = ? " +
+	"OR (audience = ? AND EXISTS (SELECT 1 FROM banner_campaign_users bcu WHERE bcu.campaign_id = banner_campaigns.campaign_id AND bcu.user_id = ?)) " +
+	"OR (audience = ? AND EXISTS (SELECT 1 FROM accounts a WHERE a.user_id = ? AND (banner_campaigns.account_type IS NULL OR a.type = banner_campaigns.account_type) " +
+	"AND (banner_campaigns.currency IS NULL OR a.currency = banner_campaigns.currency)))) " +
+	"AND campaign_id NOT IN (SELECT banner_id FROM banner_dismissals WHERE user_id = ?) ORDER BY priority DESC, starts_at DESC, campaign_id"
+
+var bannerCampaignColumns = []string{"campaign_id", "title", "description", "image", "priority", "starts_at", "ends_at", "audience"}
+
+func expectActiveCampaigns(mock sqlmock.Sqlmock, userID string, rows *sqlmock.Rows) {
+	mock.ExpectQuery(activeCampaignsQuery).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), BannerAudienceAll, BannerAudienceUsers, userID, BannerAudienceSegment, userID, userID).
+		WillReturnRows(rows)
+}
+
 func TestGetUserBanners_Success(t *testing.T) {
 	mock, teardown := setupMockDB(t)
 	defer teardown()
@@ -16,12 +34,13 @@ func TestGetUserBanners_Success(t *testing.T) {
 	repo := &ModelMysqlRepository{}
 	userID := "test-user-id"
 
-	query := "SELECT * FROM `banners` WHERE user_id = ?"
+	query := legacyBannersQuery
 
 	rows := sqlmock.NewRows([]string{"user_id"}).
 		AddRow(userID)
 
-	mock.ExpectQuery(query).WithArgs(userID).WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs(userID, userID).WillReturnRows(rows)
+	expectActiveCampaigns(mock, userID, sqlmock.NewRows(bannerCampaignColumns))
 
 	ctx := context.Background()
 	res, err := repo.GetUserBanners(ctx, userID)
@@ -44,9 +63,9 @@ func TestGetUserBanners_NotFound(t *testing.T) {
 	repo := &ModelMysqlRepository{}
 	userID := "missing-user-id"
 
-	query := "SELECT * FROM `banners` WHERE user_id = ?"
+	query := legacyBannersQuery
 
-	mock.ExpectQuery(query).WithArgs(userID).WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectQuery(query).WithArgs(userID, userID).WillReturnError(gorm.ErrRecordNotFound)
 
 	ctx := context.Background()
 	res, err := repo.GetUserBanners(ctx, userID)
@@ -72,10 +91,10 @@ func TestGetUserBanners_DBError(t *testing.T) {
 	repo := &ModelMysqlRepository{}
 	userID := "any-user-id"
 
-	query := "SELECT * FROM `banners` WHERE user_id = ?"
+	query := legacyBannersQuery
 
 	dbErr := errors.New("db error")
-	mock.ExpectQuery(query).WithArgs(userID).WillReturnError(dbErr)
+	mock.ExpectQuery(query).WithArgs(userID, userID).WillReturnError(dbErr)
 
 	ctx := context.Background()
 	res, err := repo.GetUserBanners(ctx, userID)
@@ -101,12 +120,13 @@ func TestGetUserBanners_Success_ExtraColumns(t *testing.T) {
 	repo := &ModelMysqlRepository{}
 	userID := "user-with-banners"
 
-	query := "SELECT * FROM `banners` WHERE user_id = ?"
+	query := legacyBannersQuery
 
 	now := time.Now()
 	rows := sqlmock.NewRows([]string{"user_id", "created_at", "updated_at"}).AddRow(userID, now, now)
 
-	mock.ExpectQuery(query).WithArgs(userID).WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs(userID, userID).WillReturnRows(rows)
+	expectActiveCampaigns(mock, userID, sqlmock.NewRows(bannerCampaignColumns))
 
 	ctx := context.Background()
 	res, err := repo.GetUserBanners(ctx, userID)
@@ -121,3 +141,152 @@ func TestGetUserBanners_Success_ExtraColumns(t *testing.T) {
 		t.Fatalf("unmet mock expectations: %v", err)
 	}
 }
+
+func TestGetUserBanners_CampaignsBeforeLegacy(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	userID := "test-user-id"
+
+	mock.ExpectQuery(legacyBannersQuery).WithArgs(userID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"banner_id", "user_id", "title"}).AddRow("legacy-1", userID, "Legacy"))
+	now := time.Now()
+	expectActiveCampaigns(mock, userID, sqlmock.NewRows(bannerCampaignColumns).
+		AddRow("campaign-2", "Cashback", "", "", 10, now, nil, BannerAudienceAll).
+		AddRow("campaign-1", "Travel", "", "", 1, now, nil, BannerAudienceSegment))
+
+	res, err := repo.GetUserBanners(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var ids []string
+	for _, banner := range res {
+		ids = append(ids, banner.BannerId)
+	}
+	if !reflect.DeepEqual(ids, []string{"campaign-2", "campaign-1", "legacy-1"}) {
+		t.Fatalf("unexpected banners order: %v", ids)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestCreateBannerCampaign_StoresUsers(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `banner_campaigns` (`campaign_id`,`title`,`description`,`image`,`priority`,`starts_at`,`ends_at`,`audience`,`account_type`,`currency`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?)").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM `banner_campaign_users` WHERE campaign_id = ?").WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO `banner_campaign_users` (`campaign_id`,`user_id`) VALUES (?,?),(?,?)").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("DELETE FROM `banner_translations` WHERE banner_id = ?").WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO `banner_translations` (`banner_id`,`language`,`title`,`description`) VALUES (?,?,?,?)").WithArgs(sqlmock.AnyArg(), "th", "Cashback TH", "").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	campaign, err := repo.CreateBannerCampaign(context.Background(), BannerCampaign{
+		BannerCampaigns: entity.BannerCampaigns{Title: "Cashback", StartsAt: time.Now(), Audience: BannerAudienceUsers},
+		UserIds:         []string{"user-1", "user-2"},
+		Translations:    map[string]BannerTranslation{"th": {Title: "Cashback TH"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if campaign.CampaignId == "" || len(campaign.UserIds) != 2 {
+		t.Fatalf("unexpected campaign: %+v", campaign)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestIsUserBanner_LegacyBanner(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectQuery("SELECT count(*) FROM `banners` WHERE user_id = ? AND banner_id = ?").WithArgs("user-1", "banner-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	shown, err := repo.IsUserBanner(context.Background(), "user-1", "banner-1")
+	if err != nil || !shown {
+		t.Fatalf("expected the legacy banner to be shown, got %v %v", shown, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestIsUserBanner_UnknownBanner(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectQuery("SELECT count(*) FROM `banners` WHERE user_id = ? AND banner_id = ?").WithArgs("user-1", "banner-9").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("SELECT count(*) FROM `banner_campaigns` WHERE campaign_id = ? AND starts_at <= ? AND (ends_at IS NULL OR ends_at > ?) AND ("+
+		" audience = ? OR (audience = ? AND EXISTS (SELECT 1 FROM banner_campaign_users bcu WHERE bcu.campaign_id = banner_campaigns.campaign_id AND bcu.user_id = ?))"+
+		" OR (audience = ? AND EXISTS (SELECT 1 FROM accounts a WHERE a.user_id = ? AND (banner_campaigns.account_type IS NULL OR a.type = banner_campaigns.account_type)"+
+		" AND (banner_campaigns.currency IS NULL OR a.currency = banner_campaigns.currency))))").
+		WithArgs("banner-9", sqlmock.AnyArg(), sqlmock.AnyArg(), BannerAudienceAll, BannerAudienceUsers, "user-1", BannerAudienceSegment, "user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	shown, err := repo.IsUserBanner(context.Background(), "user-1", "banner-9")
+	if err != nil || shown {
+		t.Fatalf("expected the banner not to be shown, got %v %v", shown, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestGetBannerTranslations(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	rows := sqlmock.NewRows([]string{"banner_id", "language", "title", "description"}).
+		AddRow("banner-1", "th", "Title TH", "Description TH")
+	mock.ExpectQuery("SELECT * FROM `banner_translations` WHERE banner_id IN (?,?) AND language IN (?)").WithArgs("banner-1", "banner-2", "th").WillReturnRows(rows)
+
+	translations, err := repo.GetBannerTranslations(context.Background(), []string{"banner-1", "banner-2"}, []string{"th"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(translations) != 1 || translations[0].Title != "Title TH" {
+		t.Fatalf("unexpected translations: %+v", translations)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestDeleteBannerCampaign_NotFound(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM `banner_campaigns` WHERE campaign_id = ?").WithArgs("campaign-1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := repo.DeleteBannerCampaign(context.Background(), "campaign-1")
+	if !errors.Is(err, ErrBannerCampaignNotFound) {
+		t.Fatalf("expected ErrBannerCampaignNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
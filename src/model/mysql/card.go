@@ -0,0 +1,204 @@
+package model_mysql
+
+import (
+	"assignment/datastore/mysql"
+	"assignment/entity"
+	"assignment/util"
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+var (
+	ErrCardNotFound           = errors.New("card not found")
+	ErrCardStatusChanged      = errors.New("card status was changed concurrently")
+	ErrCardRevealTokenInvalid = errors.New("card reveal token is invalid, expired or used")
+)
+
+// Card reveal audit events
+const (
+	CardRevealEventPinFailed     = "pin_failed"
+	CardRevealEventRateLimited   = "rate_limited"
+	CardRevealEventTokenIssued   = "token_issued"
+	CardRevealEventTokenRejected = "token_rejected"
+	CardRevealEventRevealed      = "revealed"
+)
+
+// GetUserCardStatus returns the status of a card owned by the user, cards of other users are reported as not found
+func (repository *ModelMysqlRepository) GetUserCardStatus(ctx context.Context, userId, cardId string) (string, error) {
+	var result []string
+	if err := mysql.DB.WithContext(ctx).
+		Table("debit_cards AS dc").
+		Joins("JOIN debit_card_status AS dc_s ON dc_s.card_id = dc.card_id").
+		Where("dc.card_id = ? AND dc.user_id = ?", cardId, userId).
+		Limit(1).
+		Pluck("dc_s.status", &result).Error; err != nil {
+		return "", err
+	}
+	if len(result) == 0 {
+		return "", ErrCardNotFound
+	}
+	return result[0], nil
+}
+
+// UpdateCardStatus moves the card from fromStatus to toStatus, it fails with ErrCardStatusChanged when the status is
+// no longer fromStatus so that a transition is never applied on top of one it was not checked against
+func (repository *ModelMysqlRepository) UpdateCardStatus(ctx context.Context, cardId, fromStatus, toStatus string) error {
+	result := mysql.DB.WithContext(ctx).Model(&entity.DebitCardStatus{}).
+		Where("card_id = ? AND status = ?", cardId, fromStatus).
+		UpdateColumn("status", toStatus)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCardStatusChanged
+	}
+	return nil
+}
+
+// GetUserCardNumber returns the full number of a card owned by the user
+func (repository *ModelMysqlRepository) GetUserCardNumber(ctx context.Context, userId, cardId string) (string, error) {
+	var result []string
+	if err := mysql.DB.WithContext(ctx).
+		Table("debit_cards AS dc").
+		Joins("JOIN debit_card_details AS dc_details ON dc_details.card_id = dc.card_id").
+		Where("dc.card_id = ? AND dc.user_id = ?", cardId, userId).
+		Limit(1).
+		Pluck("dc_details.number", &result).Error; err != nil {
+		return "", err
+	}
+	if len(result) == 0 {
+		return "", ErrCardNotFound
+	}
+	return result[0], nil
+}
+
+// CreateCardRevealToken issues a single-use token that allows the user to reveal one card until it expires
+func (repository *ModelMysqlRepository) CreateCardRevealToken(ctx context.Context, userId, cardId string, lifetime time.Duration) (string, time.Time, error) {
+	token := util.GenerateTokenSessionId(userId)
+	now := time.Now()
+	revealToken := entity.CardRevealTokens{
+		TokenHash: util.HashSessionToken(token),
+		UserId:    userId,
+		CardId:    cardId,
+		CreatedAt: now,
+		ExpiredAt: now.Add(lifetime),
+	}
+	if err := mysql.DB.WithContext(ctx).Create(&revealToken).Error; err != nil {
+		return "", time.Time{}, err
+	}
+	return token, revealToken.ExpiredAt, nil
+}
+
+// UseCardRevealToken marks the token as used, it fails with ErrCardRevealTokenInvalid unless the token was issued for
+// this user and card, has not expired and was never used
+func (repository *ModelMysqlRepository) UseCardRevealToken(ctx context.Context, userId, cardId, token string) error {
+	now := time.Now()
+	result := mysql.DB.WithContext(ctx).Model(&entity.CardRevealTokens{}).
+		Where("token_hash = ? AND user_id = ? AND card_id = ? AND used_at IS NULL AND expired_at > ?",
+			util.HashSessionToken(token), userId, cardId, now).
+		UpdateColumn("used_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCardRevealTokenInvalid
+	}
+	return nil
+}
+
+// AuditCardReveal records a step of the card reveal flow together with the request id
+func (repository *ModelMysqlRepository) AuditCardReveal(ctx context.Context, userId, cardId, event, ipAddress string) error {
+	return mysql.DB.WithContext(ctx).Create(&entity.CardRevealAudits{
+		UserId:    userId,
+		CardId:    cardId,
+		Event:     event,
+		IpAddress: ipAddress,
+		RequestId: repository.RequestId,
+		CreatedAt: time.Now(),
+	}).Error
+}
+
+// CountCardRevealEvents counts the audited events of the user since the given time, used for rate limiting
+func (repository *ModelMysqlRepository) CountCardRevealEvents(ctx context.Context, userId, event string, since time.Time) (int64, error) {
+	var count int64
+	if err := mysql.DB.WithContext(ctx).Model(&entity.CardRevealAudits{}).
+		Where("user_id = ? AND event = ? AND created_at > ?", userId, event, since).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetCardControls returns the limits and channel toggles of a card owned by the user, cards that were never
+// configured get the given defaults
+func (repository *ModelMysqlRepository) GetCardControls(ctx context.Context, userId, cardId string, defaults entity.DebitCardControls) (entity.DebitCardControls, error) {
+	var card entity.DebitCards
+	if err := mysql.DB.WithContext(ctx).Where("card_id = ? AND user_id = ?", cardId, userId).Take(&card).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.DebitCardControls{}, ErrCardNotFound
+		}
+		return entity.DebitCardControls{}, err
+	}
+
+	var controls entity.DebitCardControls
+	if err := mysql.DB.WithContext(ctx).Where("card_id = ?", cardId).Take(&controls).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.DebitCardControls{}, err
+		}
+		controls = defaults
+		controls.CardId = cardId
+		controls.UserId = userId
+	}
+	return controls, nil
+}
+
+// SaveCardControls replaces the limits and channel toggles of a card owned by the user
+func (repository *ModelMysqlRepository) SaveCardControls(ctx context.Context, userId string, controls entity.DebitCardControls) error {
+	return mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var card entity.DebitCards
+		if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("card_id = ? AND user_id = ?", controls.CardId, userId).
+			Take(&card).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCardNotFound
+			}
+			return err
+		}
+
+		controls.UserId = userId
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "card_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"daily_limit", "online_limit", "atm_limit",
+				"contactless_enabled", "online_enabled", "overseas_enabled", "updated_at"}),
+		}).Create(&controls).Error
+	})
+}
+
+// UpdateCardDesign changes the colors of a card owned by the user, creating its design when it has none
+func (repository *ModelMysqlRepository) UpdateCardDesign(ctx context.Context, userId, cardId, color, borderColor string) error {
+	return mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var card entity.DebitCards
+		if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("card_id = ? AND user_id = ?", cardId, userId).
+			Take(&card).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrCardNotFound
+			}
+			return err
+		}
+
+		// Cards without a design row get one, the other columns of an existing row are kept
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "card_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"color", "border_color"}),
+		}).Create(&entity.DebitCardDesign{
+			CardId:      cardId,
+			UserId:      userId,
+			Color:       color,
+			BorderColor: borderColor,
+		}).Error
+	})
+}
@@ -0,0 +1,289 @@
+package model_mysql
+
+import (
+	"assignment/entity"
+	"assignment/util"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+const (
+	selectCardStatusQuery = "SELECT `dc_s`.`status` FROM debit_cards AS dc JOIN debit_card_status AS dc_s ON dc_s.card_id = dc.card_id WHERE dc.card_id = ? AND dc.user_id = ? LIMIT ?"
+	useRevealTokenQuery   = "UPDATE `card_reveal_tokens` SET `used_at`=? WHERE token_hash = ? AND user_id = ? AND card_id = ? AND used_at IS NULL AND expired_at > ?"
+	insertRevealAuditSql  = "INSERT INTO `card_reveal_audits` (`user_id`,`card_id`,`event`,`ip_address`,`request_id`,`created_at`) VALUES (?,?,?,?,?,?)"
+	countRevealAuditQuery = "SELECT count(*) FROM `card_reveal_audits` WHERE user_id = ? AND event = ? AND created_at > ?"
+	selectCardQuery       = "SELECT * FROM `debit_cards` WHERE card_id = ? AND user_id = ? LIMIT ?"
+	lockCardQuery         = "SELECT * FROM `debit_cards` WHERE card_id = ? AND user_id = ? LIMIT ? FOR UPDATE"
+	selectCardControlsSql = "SELECT * FROM `debit_card_controls` WHERE card_id = ? LIMIT ?"
+	upsertCardControlsSql = "INSERT INTO `debit_card_controls` (`card_id`,`user_id`,`daily_limit`,`online_limit`,`atm_limit`,`contactless_enabled`,`online_enabled`,`overseas_enabled`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `daily_limit`=VALUES(`daily_limit`),`online_limit`=VALUES(`online_limit`),`atm_limit`=VALUES(`atm_limit`),`contactless_enabled`=VALUES(`contactless_enabled`),`online_enabled`=VALUES(`online_enabled`),`overseas_enabled`=VALUES(`overseas_enabled`),`updated_at`=VALUES(`updated_at`)"
+	updateCardStatusQuery = "UPDATE `debit_card_status` SET `status`=? WHERE card_id = ? AND status = ?"
+)
+
+func TestGetUserCardStatus_Success(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectQuery(selectCardStatusQuery).WithArgs("card-1", "user-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("active"))
+
+	status, err := repo.GetUserCardStatus(context.Background(), "user-1", "card-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != "active" {
+		t.Fatalf("unexpected status: %s", status)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestGetUserCardStatus_NotOwned(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectQuery(selectCardStatusQuery).WithArgs("card-1", "user-2", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}))
+
+	_, err := repo.GetUserCardStatus(context.Background(), "user-2", "card-1")
+	if !errors.Is(err, ErrCardNotFound) {
+		t.Fatalf("expected ErrCardNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestUpdateCardStatus_Success(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(updateCardStatusQuery).WithArgs("frozen", "card-1", "active").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.UpdateCardStatus(context.Background(), "card-1", "active", "frozen"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestUpdateCardStatus_StatusChanged(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(updateCardStatusQuery).WithArgs("frozen", "card-1", "active").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.UpdateCardStatus(context.Background(), "card-1", "active", "frozen")
+	if !errors.Is(err, ErrCardStatusChanged) {
+		t.Fatalf("expected ErrCardStatusChanged, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestUseCardRevealToken_Success(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(useRevealTokenQuery).
+		WithArgs(sqlmock.AnyArg(), util.HashSessionToken("reveal-token"), "user-1", "card-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.UseCardRevealToken(context.Background(), "user-1", "card-1", "reveal-token"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestUseCardRevealToken_AlreadyUsed(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(useRevealTokenQuery).
+		WithArgs(sqlmock.AnyArg(), util.HashSessionToken("reveal-token"), "user-1", "card-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.UseCardRevealToken(context.Background(), "user-1", "card-1", "reveal-token")
+	if !errors.Is(err, ErrCardRevealTokenInvalid) {
+		t.Fatalf("expected ErrCardRevealTokenInvalid, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestAuditCardReveal_StoresRequestId(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{RequestId: "req-1"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(insertRevealAuditSql).
+		WithArgs("user-1", "card-1", CardRevealEventRevealed, "10.0.0.1", "req-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	if err := repo.AuditCardReveal(context.Background(), "user-1", "card-1", CardRevealEventRevealed, "10.0.0.1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestCountCardRevealEvents(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	since := time.Now().Add(-time.Hour)
+
+	mock.ExpectQuery(countRevealAuditQuery).
+		WithArgs("user-1", CardRevealEventTokenIssued, since).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := repo.CountCardRevealEvents(context.Background(), "user-1", CardRevealEventTokenIssued, since)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 3 {
+		t.Fatalf("unexpected count: %d", count)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestGetCardControls_Defaults(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	defaults := entity.DebitCardControls{DailyLimit: decimal.NewFromInt(50000), ContactlessEnabled: true}
+
+	mock.ExpectQuery(selectCardQuery).WithArgs("card-1", "user-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"card_id", "user_id", "name"}).AddRow("card-1", "user-1", "My Card"))
+	mock.ExpectQuery(selectCardControlsSql).WithArgs("card-1", 1).WillReturnError(gorm.ErrRecordNotFound)
+
+	controls, err := repo.GetCardControls(context.Background(), "user-1", "card-1", defaults)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if controls.CardId != "card-1" || controls.UserId != "user-1" || !controls.DailyLimit.Equal(defaults.DailyLimit) || !controls.ContactlessEnabled {
+		t.Fatalf("unexpected controls: %+v", controls)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestSaveCardControls_NotOwned(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockCardQuery).WithArgs("card-1", "user-2", 1).WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectRollback()
+
+	err := repo.SaveCardControls(context.Background(), "user-2", entity.DebitCardControls{CardId: "card-1"})
+	if !errors.Is(err, ErrCardNotFound) {
+		t.Fatalf("expected ErrCardNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestSaveCardControls_Upsert(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	controls := entity.DebitCardControls{
+		CardId:      "card-1",
+		DailyLimit:  decimal.NewFromInt(1000),
+		OnlineLimit: decimal.NewFromInt(300),
+		AtmLimit:    decimal.NewFromInt(500),
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockCardQuery).WithArgs("card-1", "user-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"card_id", "user_id", "name"}).AddRow("card-1", "user-1", "My Card"))
+	mock.ExpectExec(upsertCardControlsSql).
+		WithArgs("card-1", "user-1", controls.DailyLimit, controls.OnlineLimit, controls.AtmLimit, false, false, false, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.SaveCardControls(context.Background(), "user-1", controls); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestUpdateCardDesign_Success(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockCardQuery).WithArgs("card-1", "user-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"card_id", "user_id", "name"}).AddRow("card-1", "user-1", "My Card"))
+	mock.ExpectExec("INSERT INTO `debit_card_design` (`card_id`,`user_id`,`color`,`border_color`,`dummy_col_9`) VALUES (?,?,?,?,?) ON DUPLICATE KEY UPDATE `color`=VALUES(`color`),`border_color`=VALUES(`border_color`)").
+		WithArgs("card-1", "user-1", "#845ef7", "#ffffff", "").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.UpdateCardDesign(context.Background(), "user-1", "card-1", "#845ef7", "#ffffff"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
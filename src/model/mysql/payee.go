@@ -0,0 +1,50 @@
+package model_mysql
+
+import (
+	"assignment/datastore/mysql"
+	"assignment/entity"
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type AccountOwner struct {
+	AccountNumber string
+	Issuer        string
+	UserId        string
+	Name          string
+}
+
+// LookupAccountOwner finds an account held in this service by its number without separators, so 568-2-90992 is
+// found as 568290992. The digits are unique across accounts.
+func (repository *ModelMysqlRepository) LookupAccountOwner(ctx context.Context, digits string) (AccountOwner, error) {
+	var owner AccountOwner
+	if err := mysql.DB.WithContext(ctx).Table("accounts AS a").
+		Select("a.account_number, a.issuer, a.user_id, u.name").
+		Joins("JOIN users AS u ON u.user_id = a.user_id").
+		Where("a.account_digits = ?", digits).
+		Take(&owner).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return owner, ErrAccountNotFound
+		}
+		return owner, err
+	}
+	return owner, nil
+}
+
+// RecordPayeeLookup remembers that the user looked up a payee, used for rate limiting
+func (repository *ModelMysqlRepository) RecordPayeeLookup(ctx context.Context, userId string) error {
+	return mysql.DB.WithContext(ctx).Create(&entity.PayeeLookups{UserId: userId, CreatedAt: time.Now()}).Error
+}
+
+// CountPayeeLookups counts the payee lookups of the user since the given time
+func (repository *ModelMysqlRepository) CountPayeeLookups(ctx context.Context, userId string, since time.Time) (int64, error) {
+	var count int64
+	if err := mysql.DB.WithContext(ctx).Model(&entity.PayeeLookups{}).
+		Where("user_id = ? AND created_at > ?", userId, since).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
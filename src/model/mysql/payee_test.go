@@ -0,0 +1,75 @@
+package model_mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const lookupAccountOwnerQuery = "SELECT a.account_number, a.issuer, a.user_id, u.name FROM accounts AS a JOIN users AS u ON u.user_id = a.user_id WHERE a.account_digits = ? LIMIT ?"
+
+func TestLookupAccountOwner_Found(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectQuery(lookupAccountOwnerQuery).WithArgs("568271318", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_number", "issuer", "user_id", "name"}).
+			AddRow("568-2-71318", "TestLab", "user-2", "Jane Doe"))
+
+	owner, err := repo.LookupAccountOwner(context.Background(), "568271318")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owner != (AccountOwner{AccountNumber: "568-2-71318", Issuer: "TestLab", UserId: "user-2", Name: "Jane Doe"}) {
+		t.Fatalf("unexpected owner: %+v", owner)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestLookupAccountOwner_NotFound(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectQuery(lookupAccountOwnerQuery).WithArgs("1234567897", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_number", "issuer", "user_id", "name"}))
+
+	_, err := repo.LookupAccountOwner(context.Background(), "1234567897")
+	if !errors.Is(err, ErrAccountNotFound) {
+		t.Fatalf("expected ErrAccountNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestCountPayeeLookups(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	since := time.Now().Add(-time.Hour)
+
+	mock.ExpectQuery("SELECT count(*) FROM `payee_lookups` WHERE user_id = ? AND created_at > ?").
+		WithArgs("user-1", since).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := repo.CountPayeeLookups(context.Background(), "user-1", since)
+	if err != nil || count != 3 {
+		t.Fatalf("unexpected count: %d %v", count, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package model_mysql
+
+import (
+	"assignment/datastore/mysql"
+	"assignment/entity"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+)
+
+var ErrSavedAccountExists = errors.New("saved account already exists")
+
+// likeEscaper escapes the wildcards of a LIKE pattern so user input is matched literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type SavedAccountChanges struct {
+	AccountName *string
+	IsFavorite  *bool
+}
+
+func toSavedAccount(saved entity.SavedAccounts) SavedAccounts {
+	return SavedAccounts{
+		SavedAccountId: saved.SavedAccountId,
+		AccountName:    saved.AccountName,
+		AccountNumber:  saved.AccountNumber,
+		Image:          saved.Image,
+		IsFavorite:     saved.IsFavorite,
+	}
+}
+
+// CreateSavedAccount saves a payee for the user. Every account number can only be saved once per user.
+func (repository *ModelMysqlRepository) CreateSavedAccount(ctx context.Context, userId, name, accountNumber, image string) (SavedAccounts, error) {
+	saved := entity.SavedAccounts{
+		SavedAccountId: uuid.NewString(),
+		UserId:         userId,
+		AccountName:    name,
+		AccountNumber:  accountNumber,
+		Image:          image,
+	}
+
+	result := mysql.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&saved)
+	if result.Error != nil {
+		return SavedAccounts{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return SavedAccounts{}, ErrSavedAccountExists
+	}
+	return toSavedAccount(saved), nil
+}
+
+// UpdateSavedAccount renames and/or (un)favorites a saved account of the user and returns it after the change
+func (repository *ModelMysqlRepository) UpdateSavedAccount(ctx context.Context, userId, savedAccountId string, changes SavedAccountChanges) (SavedAccounts, error) {
+	var saved entity.SavedAccounts
+	err := mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("saved_account_id = ? AND user_id = ?", savedAccountId, userId).
+			Take(&saved).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrPayeeNotFound
+			}
+			return err
+		}
+
+		updates := map[string]interface{}{}
+		if changes.AccountName != nil && *changes.AccountName != saved.AccountName {
+			updates["account_name"] = *changes.AccountName
+			saved.AccountName = *changes.AccountName
+		}
+		if changes.IsFavorite != nil && *changes.IsFavorite != saved.IsFavorite {
+			updates["is_favorite"] = *changes.IsFavorite
+			saved.IsFavorite = *changes.IsFavorite
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+
+		return tx.Model(&entity.SavedAccounts{}).
+			Where("saved_account_id = ? AND user_id = ?", savedAccountId, userId).
+			UpdateColumns(updates).Error
+	})
+	if err != nil {
+		return SavedAccounts{}, err
+	}
+	return toSavedAccount(saved), nil
+}
+
+// DeleteSavedAccount removes a saved account of the user
+func (repository *ModelMysqlRepository) DeleteSavedAccount(ctx context.Context, userId, savedAccountId string) error {
+	result := mysql.DB.WithContext(ctx).
+		Where("saved_account_id = ? AND user_id = ?", savedAccountId, userId).
+		Delete(&entity.SavedAccounts{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPayeeNotFound
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package model_mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const (
+	insertSavedAccountSql = "INSERT INTO `saved_accounts` (`saved_account_id`,`user_id`,`account_name`,`account_number`,`image`,`is_favorite`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `saved_account_id`=`saved_account_id`"
+	lockSavedAccountQuery = "SELECT * FROM `saved_accounts` WHERE saved_account_id = ? AND user_id = ? LIMIT ? FOR UPDATE"
+	updateSavedAccountSql = "UPDATE `saved_accounts` SET `account_name`=?,`is_favorite`=? WHERE saved_account_id = ? AND user_id = ?"
+	deleteSavedAccountSql = "DELETE FROM `saved_accounts` WHERE saved_account_id = ? AND user_id = ?"
+)
+
+var savedAccountColumns = []string{"saved_account_id", "user_id", "account_name", "account_number", "image", "is_favorite"}
+
+func TestCreateSavedAccount_Success(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(insertSavedAccountSql).
+		WithArgs(sqlmock.AnyArg(), "user-1", "Alice", "1234567890", "", false, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	saved, err := repo.CreateSavedAccount(context.Background(), "user-1", "Alice", "1234567890", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if saved.SavedAccountId == "" || saved.AccountName != "Alice" || saved.AccountNumber != "1234567890" {
+		t.Fatalf("unexpected saved account: %+v", saved)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestCreateSavedAccount_AlreadySaved(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(insertSavedAccountSql).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	_, err := repo.CreateSavedAccount(context.Background(), "user-1", "Alice", "1234567890", "")
+	if !errors.Is(err, ErrSavedAccountExists) {
+		t.Fatalf("expected ErrSavedAccountExists, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestUpdateSavedAccount_RenameAndFavorite(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	name := "Mom"
+	favorite := true
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockSavedAccountQuery).WithArgs("saved-1", "user-1", 1).
+		WillReturnRows(sqlmock.NewRows(savedAccountColumns).AddRow("saved-1", "user-1", "Alice", "1234567890", "", false))
+	mock.ExpectExec(updateSavedAccountSql).WithArgs("Mom", true, "saved-1", "user-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	saved, err := repo.UpdateSavedAccount(context.Background(), "user-1", "saved-1",
+		SavedAccountChanges{AccountName: &name, IsFavorite: &favorite})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if saved.AccountName != "Mom" || !saved.IsFavorite || saved.AccountNumber != "1234567890" {
+		t.Fatalf("unexpected saved account: %+v", saved)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestUpdateSavedAccount_NotOwned(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	name := "Mom"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockSavedAccountQuery).WithArgs("saved-1", "user-2", 1).
+		WillReturnRows(sqlmock.NewRows(savedAccountColumns))
+	mock.ExpectRollback()
+
+	_, err := repo.UpdateSavedAccount(context.Background(), "user-2", "saved-1", SavedAccountChanges{AccountName: &name})
+	if !errors.Is(err, ErrPayeeNotFound) {
+		t.Fatalf("expected ErrPayeeNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestDeleteSavedAccount_NotFound(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(deleteSavedAccountSql).WithArgs("saved-1", "user-2").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.DeleteSavedAccount(context.Background(), "user-2", "saved-1")
+	if !errors.Is(err, ErrPayeeNotFound) {
+		t.Fatalf("expected ErrPayeeNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
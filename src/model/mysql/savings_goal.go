@@ -0,0 +1,196 @@
+package model_mysql
+
+import (
+	"assignment/datastore/mysql"
+	"assignment/entity"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+const (
+	SavingsGoalStatusActive = "active"
+	SavingsGoalStatusClosed = "closed"
+
+	savingsGoalDeadlineLayout = "2006-01-02"
+)
+
+var (
+	ErrSavingsGoalNotFound = errors.New("savings goal not found")
+	ErrSavingsGoalExists   = errors.New("account already has an active savings goal")
+	ErrSavingsGoalClosed   = errors.New("savings goal is closed")
+)
+
+type SavingsGoal struct {
+	GoalId       string          `json:"goal_id"`
+	AccountId    string          `json:"account_id"`
+	Name         string          `json:"name"`
+	TargetAmount decimal.Decimal `json:"target_amount"`
+	Deadline     string          `json:"deadline"`
+	Status       string          `json:"status"`
+	Progress     int             `json:"progress"`
+}
+
+type SavingsGoalChanges struct {
+	Name         *string
+	TargetAmount *decimal.Decimal
+	Deadline     *time.Time
+}
+
+// GoalProgress is the balance as a whole percentage of the target, capped at 100
+func GoalProgress(balance, target decimal.Decimal) int {
+	if !target.IsPositive() || !balance.IsPositive() {
+		return 0
+	}
+	progress := balance.Mul(decimal.NewFromInt(100)).Div(target).IntPart()
+	return int(min(progress, 100))
+}
+
+func newSavingsGoal(goal entity.SavingsGoals, balance decimal.Decimal) SavingsGoal {
+	return SavingsGoal{
+		GoalId:       goal.GoalId,
+		AccountId:    goal.AccountId,
+		Name:         goal.Name,
+		TargetAmount: goal.TargetAmount,
+		Deadline:     goal.Deadline.Format(savingsGoalDeadlineLayout),
+		Status:       goal.Status,
+		Progress:     GoalProgress(balance, goal.TargetAmount),
+	}
+}
+
+func accountBalance(tx *gorm.DB, userId, accountId string) (decimal.Decimal, error) {
+	var balance entity.AccountBalances
+	if err := tx.Where("account_id = ? AND user_id = ?", accountId, userId).Take(&balance).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return decimal.Zero, ErrAccountNotFound
+		}
+		return decimal.Zero, err
+	}
+	return balance.Amount, nil
+}
+
+func lockSavingsGoal(tx *gorm.DB, userId, goalId string) (entity.SavingsGoals, error) {
+	var goal entity.SavingsGoals
+	if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("goal_id = ? AND user_id = ?", goalId, userId).
+		Take(&goal).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return goal, ErrSavingsGoalNotFound
+		}
+		return goal, err
+	}
+	if goal.Status != SavingsGoalStatusActive {
+		return goal, ErrSavingsGoalClosed
+	}
+	return goal, nil
+}
+
+// CreateSavingsGoal attaches a goal to an account of the user, an account has at most one active goal
+func (repository *ModelMysqlRepository) CreateSavingsGoal(ctx context.Context, userId, accountId, name string, target decimal.Decimal, deadline time.Time) (SavingsGoal, error) {
+	var result SavingsGoal
+	err := mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockAccountDetail(tx, userId, accountId); err != nil {
+			return err
+		}
+
+		var active int64
+		if err := tx.Model(&entity.SavingsGoals{}).
+			Where("user_id = ? AND account_id = ? AND status = ?", userId, accountId, SavingsGoalStatusActive).
+			Count(&active).Error; err != nil {
+			return err
+		}
+		if active > 0 {
+			return ErrSavingsGoalExists
+		}
+
+		goal := entity.SavingsGoals{
+			GoalId:       uuid.NewString(),
+			AccountId:    accountId,
+			UserId:       userId,
+			Name:         name,
+			TargetAmount: target,
+			Deadline:     deadline,
+			Status:       SavingsGoalStatusActive,
+		}
+		if err := tx.Create(&goal).Error; err != nil {
+			return err
+		}
+
+		balance, err := accountBalance(tx, userId, accountId)
+		if err != nil {
+			return err
+		}
+		result = newSavingsGoal(goal, balance)
+		return nil
+	})
+	return result, err
+}
+
+// UpdateSavingsGoal changes the name, target and/or deadline of an active goal of the user
+func (repository *ModelMysqlRepository) UpdateSavingsGoal(ctx context.Context, userId, goalId string, changes SavingsGoalChanges) (SavingsGoal, error) {
+	var result SavingsGoal
+	err := mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		goal, err := lockSavingsGoal(tx, userId, goalId)
+		if err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{}
+		if changes.Name != nil && *changes.Name != goal.Name {
+			updates["name"] = *changes.Name
+			goal.Name = *changes.Name
+		}
+		if changes.TargetAmount != nil && !changes.TargetAmount.Equal(goal.TargetAmount) {
+			updates["target_amount"] = *changes.TargetAmount
+			goal.TargetAmount = *changes.TargetAmount
+		}
+		if changes.Deadline != nil && !changes.Deadline.Equal(goal.Deadline) {
+			updates["deadline"] = *changes.Deadline
+			goal.Deadline = *changes.Deadline
+		}
+		if len(updates) > 0 {
+			if err := tx.Model(&entity.SavingsGoals{}).Where("goal_id = ?", goalId).UpdateColumns(updates).Error; err != nil {
+				return err
+			}
+		}
+
+		balance, err := accountBalance(tx, userId, goal.AccountId)
+		if err != nil {
+			return err
+		}
+		result = newSavingsGoal(goal, balance)
+		return nil
+	})
+	return result, err
+}
+
+// CloseSavingsGoal ends an active goal of the user, a closed goal can no longer be changed
+func (repository *ModelMysqlRepository) CloseSavingsGoal(ctx context.Context, userId, goalId string) (SavingsGoal, error) {
+	var result SavingsGoal
+	err := mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		goal, err := lockSavingsGoal(tx, userId, goalId)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := tx.Model(&entity.SavingsGoals{}).Where("goal_id = ?", goalId).
+			UpdateColumns(map[string]interface{}{"status": SavingsGoalStatusClosed, "closed_at": now}).Error; err != nil {
+			return err
+		}
+		goal.Status = SavingsGoalStatusClosed
+		goal.ClosedAt = &now
+
+		balance, err := accountBalance(tx, userId, goal.AccountId)
+		if err != nil {
+			return err
+		}
+		result = newSavingsGoal(goal, balance)
+		return nil
+	})
+	return result, err
+}
@@ -0,0 +1,133 @@
+package model_mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	countActiveGoalsQuery = "SELECT count(*) FROM `savings_goals` WHERE user_id = ? AND account_id = ? AND status = ?"
+	lockSavingsGoalQuery  = "SELECT * FROM `savings_goals` WHERE goal_id = ? AND user_id = ? LIMIT ? FOR UPDATE"
+	updateSavingsGoalSql  = "UPDATE `savings_goals` SET `target_amount`=? WHERE goal_id = ?"
+	selectBalanceQuery    = "SELECT * FROM `account_balances` WHERE account_id = ? AND user_id = ? LIMIT ?"
+)
+
+var savingsGoalColumns = []string{"goal_id", "account_id", "user_id", "name", "target_amount", "deadline", "status"}
+
+func TestGoalProgress(t *testing.T) {
+	for _, tc := range []struct {
+		balance, target string
+		want            int
+	}{
+		{"0", "1000", 0},
+		{"-50", "1000", 0},
+		{"123.45", "200", 61},
+		{"999.99", "1000", 99},
+		{"1000", "1000", 100},
+		{"2500", "1000", 100},
+		{"10", "0", 0},
+	} {
+		got := GoalProgress(decimal.RequireFromString(tc.balance), decimal.RequireFromString(tc.target))
+		if got != tc.want {
+			t.Fatalf("balance %s of %s: got %d, want %d", tc.balance, tc.target, got, tc.want)
+		}
+	}
+}
+
+func TestCreateSavingsGoal_AccountHasActiveGoal(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockAccountDetailsQuery).WithArgs("acc-1", "user-1", 1).
+		WillReturnRows(sqlmock.NewRows(accountDetailsColumns).AddRow("acc-1", "user-1", "#24c875", 1, 1, 0))
+	mock.ExpectQuery(countActiveGoalsQuery).WithArgs("user-1", "acc-1", SavingsGoalStatusActive).
+		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
+	mock.ExpectRollback()
+
+	_, err := repo.CreateSavingsGoal(context.Background(), "user-1", "acc-1", "Trip", decimal.NewFromInt(1000), time.Now().AddDate(0, 1, 0))
+	if !errors.Is(err, ErrSavingsGoalExists) {
+		t.Fatalf("expected ErrSavingsGoalExists, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestUpdateSavingsGoal_ComputesProgressFromBalance(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	deadline := time.Date(2030, 1, 31, 0, 0, 0, 0, time.Local)
+	target := decimal.NewFromInt(500)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockSavingsGoalQuery).WithArgs("goal-1", "user-1", 1).
+		WillReturnRows(sqlmock.NewRows(savingsGoalColumns).AddRow("goal-1", "acc-1", "user-1", "Trip", "1000", deadline, SavingsGoalStatusActive))
+	mock.ExpectExec(updateSavingsGoalSql).WithArgs(target, "goal-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(selectBalanceQuery).WithArgs("acc-1", "user-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "user_id", "amount"}).AddRow("acc-1", "user-1", "250"))
+	mock.ExpectCommit()
+
+	goal, err := repo.UpdateSavingsGoal(context.Background(), "user-1", "goal-1", SavingsGoalChanges{TargetAmount: &target})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if goal.Progress != 50 || !goal.TargetAmount.Equal(target) || goal.Deadline != "2030-01-31" {
+		t.Fatalf("unexpected goal: %+v", goal)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestCloseSavingsGoal_AlreadyClosed(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockSavingsGoalQuery).WithArgs("goal-1", "user-1", 1).
+		WillReturnRows(sqlmock.NewRows(savingsGoalColumns).AddRow("goal-1", "acc-1", "user-1", "Trip", "1000", time.Now(), SavingsGoalStatusClosed))
+	mock.ExpectRollback()
+
+	_, err := repo.CloseSavingsGoal(context.Background(), "user-1", "goal-1")
+	if !errors.Is(err, ErrSavingsGoalClosed) {
+		t.Fatalf("expected ErrSavingsGoalClosed, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestCloseSavingsGoal_NotOwned(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockSavingsGoalQuery).WithArgs("goal-1", "user-2", 1).WillReturnRows(sqlmock.NewRows(savingsGoalColumns))
+	mock.ExpectRollback()
+
+	_, err := repo.CloseSavingsGoal(context.Background(), "user-2", "goal-1")
+	if !errors.Is(err, ErrSavingsGoalNotFound) {
+		t.Fatalf("expected ErrSavingsGoalNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
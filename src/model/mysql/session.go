@@ -0,0 +1,271 @@
+package model_mysql
+
+import (
+	"assignment/datastore/mysql"
+	"assignment/entity"
+	"assignment/util"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+var (
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrRefreshTokenInvalid = errors.New("refresh token is invalid or expired")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used")
+)
+
+// SessionDevice describes where a session was created from
+type SessionDevice struct {
+	DeviceId   string
+	DeviceName string
+	UserAgent  string
+	IpAddress  string
+}
+
+// AccessTokenIssuer creates self-contained access tokens, e.g. signed JWTs
+type AccessTokenIssuer interface {
+	Issue(userId, sessionId string, expiredAt time.Time) (string, error)
+}
+
+type SessionPolicy struct {
+	// MaxSessions is the number of concurrent sessions a user may hold, 0 means unlimited
+	MaxSessions          int
+	AccessTokenLifetime  time.Duration
+	RefreshTokenLifetime time.Duration
+	// AccessTokenIssuer is nil for opaque random access tokens
+	AccessTokenIssuer AccessTokenIssuer
+}
+
+// SessionTokens are handed to the client, only their hashes are stored
+type SessionTokens struct {
+	AccessToken          string
+	AccessTokenExpiredAt time.Time
+	RefreshToken         string
+}
+
+// endSessionColumns expires both the access token and the refresh token of a session
+func endSessionColumns(now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"expired_at":         now.Add(-(time.Second * 1)),
+		"session_expired_at": now.Add(-(time.Second * 1)),
+	}
+}
+
+// CreateSessionToken starts a new session for the device. A previous session of the same device is
+// replaced, and once the user holds MaxSessions sessions the least recently used ones are ended.
+func (repository *ModelMysqlRepository) CreateSessionToken(ctx context.Context, userId string, device SessionDevice, policy SessionPolicy) (SessionTokens, string, error) {
+	var tokens SessionTokens
+	var greeting string
+
+	err := mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		if device.DeviceId != "" {
+			if err := tx.Model(&entity.Tokens{}).
+				Where("user_id = ? AND device_id = ?", userId, device.DeviceId).
+				Where("session_expired_at > ?", now).
+				UpdateColumns(endSessionColumns(now)).Error; err != nil {
+				return err
+			}
+		}
+
+		if policy.MaxSessions > 0 {
+			var activeSessionIds []string
+			if err := tx.Model(&entity.Tokens{}).
+				Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+				Where("user_id = ?", userId).
+				Where("session_expired_at > ?", now).
+				Order("COALESCE(last_seen_at, issued_at) DESC").
+				Pluck("session_id", &activeSessionIds).Error; err != nil {
+				return err
+			}
+			if len(activeSessionIds) >= policy.MaxSessions {
+				if err := tx.Model(&entity.Tokens{}).
+					Where("session_id IN ?", activeSessionIds[policy.MaxSessions-1:]).
+					UpdateColumns(endSessionColumns(now)).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		familyId := uuid.NewString()
+		var err error
+		if tokens, err = newSessionTokens(userId, familyId, now, policy); err != nil {
+			return err
+		}
+		newToken := entity.Tokens{
+			SessionId:        util.HashSessionToken(tokens.AccessToken),
+			UserId:           userId,
+			FamilyId:         familyId,
+			DeviceId:         device.DeviceId,
+			DeviceName:       device.DeviceName,
+			UserAgent:        device.UserAgent,
+			IpAddress:        device.IpAddress,
+			LastSeenAt:       &now,
+			ExpiredAt:        tokens.AccessTokenExpiredAt,
+			SessionExpiredAt: now.Add(policy.RefreshTokenLifetime),
+		}
+		if err := tx.Create(&newToken).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&entity.RefreshTokens{
+			TokenHash: util.HashSessionToken(tokens.RefreshToken),
+			FamilyId:  familyId,
+			UserId:    userId,
+			ExpiredAt: newToken.SessionExpiredAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Table(entity.UserGreetings{}.TableName()).
+			Select("greeting").Where("user_id = ?", userId).
+			Row().Scan(&greeting); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	if err != nil {
+		return SessionTokens{}, "", err // rollback
+	}
+	return tokens, greeting, nil
+}
+
+// RefreshSessionToken trades a refresh token for a new access and refresh token of the same session.
+// Every refresh token can be used once, presenting one again means it was stolen so the whole session
+// is ended and ErrRefreshTokenReused is returned.
+func (repository *ModelMysqlRepository) RefreshSessionToken(ctx context.Context, refreshToken string, policy SessionPolicy) (SessionTokens, error) {
+	var tokens SessionTokens
+	reused := false
+
+	err := mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		var current entity.RefreshTokens
+		if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("token_hash = ?", util.HashSessionToken(refreshToken)).
+			Take(&current).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrRefreshTokenInvalid
+			}
+			return err
+		}
+
+		if current.UsedAt != nil {
+			// Commit the revocation, the caller still gets ErrRefreshTokenReused
+			reused = true
+			if err := tx.Model(&entity.Tokens{}).
+				Where("family_id = ?", current.FamilyId).
+				Where("session_expired_at > ?", now).
+				UpdateColumns(endSessionColumns(now)).Error; err != nil {
+				return err
+			}
+			return tx.Model(&entity.RefreshTokens{}).
+				Where("family_id = ? AND used_at IS NULL", current.FamilyId).
+				UpdateColumn("used_at", now).Error
+		}
+		if !now.Before(current.ExpiredAt) {
+			return ErrRefreshTokenInvalid
+		}
+
+		if err := tx.Model(&current).UpdateColumn("used_at", now).Error; err != nil {
+			return err
+		}
+
+		var err error
+		if tokens, err = newSessionTokens(current.UserId, current.FamilyId, now, policy); err != nil {
+			return err
+		}
+		sessionExpiredAt := now.Add(policy.RefreshTokenLifetime)
+		result := tx.Model(&entity.Tokens{}).
+			Where("family_id = ?", current.FamilyId).
+			Where("session_expired_at > ?", now).
+			UpdateColumns(map[string]interface{}{
+				"session_id":         util.HashSessionToken(tokens.AccessToken),
+				"expired_at":         tokens.AccessTokenExpiredAt,
+				"session_expired_at": sessionExpiredAt,
+				"last_seen_at":       now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// The session was logged out or revoked
+			return ErrRefreshTokenInvalid
+		}
+
+		return tx.Create(&entity.RefreshTokens{
+			TokenHash: util.HashSessionToken(tokens.RefreshToken),
+			FamilyId:  current.FamilyId,
+			UserId:    current.UserId,
+			ExpiredAt: sessionExpiredAt,
+		}).Error
+	})
+
+	if err != nil {
+		return SessionTokens{}, err // rollback
+	}
+	if reused {
+		return SessionTokens{}, ErrRefreshTokenReused
+	}
+	return tokens, nil
+}
+
+func newSessionTokens(userId, familyId string, now time.Time, policy SessionPolicy) (SessionTokens, error) {
+	tokens := SessionTokens{
+		AccessToken:          util.GenerateTokenSessionId(userId),
+		AccessTokenExpiredAt: now.Add(policy.AccessTokenLifetime),
+		RefreshToken:         util.GenerateTokenSessionId(userId),
+	}
+	if policy.AccessTokenIssuer != nil {
+		accessToken, err := policy.AccessTokenIssuer.Issue(userId, familyId, tokens.AccessTokenExpiredAt)
+		if err != nil {
+			return SessionTokens{}, err
+		}
+		tokens.AccessToken = accessToken
+	}
+	return tokens, nil
+}
+
+// GetActiveSessions lists the sessions of the user that are not ended, most recently used first
+func (repository *ModelMysqlRepository) GetActiveSessions(ctx context.Context, userId string) ([]entity.Tokens, error) {
+	var result []entity.Tokens
+	if err := mysql.DB.WithContext(ctx).
+		Where("user_id = ?", userId).
+		Where("session_expired_at > ?", time.Now()).
+		Order("COALESCE(last_seen_at, issued_at) DESC").
+		Find(&result).Error; err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RevokeSession ends one active session of the user
+func (repository *ModelMysqlRepository) RevokeSession(ctx context.Context, userId, sessionId string) error {
+	now := time.Now()
+	result := mysql.DB.WithContext(ctx).Model(&entity.Tokens{}).
+		Where("session_id = ? AND user_id = ?", sessionId, userId).
+		Where("session_expired_at > ?", now).
+		UpdateColumns(endSessionColumns(now))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// RevokeOtherSessions ends every active session of the user except keepSessionId
+func (repository *ModelMysqlRepository) RevokeOtherSessions(ctx context.Context, userId, keepSessionId string) (int64, error) {
+	now := time.Now()
+	result := mysql.DB.WithContext(ctx).Model(&entity.Tokens{}).
+		Where("user_id = ? AND session_id <> ?", userId, keepSessionId).
+		Where("session_expired_at > ?", now).
+		UpdateColumns(endSessionColumns(now))
+	return result.RowsAffected, result.Error
+}
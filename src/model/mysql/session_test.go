@@ -0,0 +1,492 @@
+package model_mysql
+
+import (
+	"assignment/util"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"testing"
+	"time"
+)
+
+// capturedArg matches any argument and remembers it
+type capturedArg struct {
+	value driver.Value
+}
+
+func (arg *capturedArg) Match(v driver.Value) bool {
+	arg.value = v
+	return true
+}
+
+const (
+	insertTokenQuery        = "INSERT INTO `tokens` (`session_id`,`user_id`,`family_id`,`device_id`,`device_name`,`user_agent`,`ip_address`,`issued_at`,`last_seen_at`,`expired_at`,`session_expired_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?)"
+	insertRefreshTokenQuery = "INSERT INTO `refresh_tokens` (`token_hash`,`family_id`,`user_id`,`created_at`,`expired_at`,`used_at`) VALUES (?,?,?,?,?,?)"
+	selectGreetingQuery     = "SELECT greeting FROM `user_greetings` WHERE user_id = ?"
+	activeSessionsQuery     = "SELECT `session_id` FROM `tokens` WHERE user_id = ? AND session_expired_at > ? ORDER BY COALESCE(last_seen_at, issued_at) DESC FOR UPDATE"
+	selectRefreshTokenQuery = "SELECT * FROM `refresh_tokens` WHERE token_hash = ? LIMIT ? FOR UPDATE"
+)
+
+func TestCreateSessionToken_Success(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := &ModelMysqlRepository{}
+	ctx := context.Background()
+	userID := "user-1"
+	device := SessionDevice{DeviceId: "device-1", DeviceName: "Pixel", UserAgent: "okhttp", IpAddress: "10.0.0.1"}
+
+	mock.ExpectBegin()
+
+	mock.ExpectExec("UPDATE `tokens` SET `expired_at`=?,`session_expired_at`=? WHERE (user_id = ? AND device_id = ?) AND session_expired_at > ?").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID, device.DeviceId, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectQuery(activeSessionsQuery).
+		WithArgs(userID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"session_id"}).AddRow("s1"))
+
+	storedSessionId := &capturedArg{}
+	familyId := &capturedArg{}
+	mock.ExpectExec(insertTokenQuery).
+		WithArgs(storedSessionId, userID, familyId, device.DeviceId, device.DeviceName, device.UserAgent, device.IpAddress,
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	storedRefreshToken := &capturedArg{}
+	refreshFamilyId := &capturedArg{}
+	mock.ExpectExec(insertRefreshTokenQuery).
+		WithArgs(storedRefreshToken, refreshFamilyId, userID, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	mockGreeting := "Welcome back!"
+	mock.ExpectQuery(selectGreetingQuery).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"greeting"}).AddRow(mockGreeting))
+
+	mock.ExpectCommit()
+
+	policy := SessionPolicy{MaxSessions: 5, AccessTokenLifetime: 15 * time.Minute, RefreshTokenLifetime: 24 * time.Hour}
+	tokens, greeting, err := repo.CreateSessionToken(ctx, userID, device, policy)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tokens.AccessToken == "" || tokens.RefreshToken == "" || tokens.AccessToken == tokens.RefreshToken {
+		t.Fatalf("expected distinct non-empty tokens, got %+v", tokens)
+	}
+	if greeting != mockGreeting {
+		t.Fatalf("expected greeting %q, got %q", mockGreeting, greeting)
+	}
+	if storedSessionId.value != util.HashSessionToken(tokens.AccessToken) {
+		t.Fatalf("expected the hash of the access token to be stored, got %v", storedSessionId.value)
+	}
+	if storedRefreshToken.value != util.HashSessionToken(tokens.RefreshToken) {
+		t.Fatalf("expected the hash of the refresh token to be stored, got %v", storedRefreshToken.value)
+	}
+	if familyId.value == "" || familyId.value != refreshFamilyId.value {
+		t.Fatalf("expected session and refresh token to share a family, got %v and %v", familyId.value, refreshFamilyId.value)
+	}
+	if lifetime := time.Until(tokens.AccessTokenExpiredAt); lifetime <= 14*time.Minute || lifetime > 15*time.Minute {
+		t.Fatalf("unexpected access token lifetime: %s", lifetime)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestCreateSessionToken_ExpiresLeastRecentlyUsedOverLimit(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := &ModelMysqlRepository{}
+	ctx := context.Background()
+	userID := "user-2"
+
+	mock.ExpectBegin()
+
+	mock.ExpectQuery(activeSessionsQuery).
+		WithArgs(userID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"session_id"}).AddRow("newest").AddRow("older").AddRow("oldest"))
+
+	mock.ExpectExec("UPDATE `tokens` SET `expired_at`=?,`session_expired_at`=? WHERE session_id IN (?,?)").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "older", "oldest").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	mock.ExpectExec(insertTokenQuery).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(insertRefreshTokenQuery).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	mock.ExpectQuery(selectGreetingQuery).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"greeting"}).AddRow("hi"))
+
+	mock.ExpectCommit()
+
+	if _, _, err := repo.CreateSessionToken(ctx, userID, SessionDevice{}, SessionPolicy{MaxSessions: 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestCreateSessionToken_UnlimitedSkipsLimitCheck(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := &ModelMysqlRepository{}
+	ctx := context.Background()
+	userID := "user-3"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(insertTokenQuery).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(insertRefreshTokenQuery).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(selectGreetingQuery).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"greeting"}).AddRow("hi"))
+	mock.ExpectCommit()
+
+	if _, _, err := repo.CreateSessionToken(ctx, userID, SessionDevice{}, SessionPolicy{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestCreateSessionToken_InsertError(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := &ModelMysqlRepository{}
+	ctx := context.Background()
+	userID := "user-4"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(insertTokenQuery).
+		WillReturnError(errors.New("insert failed"))
+	mock.ExpectRollback()
+
+	tokens, greeting, err := repo.CreateSessionToken(ctx, userID, SessionDevice{}, SessionPolicy{})
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	if tokens.AccessToken != "" || greeting != "" {
+		t.Fatalf("expected empty outputs on error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestCreateSessionToken_GreetingQueryError(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := &ModelMysqlRepository{}
+	ctx := context.Background()
+	userID := "user-5"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(insertTokenQuery).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(insertRefreshTokenQuery).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(selectGreetingQuery).
+		WithArgs(userID).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	tokens, greeting, err := repo.CreateSessionToken(ctx, userID, SessionDevice{}, SessionPolicy{})
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	if tokens.AccessToken != "" || greeting != "" {
+		t.Fatalf("expected empty outputs on error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestGetActiveSessions_Success(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectQuery("SELECT * FROM `tokens` WHERE user_id = ? AND session_expired_at > ? ORDER BY COALESCE(last_seen_at, issued_at) DESC").
+		WithArgs("user-1", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"session_id", "user_id", "device_name"}).
+			AddRow("s1", "user-1", "Pixel").
+			AddRow("s2", "user-1", "iPad"))
+
+	sessions, err := repo.GetActiveSessions(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].SessionId != "s1" || sessions[1].DeviceName != "iPad" {
+		t.Fatalf("unexpected sessions: %+v", sessions)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestRevokeSession_NotFound(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `tokens` SET `expired_at`=?,`session_expired_at`=? WHERE (session_id = ? AND user_id = ?) AND session_expired_at > ?").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "s1", "user-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.RevokeSession(context.Background(), "user-1", "s1")
+	if !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestRevokeOtherSessions_Success(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `tokens` SET `expired_at`=?,`session_expired_at`=? WHERE (user_id = ? AND session_id <> ?) AND session_expired_at > ?").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "user-1", "current", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	revoked, err := repo.RevokeOtherSessions(context.Background(), "user-1", "current")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if revoked != 3 {
+		t.Fatalf("expected 3 revoked sessions, got %d", revoked)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func refreshTokenRows(familyId string, expiredAt time.Time, usedAt *time.Time) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"token_hash", "family_id", "user_id", "created_at", "expired_at", "used_at"}).
+		AddRow(util.HashSessionToken("refresh-1"), familyId, "user-1", time.Now(), expiredAt, usedAt)
+}
+
+func TestRefreshSessionToken_Rotates(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := &ModelMysqlRepository{}
+	policy := SessionPolicy{AccessTokenLifetime: 15 * time.Minute, RefreshTokenLifetime: 24 * time.Hour}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectRefreshTokenQuery).
+		WithArgs(util.HashSessionToken("refresh-1"), 1).
+		WillReturnRows(refreshTokenRows("family-1", time.Now().Add(time.Hour), nil))
+	mock.ExpectExec("UPDATE `refresh_tokens` SET `used_at`=? WHERE `token_hash` = ?").
+		WithArgs(sqlmock.AnyArg(), util.HashSessionToken("refresh-1")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	storedSessionId := &capturedArg{}
+	mock.ExpectExec("UPDATE `tokens` SET `expired_at`=?,`last_seen_at`=?,`session_expired_at`=?,`session_id`=? WHERE family_id = ? AND session_expired_at > ?").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), storedSessionId, "family-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	storedRefreshToken := &capturedArg{}
+	mock.ExpectExec(insertRefreshTokenQuery).
+		WithArgs(storedRefreshToken, "family-1", "user-1", sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	tokens, err := repo.RefreshSessionToken(context.Background(), "refresh-1", policy)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tokens.RefreshToken == "" || tokens.RefreshToken == "refresh-1" {
+		t.Fatalf("expected a new refresh token, got %q", tokens.RefreshToken)
+	}
+	if storedSessionId.value != util.HashSessionToken(tokens.AccessToken) {
+		t.Fatalf("expected the hash of the new access token to be stored, got %v", storedSessionId.value)
+	}
+	if storedRefreshToken.value != util.HashSessionToken(tokens.RefreshToken) {
+		t.Fatalf("expected the hash of the new refresh token to be stored, got %v", storedRefreshToken.value)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestRefreshSessionToken_ReuseRevokesFamily(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := &ModelMysqlRepository{}
+	usedAt := time.Now().Add(-time.Minute)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectRefreshTokenQuery).
+		WithArgs(util.HashSessionToken("refresh-1"), 1).
+		WillReturnRows(refreshTokenRows("family-1", time.Now().Add(time.Hour), &usedAt))
+	mock.ExpectExec("UPDATE `tokens` SET `expired_at`=?,`session_expired_at`=? WHERE family_id = ? AND session_expired_at > ?").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "family-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE `refresh_tokens` SET `used_at`=? WHERE family_id = ? AND used_at IS NULL").
+		WithArgs(sqlmock.AnyArg(), "family-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	_, err := repo.RefreshSessionToken(context.Background(), "refresh-1", SessionPolicy{})
+	if !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("expected ErrRefreshTokenReused, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestRefreshSessionToken_Expired(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectRefreshTokenQuery).
+		WithArgs(util.HashSessionToken("refresh-1"), 1).
+		WillReturnRows(refreshTokenRows("family-1", time.Now().Add(-time.Second), nil))
+	mock.ExpectRollback()
+
+	_, err := repo.RefreshSessionToken(context.Background(), "refresh-1", SessionPolicy{})
+	if !errors.Is(err, ErrRefreshTokenInvalid) {
+		t.Fatalf("expected ErrRefreshTokenInvalid, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestRefreshSessionToken_SessionEnded(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectRefreshTokenQuery).
+		WithArgs(util.HashSessionToken("refresh-1"), 1).
+		WillReturnRows(refreshTokenRows("family-1", time.Now().Add(time.Hour), nil))
+	mock.ExpectExec("UPDATE `refresh_tokens` SET `used_at`=? WHERE `token_hash` = ?").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE `tokens` SET `expired_at`=?,`last_seen_at`=?,`session_expired_at`=?,`session_id`=? WHERE family_id = ? AND session_expired_at > ?").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := repo.RefreshSessionToken(context.Background(), "refresh-1", SessionPolicy{})
+	if !errors.Is(err, ErrRefreshTokenInvalid) {
+		t.Fatalf("expected ErrRefreshTokenInvalid, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestRefreshSessionToken_Unknown(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := &ModelMysqlRepository{}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectRefreshTokenQuery).
+		WithArgs(util.HashSessionToken("nope"), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"token_hash"}))
+	mock.ExpectRollback()
+
+	_, err := repo.RefreshSessionToken(context.Background(), "nope", SessionPolicy{})
+	if !errors.Is(err, ErrRefreshTokenInvalid) {
+		t.Fatalf("expected ErrRefreshTokenInvalid, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+type fakeIssuer struct {
+	userId    string
+	sessionId string
+}
+
+func (issuer *fakeIssuer) Issue(userId, sessionId string, expiredAt time.Time) (string, error) {
+	issuer.userId, issuer.sessionId = userId, sessionId
+	return "signed." + sessionId, nil
+}
+
+func TestCreateSessionToken_UsesAccessTokenIssuer(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := &ModelMysqlRepository{}
+	userID := "user-6"
+	issuer := &fakeIssuer{}
+
+	mock.ExpectBegin()
+	storedSessionId := &capturedArg{}
+	familyId := &capturedArg{}
+	mock.ExpectExec(insertTokenQuery).
+		WithArgs(storedSessionId, userID, familyId, "", "", "", "",
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(insertRefreshTokenQuery).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(selectGreetingQuery).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"greeting"}).AddRow("hi"))
+	mock.ExpectCommit()
+
+	tokens, _, err := repo.CreateSessionToken(context.Background(), userID, SessionDevice{}, SessionPolicy{AccessTokenIssuer: issuer})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if issuer.userId != userID || issuer.sessionId != familyId.value {
+		t.Fatalf("issuer got user %q session %q, want session %v", issuer.userId, issuer.sessionId, familyId.value)
+	}
+	if tokens.AccessToken != "signed."+issuer.sessionId {
+		t.Fatalf("expected the issued access token, got %q", tokens.AccessToken)
+	}
+	if storedSessionId.value != util.HashSessionToken(tokens.AccessToken) {
+		t.Fatalf("expected the hash of the issued token to be stored, got %v", storedSessionId.value)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
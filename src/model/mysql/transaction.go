@@ -0,0 +1,21 @@
+package model_mysql
+
+import (
+	"assignment/datastore/mysql"
+	"assignment/entity"
+	"context"
+)
+
+// GetUserTransactions returns up to limit transactions of the user ordered by transaction_id,
+// starting right after the given cursor (empty cursor means first page).
+func (repository *ModelMysqlRepository) GetUserTransactions(ctx context.Context, userId, cursor string, limit int) ([]entity.Transactions, error) {
+	var result []entity.Transactions
+	query := mysql.DB.WithContext(ctx).Where("user_id = ?", userId)
+	if cursor != "" {
+		query = query.Where("transaction_id > ?", cursor)
+	}
+	if err := query.Order("transaction_id").Limit(limit).Find(&result).Error; err != nil {
+		return nil, err
+	}
+	return result, nil
+}
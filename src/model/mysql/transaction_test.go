@@ -0,0 +1,86 @@
+package model_mysql
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestGetUserTransactions_FirstPage(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	userID := "user-tx"
+
+	query := "SELECT * FROM `transactions` WHERE user_id = ? ORDER BY transaction_id LIMIT ?"
+
+	rows := sqlmock.NewRows([]string{"transaction_id", "user_id", "name", "image", "isBank"}).
+		AddRow("tx-1", userID, "Alice", "https://cdn/img1.png", 1).
+		AddRow("tx-2", userID, "Bob", "https://cdn/img2.png", 0)
+
+	mock.ExpectQuery(query).WithArgs(userID, 3).WillReturnRows(rows)
+
+	res, err := repo.GetUserTransactions(context.Background(), userID, "", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res) != 2 {
+		t.Fatalf("expected 2 transactions, got %d", len(res))
+	}
+	if res[0].TransactionId != "tx-1" || !res[0].IsBank {
+		t.Fatalf("unexpected first transaction: %+v", res[0])
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestGetUserTransactions_WithCursor(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	userID := "user-tx"
+
+	query := "SELECT * FROM `transactions` WHERE user_id = ? AND transaction_id > ? ORDER BY transaction_id LIMIT ?"
+
+	rows := sqlmock.NewRows([]string{"transaction_id", "user_id", "name", "image", "isBank"}).
+		AddRow("tx-3", userID, "Carol", "https://cdn/img3.png", 0)
+
+	mock.ExpectQuery(query).WithArgs(userID, "tx-2", 3).WillReturnRows(rows)
+
+	res, err := repo.GetUserTransactions(context.Background(), userID, "tx-2", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res) != 1 || res[0].TransactionId != "tx-3" {
+		t.Fatalf("unexpected transactions: %+v", res)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestGetUserTransactions_QueryError(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	userID := "user-tx-err"
+
+	query := "SELECT * FROM `transactions` WHERE user_id = ? ORDER BY transaction_id LIMIT ?"
+	mock.ExpectQuery(query).WithArgs(userID, 3).WillReturnError(gorm.ErrInvalidDB)
+
+	_, err := repo.GetUserTransactions(context.Background(), userID, "", 3)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+package model_mysql
+
+import (
+	"assignment/accountnumber"
+	"assignment/datastore/mysql"
+	"assignment/entity"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrAccountNotFound   = errors.New("account not found")
+	ErrPayeeNotFound     = errors.New("saved account not found")
+	ErrSameAccount       = errors.New("source and destination account are the same")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrCurrencyMismatch  = errors.New("source and destination currency mismatch")
+)
+
+type TransferRequest struct {
+	FromAccountId   string
+	ToAccountId     string
+	ToAccountNumber string
+	Amount          decimal.Decimal
+	Note            string
+}
+
+// Transfer moves money out of one of the user's accounts into either another own account (ToAccountId)
+// or one of the user's saved accounts (ToAccountNumber). When the saved account is also held in this
+// service its balance is credited as well, otherwise only the ledger records the outgoing credit.
+// It returns the created transfer and the remaining balance of the source account.
+func (repository *ModelMysqlRepository) Transfer(ctx context.Context, userId string, request TransferRequest) (entity.Transfers, decimal.Decimal, error) {
+	var transfer entity.Transfers
+	var remaining decimal.Decimal
+
+	err := mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var source entity.Accounts
+		if err := tx.Where("account_id = ? AND user_id = ?", request.FromAccountId, userId).Take(&source).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrAccountNotFound
+			}
+			return err
+		}
+
+		var destination entity.Accounts
+		toAccountNumber := request.ToAccountNumber
+		if request.ToAccountId != "" {
+			if request.ToAccountId == source.AccountId {
+				return ErrSameAccount
+			}
+			if err := tx.Where("account_id = ? AND user_id = ?", request.ToAccountId, userId).Take(&destination).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return ErrAccountNotFound
+				}
+				return err
+			}
+			toAccountNumber = destination.AccountNumber
+		} else {
+			var saved entity.SavedAccounts
+			if err := tx.Where("user_id = ? AND account_number = ?", userId, request.ToAccountNumber).Take(&saved).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return ErrPayeeNotFound
+				}
+				return err
+			}
+			// The saved number may be formatted differently from the account, it is matched on its unique digits
+			if err := tx.Where("account_digits = ?", accountnumber.Normalize(saved.AccountNumber)).Take(&destination).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if destination.AccountId == source.AccountId {
+				return ErrSameAccount
+			}
+		}
+
+		if destination.AccountId != "" && destination.Currency != source.Currency {
+			return ErrCurrencyMismatch
+		}
+
+		// Lock balances in account_id order so two opposite transfers cannot deadlock each other
+		lockIds := []string{source.AccountId}
+		if destination.AccountId != "" {
+			lockIds = append(lockIds, destination.AccountId)
+		}
+		var balances []entity.AccountBalances
+		if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("account_id IN ?", lockIds).
+			Order("account_id").
+			Find(&balances).Error; err != nil {
+			return err
+		}
+		if len(balances) != len(lockIds) {
+			return ErrAccountNotFound
+		}
+
+		for _, balance := range balances {
+			if balance.AccountId == source.AccountId {
+				remaining = balance.Amount.Sub(request.Amount)
+			}
+		}
+		if remaining.IsNegative() {
+			return ErrInsufficientFunds
+		}
+
+		if err := tx.Model(&entity.AccountBalances{}).
+			Where("account_id = ?", source.AccountId).
+			Update("amount", gorm.Expr("amount - ?", request.Amount)).Error; err != nil {
+			return err
+		}
+		if destination.AccountId != "" {
+			if err := tx.Model(&entity.AccountBalances{}).
+				Where("account_id = ?", destination.AccountId).
+				Update("amount", gorm.Expr("amount + ?", request.Amount)).Error; err != nil {
+				return err
+			}
+		}
+
+		transfer = entity.Transfers{
+			TransferId:      uuid.New().String(),
+			UserId:          userId,
+			FromAccountId:   source.AccountId,
+			ToAccountId:     destination.AccountId,
+			ToAccountNumber: toAccountNumber,
+			Amount:          request.Amount,
+			Currency:        source.Currency,
+			Note:            request.Note,
+		}
+		if err := tx.Create(&transfer).Error; err != nil {
+			return err
+		}
+
+		entries := []entity.LedgerEntries{
+			{
+				TransferId:    transfer.TransferId,
+				AccountId:     source.AccountId,
+				AccountNumber: source.AccountNumber,
+				EntryType:     entity.LedgerEntryDebit,
+				Amount:        request.Amount,
+				Currency:      source.Currency,
+			},
+			{
+				TransferId:    transfer.TransferId,
+				AccountId:     destination.AccountId,
+				AccountNumber: toAccountNumber,
+				EntryType:     entity.LedgerEntryCredit,
+				Amount:        request.Amount,
+				Currency:      source.Currency,
+			},
+		}
+		if err := tx.Create(&entries).Error; err != nil {
+			return err
+		}
+		return nil
+	})
+
+	if err != nil {
+		return entity.Transfers{}, decimal.Zero, err // rollback
+	}
+	return transfer, remaining, nil
+}
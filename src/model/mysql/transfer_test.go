@@ -0,0 +1,263 @@
+package model_mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	transferSourceQuery  = "SELECT * FROM `accounts` WHERE account_id = ? AND user_id = ? LIMIT ?"
+	transferBalanceQuery = "SELECT * FROM `account_balances` WHERE account_id IN (?,?) ORDER BY account_id FOR UPDATE"
+	transferDebitQuery   = "UPDATE `account_balances` SET `amount`=amount - ? WHERE account_id = ?"
+	transferCreditQuery  = "UPDATE `account_balances` SET `amount`=amount + ? WHERE account_id = ?"
+	transferInsertQuery  = "INSERT INTO `transfers` (`transfer_id`,`user_id`,`from_account_id`,`to_account_id`,`to_account_number`,`amount`,`currency`,`note`,`created_at`) VALUES (?,?,?,?,?,?,?,?,?)"
+	transferDestQuery    = "SELECT * FROM `accounts` WHERE account_digits = ? LIMIT ?"
+	transferLedgerQuery  = "INSERT INTO `ledger_entries` (`transfer_id`,`account_id`,`account_number`,`entry_type`,`amount`,`currency`,`created_at`) VALUES (?,?,?,?,?,?,?),(?,?,?,?,?,?,?)"
+)
+
+var accountColumns = []string{"account_id", "user_id", "type", "currency", "account_number", "issuer"}
+
+func TestTransfer_OwnAccount_Success(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	userID := "user-1"
+	amount := decimal.RequireFromString("100.50")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(transferSourceQuery).WithArgs("acc-1", userID, 1).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow("acc-1", userID, "saving", "THB", "111", "TestLab"))
+	mock.ExpectQuery(transferSourceQuery).WithArgs("acc-2", userID, 1).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow("acc-2", userID, "saving", "THB", "222", "TestLab"))
+	mock.ExpectQuery(transferBalanceQuery).WithArgs("acc-1", "acc-2").
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "user_id", "amount"}).
+			AddRow("acc-1", userID, "1000.00").
+			AddRow("acc-2", userID, "5.00"))
+	mock.ExpectExec(transferDebitQuery).WithArgs(amount, "acc-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(transferCreditQuery).WithArgs(amount, "acc-2").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(transferInsertQuery).
+		WithArgs(sqlmock.AnyArg(), userID, "acc-1", "acc-2", "222", amount, "THB", "", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(transferLedgerQuery).
+		WithArgs(
+			sqlmock.AnyArg(), "acc-1", "111", "DEBIT", amount, "THB", sqlmock.AnyArg(),
+			sqlmock.AnyArg(), "acc-2", "222", "CREDIT", amount, "THB", sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectCommit()
+
+	transfer, remaining, err := repo.Transfer(context.Background(), userID, TransferRequest{
+		FromAccountId: "acc-1",
+		ToAccountId:   "acc-2",
+		Amount:        amount,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transfer.TransferId == "" || transfer.ToAccountNumber != "222" {
+		t.Fatalf("unexpected transfer: %+v", transfer)
+	}
+	if !remaining.Equal(decimal.RequireFromString("899.50")) {
+		t.Fatalf("expected remaining 899.50, got %s", remaining)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestTransfer_InsufficientFunds(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	userID := "user-1"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(transferSourceQuery).WithArgs("acc-1", userID, 1).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow("acc-1", userID, "saving", "THB", "111", "TestLab"))
+	mock.ExpectQuery(transferSourceQuery).WithArgs("acc-2", userID, 1).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow("acc-2", userID, "saving", "THB", "222", "TestLab"))
+	mock.ExpectQuery(transferBalanceQuery).WithArgs("acc-1", "acc-2").
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "user_id", "amount"}).
+			AddRow("acc-1", userID, "10.00").
+			AddRow("acc-2", userID, "5.00"))
+	mock.ExpectRollback()
+
+	_, _, err := repo.Transfer(context.Background(), userID, TransferRequest{
+		FromAccountId: "acc-1",
+		ToAccountId:   "acc-2",
+		Amount:        decimal.RequireFromString("10.01"),
+	})
+	if !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("expected ErrInsufficientFunds, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestTransfer_CurrencyMismatch(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	userID := "user-1"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(transferSourceQuery).WithArgs("acc-1", userID, 1).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow("acc-1", userID, "saving", "THB", "111", "TestLab"))
+	mock.ExpectQuery(transferSourceQuery).WithArgs("acc-2", userID, 1).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow("acc-2", userID, "fcd", "USD", "222", "TestLab"))
+	mock.ExpectRollback()
+
+	_, _, err := repo.Transfer(context.Background(), userID, TransferRequest{
+		FromAccountId: "acc-1",
+		ToAccountId:   "acc-2",
+		Amount:        decimal.NewFromInt(1),
+	})
+	if !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatalf("expected ErrCurrencyMismatch, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestTransfer_SavedAccount_External(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	userID := "user-1"
+	amount := decimal.NewFromInt(20)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(transferSourceQuery).WithArgs("acc-1", userID, 1).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow("acc-1", userID, "saving", "THB", "111", "TestLab"))
+	mock.ExpectQuery("SELECT * FROM `saved_accounts` WHERE user_id = ? AND account_number = ? LIMIT ?").
+		WithArgs(userID, "999", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "account_name", "account_number"}).AddRow(userID, "Payee", "999"))
+	mock.ExpectQuery(transferDestQuery).
+		WithArgs("999", 1).
+		WillReturnRows(sqlmock.NewRows(accountColumns))
+	mock.ExpectQuery("SELECT * FROM `account_balances` WHERE account_id IN (?) ORDER BY account_id FOR UPDATE").
+		WithArgs("acc-1").
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "user_id", "amount"}).AddRow("acc-1", userID, "20.00"))
+	mock.ExpectExec(transferDebitQuery).WithArgs(amount, "acc-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(transferInsertQuery).
+		WithArgs(sqlmock.AnyArg(), userID, "acc-1", "", "999", amount, "THB", "rent", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(transferLedgerQuery).
+		WithArgs(
+			sqlmock.AnyArg(), "acc-1", "111", "DEBIT", amount, "THB", sqlmock.AnyArg(),
+			sqlmock.AnyArg(), "", "999", "CREDIT", amount, "THB", sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectCommit()
+
+	_, remaining, err := repo.Transfer(context.Background(), userID, TransferRequest{
+		FromAccountId:   "acc-1",
+		ToAccountNumber: "999",
+		Amount:          amount,
+		Note:            "rent",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !remaining.IsZero() {
+		t.Fatalf("expected zero remaining balance, got %s", remaining)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestTransfer_SavedAccount_Internal(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	userID := "user-1"
+	amount := decimal.NewFromInt(20)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(transferSourceQuery).WithArgs("acc-1", userID, 1).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow("acc-1", userID, "saving", "THB", "111", "TestLab"))
+	mock.ExpectQuery("SELECT * FROM `saved_accounts` WHERE user_id = ? AND account_number = ? LIMIT ?").
+		WithArgs(userID, "568-2-90992", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "account_name", "account_number"}).AddRow(userID, "Payee", "568-2-90992"))
+	mock.ExpectQuery(transferDestQuery).
+		WithArgs("568290992", 1).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow("acc-9", "user-9", "saving", "THB", "568-2-90992", "TestLab"))
+	mock.ExpectQuery("SELECT * FROM `account_balances` WHERE account_id IN (?,?) ORDER BY account_id FOR UPDATE").
+		WithArgs("acc-1", "acc-9").
+		WillReturnRows(sqlmock.NewRows([]string{"account_id", "user_id", "amount"}).
+			AddRow("acc-1", userID, "20.00").
+			AddRow("acc-9", "user-9", "0.00"))
+	mock.ExpectExec(transferDebitQuery).WithArgs(amount, "acc-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(transferCreditQuery).WithArgs(amount, "acc-9").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(transferInsertQuery).
+		WithArgs(sqlmock.AnyArg(), userID, "acc-1", "acc-9", "568-2-90992", amount, "THB", "", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(transferLedgerQuery).
+		WithArgs(
+			sqlmock.AnyArg(), "acc-1", "111", "DEBIT", amount, "THB", sqlmock.AnyArg(),
+			sqlmock.AnyArg(), "acc-9", "568-2-90992", "CREDIT", amount, "THB", sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectCommit()
+
+	transfer, _, err := repo.Transfer(context.Background(), userID, TransferRequest{
+		FromAccountId:   "acc-1",
+		ToAccountNumber: "568-2-90992",
+		Amount:          amount,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transfer.ToAccountId != "acc-9" {
+		t.Fatalf("expected the saved account to be credited, got %+v", transfer)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestTransfer_PayeeNotFound(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	userID := "user-1"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(transferSourceQuery).WithArgs("acc-1", userID, 1).
+		WillReturnRows(sqlmock.NewRows(accountColumns).AddRow("acc-1", userID, "saving", "THB", "111", "TestLab"))
+	mock.ExpectQuery("SELECT * FROM `saved_accounts` WHERE user_id = ? AND account_number = ? LIMIT ?").
+		WithArgs(userID, "999", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "account_name", "account_number"}))
+	mock.ExpectRollback()
+
+	_, _, err := repo.Transfer(context.Background(), userID, TransferRequest{
+		FromAccountId:   "acc-1",
+		ToAccountNumber: "999",
+		Amount:          decimal.NewFromInt(1),
+	})
+	if !errors.Is(err, ErrPayeeNotFound) {
+		t.Fatalf("expected ErrPayeeNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
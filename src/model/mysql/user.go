@@ -6,8 +6,13 @@ import (
 	"context"
 	"errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strconv"
+	"time"
 )
 
+var ErrUserNotFound = errors.New("user not found")
+
 type User struct {
 	Name      string `json:"name"`
 	DummyCol1 string `json:"dummy_col1"`
@@ -17,7 +22,7 @@ func (repository *ModelMysqlRepository) GetUser(ctx context.Context, userId stri
 	var user entity.Users
 	if err := mysql.DB.WithContext(ctx).Where("user_id = ?", userId).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return User{}, errors.New("user not found")
+			return User{}, ErrUserNotFound
 		} else {
 			return User{}, err
 		}
@@ -25,3 +30,197 @@ func (repository *ModelMysqlRepository) GetUser(ctx context.Context, userId stri
 
 	return User{Name: user.Name, DummyCol1: user.DummyCol1}, nil
 }
+
+type UserProfile struct {
+	UserId            string
+	Name              string
+	Greeting          string
+	MainAccountNumber string
+	Language          string
+	NotifyPush        bool
+	NotifyEmail       bool
+	NotifySms         bool
+}
+
+// GetUserProfile reads the user together with the greeting, main account number and preferences, any of which may be
+// missing. Language is empty when the user never chose one, notifications default to on like the table defaults.
+func (repository *ModelMysqlRepository) GetUserProfile(ctx context.Context, userId string) (UserProfile, error) {
+	var result []UserProfile
+	if err := mysql.DB.WithContext(ctx).
+		Table("users AS u").
+		Select(`
+			u.user_id,
+			u.name,
+			COALESCE(ug.greeting, '') AS greeting,
+			COALESCE(a.account_number, '') AS main_account_number,
+			COALESCE(up.language, '') AS language,
+			COALESCE(up.notify_push, 1) AS notify_push,
+			COALESCE(up.notify_email, 1) AS notify_email,
+			COALESCE(up.notify_sms, 1) AS notify_sms
+		`).
+		Joins("LEFT JOIN user_greetings AS ug ON ug.user_id = u.user_id").
+		Joins("LEFT JOIN account_details AS ad ON ad.user_id = u.user_id AND ad.is_main_account = 1").
+		Joins("LEFT JOIN accounts AS a ON a.account_id = ad.account_id AND a.user_id = u.user_id").
+		Joins("LEFT JOIN user_preferences AS up ON up.user_id = u.user_id").
+		Where("u.user_id = ?", userId).
+		Limit(1).
+		Scan(&result).Error; err != nil {
+		return UserProfile{}, err
+	}
+	if len(result) == 0 {
+		return UserProfile{}, ErrUserNotFound
+	}
+	return result[0], nil
+}
+
+// GetGreetingTranslations returns the greetings of the user in the given languages
+func (repository *ModelMysqlRepository) GetGreetingTranslations(ctx context.Context, userId string, languages []string) ([]entity.UserGreetingTranslations, error) {
+	var translations []entity.UserGreetingTranslations
+	if err := mysql.DB.WithContext(ctx).
+		Where("user_id = ? AND language IN ?", userId, languages).
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// ProfileChanges holds the profile fields to update, nil fields are left untouched
+type ProfileChanges struct {
+	Name     *string
+	Greeting *string
+}
+
+// PreferenceChanges holds the preferences to update, nil fields are left untouched
+type PreferenceChanges struct {
+	Language    *string
+	NotifyPush  *bool
+	NotifyEmail *bool
+	NotifySms   *bool
+}
+
+// lockUser locks the user row so that concurrent profile and preference updates of a user are audited in order
+func lockUser(tx *gorm.DB, userId string) (entity.Users, error) {
+	var user entity.Users
+	if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("user_id = ?", userId).
+		Take(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Users{}, ErrUserNotFound
+		}
+		return entity.Users{}, err
+	}
+	return user, nil
+}
+
+func (repository *ModelMysqlRepository) newProfileAudit(userId, field, oldValue, newValue string, now time.Time) entity.UserProfileAudits {
+	return entity.UserProfileAudits{
+		UserId:    userId,
+		Field:     field,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+		RequestId: repository.RequestId,
+		CreatedAt: now,
+	}
+}
+
+// UpdateUserProfile updates the display name and greeting and writes an audit row for every value that changed
+func (repository *ModelMysqlRepository) UpdateUserProfile(ctx context.Context, userId string, changes ProfileChanges) error {
+	return mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		user, err := lockUser(tx, userId)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		var audits []entity.UserProfileAudits
+
+		if changes.Name != nil && *changes.Name != user.Name {
+			if err := tx.Model(&entity.Users{}).Where("user_id = ?", userId).
+				UpdateColumn("name", *changes.Name).Error; err != nil {
+				return err
+			}
+			audits = append(audits, repository.newProfileAudit(userId, "name", user.Name, *changes.Name, now))
+		}
+
+		if changes.Greeting != nil {
+			var greeting entity.UserGreetings
+			if err := tx.Where("user_id = ?", userId).Take(&greeting).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if *changes.Greeting != greeting.Greeting {
+				if err := tx.Clauses(clause.OnConflict{
+					Columns:   []clause.Column{{Name: "user_id"}},
+					DoUpdates: clause.AssignmentColumns([]string{"greeting"}),
+				}).Select("user_id", "greeting").
+					Create(&entity.UserGreetings{UserId: userId, Greeting: *changes.Greeting}).Error; err != nil {
+					return err
+				}
+				audits = append(audits, repository.newProfileAudit(userId, "greeting", greeting.Greeting, *changes.Greeting, now))
+			}
+		}
+
+		if len(audits) == 0 {
+			return nil
+		}
+		return tx.Create(&audits).Error
+	})
+}
+
+// UpdateUserPreferences creates or updates the preferences of the user and writes an audit row for every value that
+// changed. Users without a preferences row start from defaultLanguage with every notification turned on.
+func (repository *ModelMysqlRepository) UpdateUserPreferences(ctx context.Context, userId, defaultLanguage string, changes PreferenceChanges) error {
+	return mysql.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockUser(tx, userId); err != nil {
+			return err
+		}
+
+		var preferences entity.UserPreferences
+		if err := tx.Where("user_id = ?", userId).Take(&preferences).Error; err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			preferences = entity.UserPreferences{
+				UserId:      userId,
+				Language:    defaultLanguage,
+				NotifyPush:  true,
+				NotifyEmail: true,
+				NotifySms:   true,
+			}
+		}
+
+		now := time.Now()
+		var audits []entity.UserProfileAudits
+
+		if changes.Language != nil && *changes.Language != preferences.Language {
+			audits = append(audits, repository.newProfileAudit(userId, "language", preferences.Language, *changes.Language, now))
+			preferences.Language = *changes.Language
+		}
+		for _, setting := range []struct {
+			field   string
+			current *bool
+			changed *bool
+		}{
+			{"notify_push", &preferences.NotifyPush, changes.NotifyPush},
+			{"notify_email", &preferences.NotifyEmail, changes.NotifyEmail},
+			{"notify_sms", &preferences.NotifySms, changes.NotifySms},
+		} {
+			if setting.changed != nil && *setting.changed != *setting.current {
+				audits = append(audits, repository.newProfileAudit(userId, setting.field,
+					strconv.FormatBool(*setting.current), strconv.FormatBool(*setting.changed), now))
+				*setting.current = *setting.changed
+			}
+		}
+
+		if len(audits) == 0 {
+			return nil
+		}
+
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"language", "notify_push", "notify_email", "notify_sms", "updated_at"}),
+		}).Create(&preferences).Error; err != nil {
+			return err
+		}
+		return tx.Create(&audits).Error
+	})
+}
@@ -91,3 +91,228 @@ func TestGetUser_DBError(t *testing.T) {
 		t.Fatalf("unmet mock expectations: %v", err)
 	}
 }
+
+const userProfileQuery = `
+		SELECT
+			u.user_id,
+			u.name,
+			COALESCE(ug.greeting, '') AS greeting,
+			COALESCE(a.account_number, '') AS main_account_number,
+			COALESCE(up.language, '') AS language,
+			COALESCE(up.notify_push, 1) AS notify_push,
+			COALESCE(up.notify_email, 1) AS notify_email,
+			COALESCE(up.notify_sms, 1) AS notify_sms
+		FROM users AS u
+		LEFT JOIN user_greetings AS ug ON ug.user_id = u.user_id
+		LEFT JOIN account_details AS ad ON ad.user_id = u.user_id AND ad.is_main_account = 1
+		LEFT JOIN accounts AS a ON a.account_id = ad.account_id AND a.user_id = u.user_id
+		LEFT JOIN user_preferences AS up ON up.user_id = u.user_id
+		WHERE u.user_id = ? LIMIT ?
+	`
+
+func TestGetUserProfile_Success(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	userID := "user-1"
+
+	query := userProfileQuery
+
+	rows := sqlmock.NewRows([]string{"user_id", "name", "greeting", "main_account_number", "language", "notify_push", "notify_email", "notify_sms"}).
+		AddRow(userID, "Alice", "Hello Alice", "568-2-81740-9", "th", 1, 0, 1)
+
+	mock.ExpectQuery(query).WithArgs(userID, 1).WillReturnRows(rows)
+
+	got, err := repo.GetUserProfile(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := UserProfile{UserId: userID, Name: "Alice", Greeting: "Hello Alice", MainAccountNumber: "568-2-81740-9",
+		Language: "th", NotifyPush: true, NotifyEmail: false, NotifySms: true}
+	if got != expected {
+		t.Fatalf("unexpected profile: got %+v, want %+v", got, expected)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestGetUserProfile_NotFound(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	userID := "missing-user-id"
+
+	rows := sqlmock.NewRows([]string{"user_id", "name", "greeting", "main_account_number"})
+	mock.ExpectQuery(userProfileQuery).WithArgs(userID, 1).WillReturnRows(rows)
+
+	_, err := repo.GetUserProfile(context.Background(), userID)
+	if !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("expected ErrUserNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+const (
+	lockUserQuery          = "SELECT * FROM `users` WHERE user_id = ? LIMIT ? FOR UPDATE"
+	updateUserNameQuery    = "UPDATE `users` SET `name`=? WHERE user_id = ?"
+	insertProfileAudits    = "INSERT INTO `user_profile_audits` (`user_id`,`field`,`old_value`,`new_value`,`request_id`,`created_at`) VALUES (?,?,?,?,?,?)"
+	selectPreferencesQuery = "SELECT * FROM `user_preferences` WHERE user_id = ? LIMIT ?"
+	upsertPreferencesQuery = "INSERT INTO `user_preferences` (`user_id`,`language`,`notify_push`,`notify_email`,`notify_sms`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `language`=VALUES(`language`),`notify_push`=VALUES(`notify_push`),`notify_email`=VALUES(`notify_email`),`notify_sms`=VALUES(`notify_sms`),`updated_at`=VALUES(`updated_at`)"
+)
+
+func TestUpdateUserProfile_AuditsChangedName(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{RequestId: "req-1"}
+	userID := "user-1"
+	name := "Alice B."
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockUserQuery).WithArgs(userID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "name", "dummy_col_1"}).AddRow(userID, "Alice", ""))
+	mock.ExpectExec(updateUserNameQuery).WithArgs(name, userID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertProfileAudits).
+		WithArgs(userID, "name", "Alice", name, "req-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	if err := repo.UpdateUserProfile(context.Background(), userID, ProfileChanges{Name: &name}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestUpdateUserProfile_UnchangedNameSkipsWrites(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	userID := "user-1"
+	name := "Alice"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockUserQuery).WithArgs(userID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "name", "dummy_col_1"}).AddRow(userID, "Alice", ""))
+	mock.ExpectCommit()
+
+	if err := repo.UpdateUserProfile(context.Background(), userID, ProfileChanges{Name: &name}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestUpdateUserProfile_UserNotFound(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+	userID := "missing-user-id"
+	name := "Alice"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockUserQuery).WithArgs(userID, 1).WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectRollback()
+
+	err := repo.UpdateUserProfile(context.Background(), userID, ProfileChanges{Name: &name})
+	if !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("expected ErrUserNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestUpdateUserPreferences_CreatesRowFromDefaults(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{RequestId: "req-1"}
+	userID := "user-1"
+	off := false
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockUserQuery).WithArgs(userID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "name", "dummy_col_1"}).AddRow(userID, "Alice", ""))
+	mock.ExpectQuery(selectPreferencesQuery).WithArgs(userID, 1).WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectExec(upsertPreferencesQuery).
+		WithArgs(userID, "en", true, true, false, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertProfileAudits).
+		WithArgs(userID, "notify_sms", "true", "false", "req-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.UpdateUserPreferences(context.Background(), userID, "en", PreferenceChanges{NotifySms: &off})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestUpdateUserProfile_UpsertsGreeting(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{RequestId: "req-1"}
+	userID := "user-1"
+	greeting := "Have a nice day"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockUserQuery).WithArgs(userID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "name", "dummy_col_1"}).AddRow(userID, "Alice", ""))
+	mock.ExpectQuery("SELECT * FROM `user_greetings` WHERE user_id = ? LIMIT ?").WithArgs(userID, 1).WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectExec("INSERT INTO `user_greetings` (`user_id`,`greeting`) VALUES (?,?) ON DUPLICATE KEY UPDATE `greeting`=VALUES(`greeting`)").WithArgs(userID, greeting).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertProfileAudits).
+		WithArgs(userID, "greeting", "", greeting, "req-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	if err := repo.UpdateUserProfile(context.Background(), userID, ProfileChanges{Greeting: &greeting}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestGetGreetingTranslations(t *testing.T) {
+	mock, teardown := setupMockDB(t)
+	defer teardown()
+
+	repo := &ModelMysqlRepository{}
+
+	rows := sqlmock.NewRows([]string{"user_id", "language", "greeting"}).AddRow("test-user-id", "th", "สวัสดี")
+	mock.ExpectQuery("SELECT * FROM `user_greeting_translations` WHERE user_id = ? AND language IN (?,?)").
+		WithArgs("test-user-id", "lo", "th").WillReturnRows(rows)
+
+	translations, err := repo.GetGreetingTranslations(context.Background(), "test-user-id", []string{"lo", "th"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(translations) != 1 || translations[0].Greeting != "สวัสดี" {
+		t.Fatalf("unexpected translations: %+v", translations)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet mock expectations: %v", err)
+	}
+}
@@ -0,0 +1,23 @@
+package lognotifier
+
+import (
+	"assignment/logger"
+	"context"
+	"time"
+)
+
+// LogNotifier only writes notifications to the service log. It is meant for local and test
+// environments where no delivery provider is configured.
+type LogNotifier struct {
+	logger logger.LoggerIface
+}
+
+func NewNotifier() *LogNotifier {
+	return &LogNotifier{logger: logger.Logger}
+}
+
+func (n LogNotifier) SendPinResetCode(ctx context.Context, userId, code string, expiredAt time.Time) error {
+	n.logger.Infof("pin reset code issued for user %s, expires at %s", userId, expiredAt.Format(time.RFC3339))
+	n.logger.Debugf("pin reset code for user %s is %s", userId, code)
+	return nil
+}
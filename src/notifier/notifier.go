@@ -0,0 +1,13 @@
+package notifier
+
+import (
+	"context"
+	"time"
+)
+
+var Notifier NotifierIface
+
+// NotifierIface delivers out-of-band messages to users (SMS, push, e-mail, ...)
+type NotifierIface interface {
+	SendPinResetCode(ctx context.Context, userId, code string, expiredAt time.Time) error
+}
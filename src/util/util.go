@@ -1,19 +1,82 @@
 package util
 
 import (
+	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/sha3"
 	"math/big"
 	"strings"
+	"time"
+	"unicode"
 )
 
+const argon2idPrefix = "$argon2id$"
+
+// Limits of the parameters read back from a stored hash, a hash outside them is rejected instead of making
+// argon2.IDKey panic or spend an unbounded amount of memory and time
+const (
+	maxArgon2Memory      = 4 * 1024 * 1024 // KiB
+	maxArgon2Iterations  = 64
+	maxArgon2Parallelism = 64
+	minArgon2SaltLength  = 8
+	minArgon2KeyLength   = 16
+	maxArgon2KeyLength   = 1024
+)
+
+// Argon2Params is the cost of the argon2id pin hash, it is encoded into every hash so it can be raised
+// later without breaking the pins already stored
+type Argon2Params struct {
+	Memory      uint32 // KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+var DefaultArgon2Params = Argon2Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// PinHashParams and PinPepper are set from config at start up. The pepper is mixed into argon2id hashes
+// only, changing it invalidates every pin hashed with the previous one.
+var (
+	PinHashParams = DefaultArgon2Params
+	PinPepper     string
+)
+
+// HashPassword hashes with argon2id using PinHashParams and PinPepper,
+// e.g. $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>
 func HashPassword(plainTextPassword string) (string, error) {
-	salt := GenerateSalt()
-	return HashPasswordFixSalt(salt, plainTextPassword)
+	params := PinHashParams
+	salt, err := GenerateRandomBytes(int(params.SaltLength))
+	if err != nil {
+		return "", err
+	}
+	key := argon2.IDKey(pepper(plainTextPassword), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2idPrefix, argon2.Version, params.Memory, params.Iterations,
+		params.Parallelism, base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func pepper(plainTextPassword string) []byte {
+	if PinPepper == "" {
+		return []byte(plainTextPassword)
+	}
+	mac := hmac.New(sha256.New, []byte(PinPepper))
+	mac.Write([]byte(plainTextPassword))
+	return mac.Sum(nil)
 }
 
 func GenerateSalt() string {
@@ -35,6 +98,8 @@ func GenerateRandomStringFromSpecificCharacters(characters string, length int) (
 	return randomString, nil
 }
 
+// HashPasswordFixSalt is the legacy salt:hex SHAKE256 format, it is kept only to verify pins stored
+// before the move to argon2id
 func HashPasswordFixSalt(salt, plainTextPassword string) (string, error) {
 	buffer := []byte(plainTextPassword)
 
@@ -53,7 +118,12 @@ func HashPasswordFixSalt(salt, plainTextPassword string) (string, error) {
 	return salt + ":" + hex.EncodeToString(hmac), nil
 }
 
+// ValidatePin accepts both the argon2id format and the legacy salt:hex format
 func ValidatePin(plainPin, hashedPin string) (bool, error) {
+	if strings.HasPrefix(hashedPin, argon2idPrefix) {
+		return validateArgon2id(plainPin, hashedPin)
+	}
+
 	//get salt from hashedPassword
 	splitHashedPin := strings.Split(hashedPin, ":")
 	if len(splitHashedPin) != 2 {
@@ -68,6 +138,125 @@ func ValidatePin(plainPin, hashedPin string) (bool, error) {
 	return hashedFromPlain == hashedPin, nil
 }
 
+func validateArgon2id(plainPin, hashedPin string) (bool, error) {
+	params, salt, key, err := decodeArgon2id(hashedPin)
+	if err != nil {
+		return false, err
+	}
+	keyFromPlain := argon2.IDKey(pepper(plainPin), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	return subtle.ConstantTimeCompare(key, keyFromPlain) == 1, nil
+}
+
+func decodeArgon2id(hashedPin string) (Argon2Params, []byte, []byte, error) {
+	params := Argon2Params{}
+	parts := strings.Split(hashedPin, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, fmt.Errorf("wrong hashed pin format to check")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, fmt.Errorf("wrong hashed pin format to check")
+	}
+	if version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, fmt.Errorf("wrong hashed pin format to check")
+	}
+	if params.Memory == 0 || params.Memory > maxArgon2Memory ||
+		params.Iterations == 0 || params.Iterations > maxArgon2Iterations ||
+		params.Parallelism == 0 || params.Parallelism > maxArgon2Parallelism {
+		return params, nil, nil, fmt.Errorf("argon2 parameters %s out of range", parts[3])
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("wrong hashed pin format to check")
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("wrong hashed pin format to check")
+	}
+	if len(salt) < minArgon2SaltLength || len(key) < minArgon2KeyLength || len(key) > maxArgon2KeyLength {
+		return params, nil, nil, fmt.Errorf("argon2 salt or key length out of range")
+	}
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+	return params, salt, key, nil
+}
+
+// PinNeedsRehash reports whether a stored hash is in the legacy format or was made with a different cost
+// than PinHashParams
+func PinNeedsRehash(hashedPin string) bool {
+	if !strings.HasPrefix(hashedPin, argon2idPrefix) {
+		return true
+	}
+	params, _, _, err := decodeArgon2id(hashedPin)
+	if err != nil {
+		return true
+	}
+	return params != PinHashParams
+}
+
+// IsStrongPin accepts only 6 digit pins without 3 identical digits in a row and without
+// an ascending or descending run over the whole pin (e.g. 123456, 987654)
+func IsStrongPin(pin string) bool {
+	if len(pin) != 6 {
+		return false
+	}
+	for _, r := range pin {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	for i := 2; i < len(pin); i++ {
+		if pin[i] == pin[i-1] && pin[i] == pin[i-2] {
+			return false
+		}
+	}
+
+	ascending, descending := true, true
+	for i := 1; i < len(pin); i++ {
+		if pin[i] != pin[i-1]+1 {
+			ascending = false
+		}
+		if pin[i] != pin[i-1]-1 {
+			descending = false
+		}
+	}
+	return !ascending && !descending
+}
+
+// MaskIdentifier hides every letter and digit except the last visible ones, separators such as '-' are kept
+// so the shape stays recognisable, e.g. 568-2-90992 becomes ***-*-*0992
+func MaskIdentifier(value string, visible int) string {
+	masked := []rune(value)
+	for i := len(masked) - 1; i >= 0; i-- {
+		r := masked[i]
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			continue
+		}
+		if visible > 0 {
+			visible--
+			continue
+		}
+		masked[i] = '*'
+	}
+	return string(masked)
+}
+
+// MaskName keeps only the first letter of every word of a person's name, e.g. John Smith becomes J*** S****
+func MaskName(name string) string {
+	words := strings.Fields(name)
+	for i, word := range words {
+		runes := []rune(word)
+		words[i] = string(runes[0]) + strings.Repeat("*", len(runes)-1)
+	}
+	return strings.Join(words, " ")
+}
+
 func GenerateTokenSessionId(userId string) string {
 	randomBytes, _ := GenerateRandomBytes(32)
 	hash := sha256.New()
@@ -77,6 +266,12 @@ func GenerateTokenSessionId(userId string) string {
 	return hex.EncodeToString(sum)
 }
 
+// HashSessionToken is what gets stored for a session token, the token itself is only ever handed to the client
+func HashSessionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 func GenerateRandomBytes(n int) ([]byte, error) {
 	b := make([]byte, n)
 	_, err := rand.Read(b)
@@ -85,3 +280,20 @@ func GenerateRandomBytes(n int) ([]byte, error) {
 	}
 	return b, nil
 }
+
+// CardSecret derives a 3 digit CVV-style secret for the card number that changes every period, so a revealed secret
+// stops being useful once its period is over. It also returns the end of the current period.
+func CardSecret(key []byte, cardNumber string, at time.Time, period time.Duration) (string, time.Time) {
+	window := at.UnixNano() / int64(period)
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(cardNumber))
+	binary.Write(mac, binary.BigEndian, window)
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation as in HOTP (RFC 4226)
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%03d", code%1000), time.Unix(0, (window+1)*int64(period))
+}
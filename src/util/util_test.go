@@ -0,0 +1,61 @@
+package util
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestValidatePin_Argon2id(t *testing.T) {
+	params := PinHashParams
+	defer func() { PinHashParams = params }()
+	PinHashParams = Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+	hashedPin, err := HashPassword("135792")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok, err := ValidatePin("135792", hashedPin); err != nil || !ok {
+		t.Fatalf("expected the pin to match, got %v %v", ok, err)
+	}
+	if ok, err := ValidatePin("135793", hashedPin); err != nil || ok {
+		t.Fatalf("expected another pin not to match, got %v %v", ok, err)
+	}
+	if PinNeedsRehash(hashedPin) {
+		t.Fatal("a hash made with PinHashParams must not need a rehash")
+	}
+}
+
+func TestValidatePin_MalformedArgon2id(t *testing.T) {
+	salt := base64.RawStdEncoding.EncodeToString([]byte("0123456789abcdef"))
+	key := base64.RawStdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	hash := func(version, params, salt, key string) string {
+		return strings.Join([]string{"", "argon2id", version, params, salt, key}, "$")
+	}
+
+	cases := map[string]string{
+		"missing part":         "$argon2id$v=19$m=1024,t=1,p=1$" + salt,
+		"unsupported version":  hash("v=16", "m=1024,t=1,p=1", salt, key),
+		"malformed params":     hash("v=19", "m=1024;t=1;p=1", salt, key),
+		"zero parallelism":     hash("v=19", "m=1024,t=1,p=0", salt, key),
+		"zero iterations":      hash("v=19", "m=1024,t=0,p=1", salt, key),
+		"zero memory":          hash("v=19", "m=0,t=1,p=1", salt, key),
+		"negative memory":      hash("v=19", "m=-1,t=1,p=1", salt, key),
+		"too much memory":      hash("v=19", "m=4194305,t=1,p=1", salt, key),
+		"too many iterations":  hash("v=19", "m=1024,t=65,p=1", salt, key),
+		"too much parallelism": hash("v=19", "m=1024,t=1,p=65", salt, key),
+		"parallelism overflow": hash("v=19", "m=1024,t=1,p=256", salt, key),
+		"salt not base64":      hash("v=19", "m=1024,t=1,p=1", "!!!", key),
+		"key not base64":       hash("v=19", "m=1024,t=1,p=1", salt, "!!!"),
+		"short salt":           hash("v=19", "m=1024,t=1,p=1", base64.RawStdEncoding.EncodeToString([]byte("salt")), key),
+		"empty key":            hash("v=19", "m=1024,t=1,p=1", salt, ""),
+	}
+	for name, hashedPin := range cases {
+		if ok, err := ValidatePin("135792", hashedPin); err == nil || ok {
+			t.Errorf("%s: expected an error, got %v %v", name, ok, err)
+		}
+		if !PinNeedsRehash(hashedPin) {
+			t.Errorf("%s: expected a malformed hash to need a rehash", name)
+		}
+	}
+}